@@ -2,7 +2,6 @@ package nylas
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/mqasimca/nylas-go/applications"
@@ -14,13 +13,13 @@ func (s *ApplicationsService) GetDetails(ctx context.Context) (*applications.App
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("applications.GetDetails: %w", err)
+		return nil, newOpError("applications.GetDetails", "", "", err)
 	}
 
 	var details applications.ApplicationDetails
 	_, err = s.client.Do(req, &details)
 	if err != nil {
-		return nil, fmt.Errorf("applications.GetDetails: %w", err)
+		return nil, newOpError("applications.GetDetails", "", "", err)
 	}
 
 	return &details, nil
@@ -0,0 +1,17 @@
+package applications
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes a, keeping fields this package does not declare in Extra.
+func (a *ApplicationDetails) UnmarshalJSON(data []byte) error {
+	type alias ApplicationDetails
+	extra, err := common.UnmarshalExtra(data, (*alias)(a))
+	a.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a together with the fields kept in Extra.
+func (a ApplicationDetails) MarshalJSON() ([]byte, error) {
+	type alias ApplicationDetails
+	return common.MarshalExtra(alias(a), a.Extra)
+}
@@ -1,6 +1,8 @@
 // Package applications provides types for the Nylas Applications API.
 package applications
 
+import "encoding/json"
+
 // ApplicationDetails represents application configuration details.
 type ApplicationDetails struct {
 	ApplicationID        string                `json:"application_id"`
@@ -10,6 +12,9 @@ type ApplicationDetails struct {
 	Branding             *Branding             `json:"branding,omitempty"`
 	HostedAuthentication *HostedAuthentication `json:"hosted_authentication,omitempty"`
 	CallbackURIs         []CallbackURI         `json:"callback_uris,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // CallbackURI represents a callback/redirect URI configuration.
@@ -2,9 +2,16 @@ package nylas
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mqasimca/nylas-go/attachments"
 )
@@ -15,7 +22,7 @@ func (s *AttachmentsService) Get(ctx context.Context, grantID, attachmentID, mes
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("attachments.Get(%s): %w", attachmentID, err)
+		return nil, newOpError("attachments.Get", grantID, attachmentID, err)
 	}
 
 	q := req.URL.Query()
@@ -25,41 +32,321 @@ func (s *AttachmentsService) Get(ctx context.Context, grantID, attachmentID, mes
 	var attachment attachments.Attachment
 	_, err = s.client.Do(req, &attachment)
 	if err != nil {
-		return nil, fmt.Errorf("attachments.Get(%s): %w", attachmentID, err)
+		return nil, newOpError("attachments.Get", grantID, attachmentID, err)
 	}
 
 	return &attachment, nil
 }
 
 // Download downloads an attachment and returns the response.
+//
+// Transient failures before the body starts are retried like other requests, and
+// API errors are returned as *APIError. The caller must close Content. Use DownloadTo
+// or SaveToFile to resume interrupted transfers automatically.
 func (s *AttachmentsService) Download(ctx context.Context, grantID, attachmentID, messageID string) (*attachments.DownloadResponse, error) {
+	resp, err := s.download(ctx, grantID, attachmentID, messageID, 0)
+	if err != nil {
+		return nil, newOpError("attachments.Download", grantID, attachmentID, err)
+	}
+	return resp, nil
+}
+
+// DownloadRange downloads an attachment starting at byte offset, using an HTTP Range
+// request. If the server ignores the range the whole file is returned; check Offset
+// on the response to see where Content starts.
+func (s *AttachmentsService) DownloadRange(ctx context.Context, grantID, attachmentID, messageID string, offset int64) (*attachments.DownloadResponse, error) {
+	resp, err := s.download(ctx, grantID, attachmentID, messageID, offset)
+	if err != nil {
+		return nil, newOpError("attachments.DownloadRange", grantID, attachmentID, err)
+	}
+	return resp, nil
+}
+
+// DownloadTo writes an attachment to w, resuming with Range requests if the transfer
+// is interrupted, and returns its size and SHA-256.
+//
+// The attachment metadata is fetched first, and the number of bytes written is checked
+// against Attachment.Size; a difference is reported as ErrSizeMismatch.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	res, err := client.Attachments.DownloadTo(ctx, grantID, attachmentID, messageID, &buf)
+//	log.Printf("%s: %d bytes, sha256 %s", res.Filename, res.Size, res.SHA256)
+func (s *AttachmentsService) DownloadTo(ctx context.Context, grantID, attachmentID, messageID string, w io.Writer) (*attachments.DownloadResult, error) {
+	meta, err := s.Get(ctx, grantID, attachmentID, messageID)
+	if err != nil {
+		return nil, newOpError("attachments.DownloadTo", grantID, attachmentID, err)
+	}
+
+	h := sha256.New()
+	res := newDownloadResult(meta)
+	res.Size, res.Resumes, err = s.copyFrom(ctx, grantID, attachmentID, messageID, io.MultiWriter(w, h), 0)
+	if err == nil {
+		err = checkDownloadSize(res.Size, meta)
+	}
+	if err != nil {
+		return nil, newOpError("attachments.DownloadTo", grantID, attachmentID, err)
+	}
+
+	res.SHA256 = hex.EncodeToString(h.Sum(nil))
+	return res, nil
+}
+
+// SaveToFile downloads an attachment to path and returns its size and SHA-256.
+// If path is an existing directory, the file is saved in it under the attachment's
+// file name.
+//
+// Data is written to path + ".part" and renamed to path once the size has been checked
+// against Attachment.Size. If the download fails, the partial file is kept and the next
+// call resumes from where it stopped.
+func (s *AttachmentsService) SaveToFile(ctx context.Context, grantID, attachmentID, messageID, path string) (*attachments.DownloadResult, error) {
+	meta, err := s.Get(ctx, grantID, attachmentID, messageID)
+	if err != nil {
+		return nil, newOpError("attachments.SaveToFile", grantID, attachmentID, err)
+	}
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		name := attachments.SafeFilename(meta.Filename)
+		if name == "" {
+			name = attachmentID
+		}
+		path = filepath.Join(path, name)
+	}
+
+	res, err := s.saveToFile(ctx, grantID, attachmentID, messageID, path, meta)
+	if err != nil {
+		return nil, newOpError("attachments.SaveToFile", grantID, attachmentID, err)
+	}
+	return res, nil
+}
+
+func (s *AttachmentsService) saveToFile(ctx context.Context, grantID, attachmentID, messageID, path string, meta *attachments.Attachment) (*attachments.DownloadResult, error) {
+	partPath := path + ".part"
+	f, err := os.OpenFile(partPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	// Hash what an earlier attempt already saved; this also leaves f positioned at its end.
+	h := sha256.New()
+	offset, err := io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+	if meta.Size > 0 && offset > int64(meta.Size) {
+		if err := f.Truncate(0); err != nil {
+			return nil, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		h.Reset()
+		offset = 0
+	}
+
+	res := newDownloadResult(meta)
+	res.Size = offset
+	if meta.Size <= 0 || offset < int64(meta.Size) {
+		res.Size, res.Resumes, err = s.copyFrom(ctx, grantID, attachmentID, messageID, io.MultiWriter(f, h), offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := checkDownloadSize(res.Size, meta); err != nil {
+		return nil, err
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(partPath, path); err != nil {
+		return nil, err
+	}
+
+	res.SHA256 = hex.EncodeToString(h.Sum(nil))
+	res.Path = path
+	return res, nil
+}
+
+// copyFrom streams the attachment from offset into w. A read error mid-transfer is
+// followed by a Range request for the rest, up to MaxRetries times. It returns the
+// offset reached and the number of resumes.
+func (s *AttachmentsService) copyFrom(ctx context.Context, grantID, attachmentID, messageID string, w io.Writer, offset int64) (int64, int, error) {
+	tw := &trackingWriter{w: w}
+	for resumes := 0; ; resumes++ {
+		resp, err := s.download(ctx, grantID, attachmentID, messageID, offset)
+		if err != nil {
+			return offset, resumes, err
+		}
+
+		// The server ignored the Range header; skip what we already have.
+		if resp.Offset < offset {
+			if _, err := io.CopyN(io.Discard, resp.Content, offset-resp.Offset); err != nil {
+				_ = resp.Content.Close()
+				return offset, resumes, err
+			}
+		}
+
+		n, err := io.Copy(tw, resp.Content)
+		_ = resp.Content.Close()
+		offset += n
+		if err == nil {
+			return offset, resumes, nil
+		}
+		if tw.err != nil || ctx.Err() != nil || resumes >= s.client.MaxRetries {
+			return offset, resumes, err
+		}
+		time.Sleep(s.client.RetryWait * time.Duration(1<<resumes))
+	}
+}
+
+// download requests the attachment data from offset onwards.
+func (s *AttachmentsService) download(ctx context.Context, grantID, attachmentID, messageID string, offset int64) (*attachments.DownloadResponse, error) {
 	path := fmt.Sprintf("/v3/grants/%s/attachments/%s/download", grantID, attachmentID)
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("attachments.Download(%s): %w", attachmentID, err)
+		return nil, err
 	}
 
 	q := req.URL.Query()
 	q.Set("message_id", messageID)
 	req.URL.RawQuery = q.Encode()
+	req.Header.Set("Accept", "*/*")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
 
-	resp, err := s.client.HTTPClient.Do(req)
+	resp, err := s.client.roundTrip(req)
 	if err != nil {
-		return nil, fmt.Errorf("attachments.Download(%s): %w", attachmentID, err)
+		return nil, err
 	}
 
+	s.client.updateRateLimits(resp)
+
+	// A range starting at the end of the file is unsatisfiable; when the server
+	// confirms that is the file's size, everything has been downloaded already.
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0 {
+		if _, total := parseContentRange(resp.Header.Get("Content-Range")); total == offset {
+			_ = resp.Body.Close()
+			return &attachments.DownloadResponse{Content: http.NoBody, Offset: offset, TotalSize: total}, nil
+		}
+	}
 	if resp.StatusCode >= 400 {
-		_ = resp.Body.Close()
-		return nil, fmt.Errorf("attachments.Download(%s): status %d", attachmentID, resp.StatusCode)
+		defer func() { _ = resp.Body.Close() }()
+		return nil, parseError(resp)
 	}
 
-	size, _ := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	out := &attachments.DownloadResponse{
+		Content:            resp.Body,
+		ContentType:        resp.Header.Get("Content-Type"),
+		ContentDisposition: resp.Header.Get("Content-Disposition"),
+		Size:               max(resp.ContentLength, 0),
+	}
+	_, out.Filename = attachments.ParseContentDisposition(out.ContentDisposition)
+
+	if resp.StatusCode == http.StatusPartialContent {
+		out.Offset, out.TotalSize = parseContentRange(resp.Header.Get("Content-Range"))
+	} else {
+		out.TotalSize = out.Size
+	}
+
+	return out, nil
+}
+
+// parseContentRange returns the first byte position and complete length from a
+// "bytes first-last/complete" Content-Range header. Unknown values are 0.
+func parseContentRange(header string) (offset, total int64) {
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, 0
+	}
+	rng, complete, _ := strings.Cut(spec, "/")
+	first, _, _ := strings.Cut(rng, "-")
+	offset, _ = strconv.ParseInt(first, 10, 64)
+	total, _ = strconv.ParseInt(complete, 10, 64)
+	return offset, total
+}
+
+func newDownloadResult(meta *attachments.Attachment) *attachments.DownloadResult {
+	return &attachments.DownloadResult{Filename: meta.Filename, ContentType: meta.ContentType}
+}
 
-	return &attachments.DownloadResponse{
-		Content:     resp.Body,
-		ContentType: resp.Header.Get("Content-Type"),
-		Filename:    resp.Header.Get("Content-Disposition"),
-		Size:        size,
-	}, nil
+// checkDownloadSize compares the bytes written with the size in the attachment metadata.
+func checkDownloadSize(written int64, meta *attachments.Attachment) error {
+	if meta.Size > 0 && written != int64(meta.Size) {
+		return fmt.Errorf("%w: got %d bytes, want %d", ErrSizeMismatch, written, meta.Size)
+	}
+	return nil
+}
+
+// attachmentReader downloads an attachment on its first Read and streams it,
+// closing the response at EOF or on error. Once read to EOF, further reads fail
+// with ErrAttachmentConsumed.
+type attachmentReader struct {
+	s            *AttachmentsService
+	ctx          context.Context
+	grantID      string
+	attachmentID string
+	messageID    string
+	filename     string
+	size         int64
+
+	body io.ReadCloser
+	n    int64
+	err  error
+}
+
+func (r *attachmentReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.body == nil {
+		resp, err := r.s.download(r.ctx, r.grantID, r.attachmentID, r.messageID, 0)
+		if err != nil {
+			r.err = newOpError("attachments.Download", r.grantID, r.attachmentID, err)
+			return 0, r.err
+		}
+		r.body = resp.Content
+	}
+	n, err := r.body.Read(p)
+	r.n += int64(n)
+	if err == io.EOF && r.size > 0 && r.n != r.size {
+		err = fmt.Errorf("%w: got %d bytes, want %d", ErrSizeMismatch, r.n, r.size)
+	}
+	if err != nil {
+		_ = r.body.Close()
+		r.err = err
+		if err == io.EOF {
+			r.err = fmt.Errorf("%w: %q was already sent", ErrAttachmentConsumed, r.filename)
+		}
+	}
+	return n, err
+}
+
+// Close releases the download if it was started and not read to the end.
+func (r *attachmentReader) Close() error {
+	if r.err == nil {
+		r.err = fmt.Errorf("%w: %q was closed", ErrAttachmentConsumed, r.filename)
+		if r.body != nil {
+			return r.body.Close()
+		}
+	}
+	return nil
+}
+
+// trackingWriter records the first write error so it can be told apart from read errors.
+type trackingWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	n, err := t.w.Write(p)
+	if err != nil && t.err == nil {
+		t.err = err
+	}
+	return n, err
 }
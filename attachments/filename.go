@@ -0,0 +1,46 @@
+package attachments
+
+import (
+	"mime"
+	"path"
+	"strings"
+)
+
+// ParseContentDisposition returns the disposition type and file name from a
+// Content-Disposition header. The RFC 5987 filename* form takes precedence over
+// filename, RFC 2047 encoded words are decoded, and any directory part is removed
+// so the name is safe to join to a directory. Unparsable headers yield empty strings.
+//
+// Example:
+//
+//	disposition, name := attachments.ParseContentDisposition(`attachment; filename*=UTF-8''r%C3%A9sum%C3%A9.pdf`)
+//	// disposition == "attachment", name == "résumé.pdf"
+func ParseContentDisposition(header string) (disposition, filename string) {
+	disposition, params, err := mime.ParseMediaType(header)
+	if err != nil {
+		return "", ""
+	}
+	name := params["filename"]
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(name); err == nil {
+		name = decoded
+	}
+	return disposition, SafeFilename(name)
+}
+
+// SafeFilename reduces name to a bare file name: directory parts from either path
+// separator are removed, control characters are dropped, and "", "." and ".." become "".
+func SafeFilename(name string) string {
+	name = strings.ReplaceAll(name, `\`, "/")
+	name = path.Base(name)
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == ".." || name == "/" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,30 @@
+package attachments
+
+import "testing"
+
+func TestParseContentDisposition(t *testing.T) {
+	tests := []struct {
+		header          string
+		wantDisposition string
+		wantFilename    string
+	}{
+		{`attachment; filename="report.pdf"`, "attachment", "report.pdf"},
+		{`attachment; filename=plain.txt`, "attachment", "plain.txt"},
+		{`attachment; filename="fallback.pdf"; filename*=UTF-8''r%C3%A9sum%C3%A9.pdf`, "attachment", "résumé.pdf"},
+		{`inline; filename="=?UTF-8?B?0L7RgtGH0LXRgi5wZGY=?="`, "inline", "отчет.pdf"},
+		{`attachment; filename="../../etc/passwd"`, "attachment", "passwd"},
+		{`attachment; filename="C:\\Users\\me\\evil.exe"`, "attachment", "evil.exe"},
+		{`attachment; filename=".."`, "attachment", ""},
+		{`attachment`, "attachment", ""},
+		{`not a; valid=header=`, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			disposition, filename := ParseContentDisposition(tt.header)
+			if disposition != tt.wantDisposition || filename != tt.wantFilename {
+				t.Errorf("ParseContentDisposition() = %q, %q, want %q, %q", disposition, filename, tt.wantDisposition, tt.wantFilename)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package attachments
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes a, keeping fields this package does not declare in Extra.
+func (a *Attachment) UnmarshalJSON(data []byte) error {
+	type alias Attachment
+	extra, err := common.UnmarshalExtra(data, (*alias)(a))
+	a.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a together with the fields kept in Extra.
+func (a Attachment) MarshalJSON() ([]byte, error) {
+	type alias Attachment
+	return common.MarshalExtra(alias(a), a.Extra)
+}
@@ -1,6 +1,9 @@
 package attachments
 
-import "io"
+import (
+	"encoding/json"
+	"io"
+)
 
 // Attachment represents an email attachment in the Nylas API.
 type Attachment struct {
@@ -20,6 +23,9 @@ type Attachment struct {
 	ContentDisposition string `json:"content_disposition,omitempty"`
 	// IsInline indicates whether this attachment is embedded in the message body.
 	IsInline bool `json:"is_inline,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // DownloadResponse represents the response from downloading an attachment.
@@ -28,8 +34,32 @@ type DownloadResponse struct {
 	Content io.ReadCloser
 	// ContentType is the MIME type of the attachment.
 	ContentType string
-	// Filename is the original filename.
+	// Filename is the file name from the Content-Disposition header, without any
+	// directory part. See ParseContentDisposition.
 	Filename string
-	// Size is the file size in bytes.
+	// ContentDisposition is the raw Content-Disposition header.
+	ContentDisposition string
+	// Size is the number of bytes in Content, or 0 if unknown.
+	Size int64
+	// Offset is the position in the file where Content starts. It is 0 unless a
+	// ranged download was requested and the server honoured it.
+	Offset int64
+	// TotalSize is the size of the whole file, or 0 if unknown.
+	TotalSize int64
+}
+
+// DownloadResult describes an attachment written by DownloadTo or SaveToFile.
+type DownloadResult struct {
+	// Filename is the attachment's file name.
+	Filename string
+	// ContentType is the MIME type of the attachment.
+	ContentType string
+	// Size is the number of bytes written.
 	Size int64
+	// SHA256 is the hex-encoded SHA-256 of the data written.
+	SHA256 string
+	// Path is the file the attachment was saved to. Only set by SaveToFile.
+	Path string
+	// Resumes is the number of times an interrupted download was resumed.
+	Resumes int
 }
@@ -1,10 +1,19 @@
 package nylas
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -116,7 +125,7 @@ func TestAttachmentsService_Download(t *testing.T) {
 			}))
 			defer srv.Close()
 
-			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(srv.URL))
+			client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(srv.URL), WithMaxRetries(0))
 			resp, err := client.Attachments.Download(context.Background(), "grant-123", "attach-1", "msg-123")
 
 			if (err != nil) != tt.wantErr {
@@ -135,3 +144,160 @@ func TestAttachmentsService_Download(t *testing.T) {
 		})
 	}
 }
+
+// newDownloadServer serves attachment metadata and data. The first failFirst data
+// responses are cut off half way through; Range requests are honoured.
+func newDownloadServer(t *testing.T, data string, failFirst int) (*Client, *[]string) {
+	t.Helper()
+	var ranges []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/download") {
+			fmt.Fprintf(w, `{"data": {"id": "attach-1", "filename": "report.pdf", "content_type": "application/pdf", "size": %d}}`, len(data))
+			return
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		start := 0
+		if rng := r.Header.Get("Range"); rng != "" {
+			_, _ = fmt.Sscanf(rng, "bytes=%d-", &start)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(data)-1, len(data)))
+		}
+		body := data[start:]
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Header().Set("Content-Disposition", `attachment; filename="report.pdf"`)
+		if start > 0 {
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		if len(ranges) <= failFirst {
+			_, _ = w.Write([]byte(body[:len(body)/2]))
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	client.MaxRetries = 2
+	client.RetryWait = 0
+	return client, &ranges
+}
+
+func TestAttachmentsService_Download_Errors(t *testing.T) {
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "attachment not found", "type": "not_found"}`))
+	})
+	client.MaxRetries = 1
+	client.RetryWait = 0
+
+	_, err := client.Attachments.Download(context.Background(), "grant-123", "attach-1", "msg-123")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || !errors.Is(err, ErrNotFound) {
+		t.Errorf("Download() error = %v, want *APIError 404", err)
+	}
+	if calls != 2 {
+		t.Errorf("server calls = %d, want 2 (one retry)", calls)
+	}
+}
+
+func TestAttachmentsService_DownloadTo(t *testing.T) {
+	data := strings.Repeat("0123456789", 100)
+	client, ranges := newDownloadServer(t, data, 1)
+
+	var buf bytes.Buffer
+	res, err := client.Attachments.DownloadTo(context.Background(), "grant-123", "attach-1", "msg-123", &buf)
+	if err != nil {
+		t.Fatalf("DownloadTo() error = %v", err)
+	}
+	if buf.String() != data {
+		t.Fatalf("downloaded %d bytes, want the original %d", buf.Len(), len(data))
+	}
+	sum := sha256.Sum256([]byte(data))
+	if res.SHA256 != hex.EncodeToString(sum[:]) || res.Size != int64(len(data)) || res.Resumes != 1 {
+		t.Errorf("result = %+v", res)
+	}
+	if len(*ranges) != 2 || (*ranges)[0] != "" || (*ranges)[1] != "bytes=500-" {
+		t.Errorf("Range headers = %q, want a resume from byte 500", *ranges)
+	}
+}
+
+func TestAttachmentsService_DownloadTo_SizeMismatch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/download") {
+			_, _ = w.Write([]byte(`{"data": {"id": "attach-1", "size": 100}}`))
+			return
+		}
+		_, _ = w.Write([]byte("short"))
+	})
+
+	_, err := client.Attachments.DownloadTo(context.Background(), "grant-123", "attach-1", "msg-123", io.Discard)
+	if !errors.Is(err, ErrSizeMismatch) {
+		t.Errorf("DownloadTo() error = %v, want ErrSizeMismatch", err)
+	}
+}
+
+func TestAttachmentsService_SaveToFile(t *testing.T) {
+	data := strings.Repeat("abcdefghij", 100)
+	client, ranges := newDownloadServer(t, data, 0)
+	dir := t.TempDir()
+
+	// An earlier attempt left the first 300 bytes behind.
+	if err := os.WriteFile(filepath.Join(dir, "report.pdf.part"), []byte(data[:300]), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Attachments.SaveToFile(context.Background(), "grant-123", "attach-1", "msg-123", dir)
+	if err != nil {
+		t.Fatalf("SaveToFile() error = %v", err)
+	}
+	want := filepath.Join(dir, "report.pdf")
+	if res.Path != want {
+		t.Errorf("Path = %q, want %q", res.Path, want)
+	}
+	got, err := os.ReadFile(want)
+	if err != nil || string(got) != data {
+		t.Fatalf("saved file = %d bytes, %v", len(got), err)
+	}
+	sum := sha256.Sum256([]byte(data))
+	if res.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("SHA256 = %s, want hash of the whole file", res.SHA256)
+	}
+	if len(*ranges) != 1 || (*ranges)[0] != "bytes=300-" {
+		t.Errorf("Range headers = %q, want a resume from the partial file", *ranges)
+	}
+	if _, err := os.Stat(want + ".part"); !os.IsNotExist(err) {
+		t.Errorf("partial file still exists: %v", err)
+	}
+}
+
+func TestAttachmentsService_SaveToFile_CompletePart(t *testing.T) {
+	data := "complete file"
+	var ranges []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/download") {
+			// The size is unknown, so a complete partial file cannot be recognised.
+			_, _ = w.Write([]byte(`{"data": {"id": "attach-1", "filename": "notes.txt"}}`))
+			return
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(data)))
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+	})
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt.part"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Attachments.SaveToFile(context.Background(), "grant-123", "attach-1", "msg-123", dir)
+	if err != nil {
+		t.Fatalf("SaveToFile() error = %v", err)
+	}
+	if got, err := os.ReadFile(res.Path); err != nil || string(got) != data || res.Size != int64(len(data)) {
+		t.Errorf("saved file = %q, %v, result = %+v", got, err, res)
+	}
+	if len(ranges) != 1 || ranges[0] != fmt.Sprintf("bytes=%d-", len(data)) {
+		t.Errorf("Range headers = %q", ranges)
+	}
+}
@@ -0,0 +1,384 @@
+package nylas
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Audit result statuses.
+const (
+	AuditStatusSuccess = "success"
+	AuditStatusError   = "error"
+)
+
+// DefaultAuditRedactFields are JSON keys whose values are always redacted from audited bodies.
+var DefaultAuditRedactFields = []string{
+	"client_secret",
+	"access_token",
+	"refresh_token",
+	"code",
+	"code_verifier",
+	"password",
+	"webhook_secret",
+	"settings",
+	"content",
+}
+
+// redactedValue replaces redacted fields in audited bodies.
+const redactedValue = "[REDACTED]"
+
+// AuditRecord describes a single mutating API call. Calls that take a body but
+// change nothing, such as free/busy, availability and smart compose, are not
+// audited.
+type AuditRecord struct {
+	// Time is when the call started.
+	Time time.Time `json:"time"`
+	// Operation identifies the call as "METHOD /path/template",
+	// e.g. "DELETE /v3/grants/{grant_id}/messages/{id}".
+	Operation string `json:"operation"`
+	// Method is the HTTP method.
+	Method string `json:"method"`
+	// Path is the request path with IDs filled in.
+	Path string `json:"path"`
+	// Resource is the resource type, e.g. "messages" or "events".
+	Resource string `json:"resource,omitempty"`
+	// GrantID is the grant the call acted on behalf of, if any.
+	GrantID string `json:"grant_id,omitempty"`
+	// ResourceID is the ID of the resource acted on, if any.
+	ResourceID string `json:"resource_id,omitempty"`
+	// BodySHA256 is the hex SHA-256 of the request body, if the request had one.
+	// Streamed bodies, such as large multipart uploads, are hashed as they are sent,
+	// so it is empty if the call failed before the whole body was sent.
+	BodySHA256 string `json:"body_sha256,omitempty"`
+	// Body is the request body with sensitive fields redacted. Only set when enabled with WithAuditBodies.
+	Body json.RawMessage `json:"body,omitempty"`
+	// Status is AuditStatusSuccess or AuditStatusError.
+	Status string `json:"status"`
+	// StatusCode is the HTTP status code, or 0 if no response was received.
+	StatusCode int `json:"status_code,omitempty"`
+	// Error is the error message for failed calls.
+	Error string `json:"error,omitempty"`
+	// RequestID is the Nylas request ID from the response.
+	RequestID string `json:"request_id,omitempty"`
+	// DryRun is true if the call was intercepted in dry-run mode.
+	DryRun bool `json:"dry_run,omitempty"`
+	// Duration is how long the call took, including retries.
+	Duration time.Duration `json:"duration_ns"`
+
+	// streamed hashes a body that cannot be replayed while it is sent.
+	streamed *hashingBody
+}
+
+// AuditSink receives a record for every mutating API call.
+// Implementations must be safe for concurrent use. Errors returned by Audit do not
+// affect the API call, which has already completed; sinks are responsible for
+// surfacing their own failures.
+type AuditSink interface {
+	Audit(ctx context.Context, rec *AuditRecord) error
+}
+
+// AuditSinkFunc adapts a function to the AuditSink interface.
+type AuditSinkFunc func(ctx context.Context, rec *AuditRecord) error
+
+// Audit calls f(ctx, rec).
+func (f AuditSinkFunc) Audit(ctx context.Context, rec *AuditRecord) error {
+	return f(ctx, rec)
+}
+
+// WithAuditSink sends a structured record of every mutating call (POST, PUT, PATCH, DELETE)
+// to sink, including calls intercepted in dry-run mode. Read-only POSTs are not audited.
+func WithAuditSink(sink AuditSink) Option {
+	return func(c *Client) { c.auditSink = sink }
+}
+
+// WithAuditBodies includes request bodies in audit records. Values of the fields in
+// DefaultAuditRedactFields and redactFields are replaced with "[REDACTED]" at any depth.
+func WithAuditBodies(redactFields ...string) Option {
+	return func(c *Client) {
+		c.auditBodies = true
+		c.auditRedact = make(map[string]bool, len(DefaultAuditRedactFields)+len(redactFields))
+		for _, f := range DefaultAuditRedactFields {
+			c.auditRedact[f] = true
+		}
+		for _, f := range redactFields {
+			c.auditRedact[f] = true
+		}
+	}
+}
+
+// WriterAuditSink writes audit records as JSON Lines to an io.Writer.
+type WriterAuditSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriterAuditSink returns a sink that writes one JSON object per line to w.
+func NewWriterAuditSink(w io.Writer) *WriterAuditSink {
+	return &WriterAuditSink{enc: json.NewEncoder(w)}
+}
+
+// Audit writes rec as a single JSON line.
+func (s *WriterAuditSink) Audit(_ context.Context, rec *AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(rec)
+}
+
+// FileAuditSink appends audit records as JSON Lines to a file.
+type FileAuditSink struct {
+	*WriterAuditSink
+	f *os.File
+
+	errMu sync.Mutex
+	err   error
+}
+
+// NewFileAuditSink opens (or creates) path for appending and returns a sink writing to it.
+// Call Close when done.
+func NewFileAuditSink(path string) (*FileAuditSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileAuditSink{WriterAuditSink: NewWriterAuditSink(f), f: f}, nil
+}
+
+// Audit writes rec as a single JSON line. The first write error is retained and returned by Err.
+func (s *FileAuditSink) Audit(ctx context.Context, rec *AuditRecord) error {
+	err := s.WriterAuditSink.Audit(ctx, rec)
+	if err != nil {
+		s.errMu.Lock()
+		if s.err == nil {
+			s.err = err
+		}
+		s.errMu.Unlock()
+	}
+	return err
+}
+
+// Err returns the first error encountered while writing records, if any.
+func (s *FileAuditSink) Err() error {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	return s.err
+}
+
+// Close syncs and closes the underlying file.
+func (s *FileAuditSink) Close() error {
+	if err := s.f.Sync(); err != nil {
+		_ = s.f.Close()
+		return err
+	}
+	return s.f.Close()
+}
+
+// newAuditRecord starts an audit record for req before it is sent.
+func (c *Client) newAuditRecord(req *http.Request) *AuditRecord {
+	info := describePath(req.URL.Path)
+	rec := &AuditRecord{
+		Time:       time.Now(),
+		Operation:  req.Method + " " + info.template,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Resource:   info.resource,
+		GrantID:    info.grantID,
+		ResourceID: info.resourceID,
+		DryRun:     c.isDryRun(req),
+	}
+
+	if body := peekBody(req); len(body) > 0 {
+		sum := sha256.Sum256(body)
+		rec.BodySHA256 = hex.EncodeToString(sum[:])
+		if c.auditBodies {
+			rec.Body = redactJSON(body, c.auditRedact)
+		}
+	} else if req.Body != nil && req.GetBody == nil {
+		rec.streamed = &hashingBody{ReadCloser: req.Body, h: sha256.New()}
+		req.Body = rec.streamed
+	}
+	return rec
+}
+
+// finishAuditRecord fills in the result of the call and delivers rec to the sink.
+func (c *Client) finishAuditRecord(req *http.Request, rec *AuditRecord, resp *http.Response, err error) {
+	rec.Duration = time.Since(rec.Time)
+	rec.Status = AuditStatusSuccess
+	if rec.streamed != nil {
+		rec.BodySHA256 = rec.streamed.sum()
+	}
+
+	switch {
+	case err != nil:
+		rec.Status = AuditStatusError
+		rec.Error = err.Error()
+	case resp.StatusCode >= 400:
+		rec.Status = AuditStatusError
+		rec.StatusCode = resp.StatusCode
+		rec.RequestID = resp.Header.Get("X-Request-Id")
+		// Peek at the error body without consuming it for parseError.
+		body, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		var apiErr APIError
+		if readErr == nil && json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			rec.Error = apiErr.Message
+		}
+	default:
+		rec.StatusCode = resp.StatusCode
+		rec.RequestID = resp.Header.Get("X-Request-Id")
+	}
+
+	_ = c.auditSink.Audit(req.Context(), rec)
+}
+
+// hashingBody hashes a streamed request body as the transport reads it.
+type hashingBody struct {
+	io.ReadCloser
+
+	mu   sync.Mutex
+	h    hash.Hash
+	done bool
+}
+
+func (b *hashingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.mu.Lock()
+	b.h.Write(p[:n])
+	b.done = b.done || err == io.EOF
+	b.mu.Unlock()
+	return n, err
+}
+
+// sum returns the hex SHA-256 of the body, or "" if it was not read to the end.
+func (b *hashingBody) sum() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.done {
+		return ""
+	}
+	return hex.EncodeToString(b.h.Sum(nil))
+}
+
+// peekBody returns a copy of the request body without consuming it.
+// Bodies that cannot be replayed are not read.
+func peekBody(req *http.Request) []byte {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = rc.Close() }()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// redactJSON replaces the values of redacted keys at any depth. Bodies that are not
+// valid JSON are omitted entirely.
+func redactJSON(body []byte, redact map[string]bool) json.RawMessage {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+	out, err := json.Marshal(redactValue(v, redact))
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+func redactValue(v any, redact map[string]bool) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, inner := range val {
+			if redact[k] {
+				val[k] = redactedValue
+				continue
+			}
+			val[k] = redactValue(inner, redact)
+		}
+	case []any:
+		for i, inner := range val {
+			val[i] = redactValue(inner, redact)
+		}
+	}
+	return v
+}
+
+// pathInfo describes the resource addressed by an API path.
+type pathInfo struct {
+	template   string
+	resource   string
+	grantID    string
+	resourceID string
+}
+
+// pathCollections are path segments that name resource collections.
+var pathCollections = map[string]bool{
+	"v3": true, "grants": true, "applications": true, "redirect-uris": true, "calendars": true,
+	"connect": true, "connectors": true, "creds": true, "providers": true, "scheduling": true,
+	"sessions": true, "webhooks": true, "attachments": true, "contacts": true, "groups": true,
+	"drafts": true, "events": true, "folders": true, "messages": true, "schedules": true,
+	"notetakers": true, "configurations": true, "threads": true, "bookings": true,
+}
+
+// pathActions are path segments that name actions on a collection or resource.
+var pathActions = map[string]bool{
+	"send": true, "clean": true, "send-rsvp": true, "import": true, "download": true,
+	"cancel": true, "leave": true, "history": true, "media": true, "reschedule": true,
+	"rotate-secret": true, "availability": true, "free-busy": true, "detect": true,
+	"custom": true, "revoke": true, "token": true, "tokeninfo": true, "smart-compose": true,
+	"ip-addresses": true,
+}
+
+// describePath extracts the grant, resource type and resource ID from an API path and
+// builds a template with the IDs replaced by placeholders.
+func describePath(path string) pathInfo {
+	var info pathInfo
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	tmpl := make([]string, len(segs))
+
+	lastCollection := ""
+	for i, seg := range segs {
+		switch {
+		case pathCollections[seg]:
+			tmpl[i] = seg
+			if seg != "v3" && seg != "grants" {
+				lastCollection = seg
+			}
+		case pathActions[seg]:
+			tmpl[i] = seg
+		case i > 0 && segs[i-1] == "grants":
+			tmpl[i] = "{grant_id}"
+			info.grantID = seg
+		default:
+			tmpl[i] = "{id}"
+			info.resourceID = seg
+			info.resource = lastCollection
+		}
+	}
+	if info.resource == "" {
+		info.resource = lastCollection
+		if info.resource == "" && info.grantID != "" {
+			info.resource = "grants"
+			info.resourceID = info.grantID
+		}
+	}
+
+	info.template = "/" + strings.Join(tmpl, "/")
+	return info
+}
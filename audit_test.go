@@ -0,0 +1,214 @@
+package nylas
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/calendars"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+type memoryAuditSink struct {
+	mu      sync.Mutex
+	records []*AuditRecord
+}
+
+func (s *memoryAuditSink) Audit(_ context.Context, rec *AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, rec)
+	return nil
+}
+
+func TestAudit_MutatingCalls(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-"+r.Method)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(404)
+			_, _ = w.Write([]byte(`{"message": "message not found", "type": "not_found_error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"id": "msg-1"}, "request_id": "req-1"}`))
+	})
+	sink := &memoryAuditSink{}
+	WithAuditSink(sink)(client)
+	WithAuditBodies("subject")(client)
+	ctx := context.Background()
+
+	_, err := client.Messages.Send(ctx, "grant-123", &messages.SendRequest{
+		To:          []messages.Participant{{Email: "to@example.com"}},
+		Subject:     "Contract",
+		Attachments: []messages.AttachmentRequest{{Filename: "a.pdf", Content: "c2VjcmV0"}},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if _, err := client.Messages.Get(ctx, "grant-123", "msg-1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if err := client.Messages.Delete(ctx, "grant-123", "msg-404"); err == nil {
+		t.Fatal("Delete() expected error")
+	}
+
+	if len(sink.records) != 2 {
+		t.Fatalf("records = %d, want 2 (GET is not audited)", len(sink.records))
+	}
+
+	send := sink.records[0]
+	if send.Operation != "POST /v3/grants/{grant_id}/messages/send" || send.GrantID != "grant-123" || send.Resource != "messages" {
+		t.Errorf("send record = %+v", send)
+	}
+	if send.Status != AuditStatusSuccess || send.StatusCode != 200 || send.RequestID != "req-POST" {
+		t.Errorf("send result = %s %d %s", send.Status, send.StatusCode, send.RequestID)
+	}
+	if len(send.BodySHA256) != 64 {
+		t.Errorf("BodySHA256 = %q", send.BodySHA256)
+	}
+	body := string(send.Body)
+	if strings.Contains(body, "Contract") || strings.Contains(body, "c2VjcmV0") || !strings.Contains(body, "to@example.com") {
+		t.Errorf("redacted body = %s", body)
+	}
+
+	del := sink.records[1]
+	if del.Operation != "DELETE /v3/grants/{grant_id}/messages/{id}" || del.ResourceID != "msg-404" {
+		t.Errorf("delete record = %+v", del)
+	}
+	if del.Status != AuditStatusError || del.StatusCode != 404 || del.Error != "message not found" {
+		t.Errorf("delete result = %s %d %q", del.Status, del.StatusCode, del.Error)
+	}
+}
+
+func TestAudit_DryRun(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("dry-run request reached server")
+	})
+	sink := &memoryAuditSink{}
+	WithAuditSink(sink)(client)
+	WithDryRun()(client)
+
+	if err := client.Messages.Delete(context.Background(), "grant-123", "msg-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if len(sink.records) != 1 || !sink.records[0].DryRun || sink.records[0].Body != nil {
+		t.Errorf("records = %+v, want one dry-run record without body", sink.records)
+	}
+}
+
+func TestAudit_StreamedAndReadOnly(t *testing.T) {
+	var sent string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.HasSuffix(r.URL.Path, "/free-busy") {
+			_, _ = w.Write([]byte(`{"data": []}`))
+			return
+		}
+		sum := sha256.Sum256(body)
+		sent = hex.EncodeToString(sum[:])
+		_, _ = w.Write([]byte(`{"data": {"id": "msg-1"}}`))
+	})
+	sink := &memoryAuditSink{}
+	WithAuditSink(sink)(client)
+	ctx := context.Background()
+
+	_, err := client.Messages.Send(ctx, "grant-123", &messages.SendRequest{
+		To:          []messages.Participant{{Email: "to@example.com"}},
+		Attachments: []messages.AttachmentRequest{{Filename: "a.bin", Reader: strings.NewReader("streamed data")}},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if _, err := client.Calendars.FreeBusy(ctx, "grant-123", &calendars.FreeBusyRequest{StartTime: 1700000000, EndTime: 1700003600, Emails: []string{"a@example.com"}}); err != nil {
+		t.Fatalf("FreeBusy() error = %v", err)
+	}
+
+	if len(sink.records) != 1 {
+		t.Fatalf("records = %d, want 1 (free/busy is read-only)", len(sink.records))
+	}
+	if rec := sink.records[0]; sent == "" || rec.BodySHA256 != sent {
+		t.Errorf("BodySHA256 = %q, want hash of the sent body %q", rec.BodySHA256, sent)
+	}
+}
+
+func TestWriterAuditSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterAuditSink(&buf)
+	_ = sink.Audit(context.Background(), &AuditRecord{Operation: "POST /v3/webhooks", Status: AuditStatusSuccess})
+	_ = sink.Audit(context.Background(), &AuditRecord{Operation: "DELETE /v3/webhooks/{id}", Status: AuditStatusError})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %d, want 2", len(lines))
+	}
+	var rec AuditRecord
+	if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil || rec.Operation != "DELETE /v3/webhooks/{id}" {
+		t.Errorf("line 2 = %s, err = %v", lines[1], err)
+	}
+}
+
+func TestFileAuditSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	for i := 0; i < 2; i++ {
+		sink, err := NewFileAuditSink(path)
+		if err != nil {
+			t.Fatalf("NewFileAuditSink() error = %v", err)
+		}
+		_ = sink.Audit(context.Background(), &AuditRecord{Operation: "POST /v3/grants/{grant_id}/drafts"})
+		if err := sink.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		if sink.Err() != nil {
+			t.Errorf("Err() = %v", sink.Err())
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	count := 0
+	for scanner := bufio.NewScanner(f); scanner.Scan(); {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("lines = %d, want 2 (file is appended)", count)
+	}
+}
+
+func TestDescribePath(t *testing.T) {
+	tests := []struct {
+		path       string
+		template   string
+		resource   string
+		grantID    string
+		resourceID string
+	}{
+		{"/v3/grants/g1/messages/send", "/v3/grants/{grant_id}/messages/send", "messages", "g1", ""},
+		{"/v3/grants/g1/events/e1/send-rsvp", "/v3/grants/{grant_id}/events/{id}/send-rsvp", "events", "g1", "e1"},
+		{"/v3/grants/g1/messages/schedules/s1", "/v3/grants/{grant_id}/messages/schedules/{id}", "schedules", "g1", "s1"},
+		{"/v3/webhooks/rotate-secret/w1", "/v3/webhooks/rotate-secret/{id}", "webhooks", "", "w1"},
+		{"/v3/scheduling/configurations/c1/bookings/b1/cancel", "/v3/scheduling/configurations/{id}/bookings/{id}/cancel", "bookings", "", "b1"},
+		{"/v3/grants/g1", "/v3/grants/{grant_id}", "grants", "g1", "g1"},
+		{"/v3/connectors/google/creds", "/v3/connectors/{id}/creds", "connectors", "", "google"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := describePath(tt.path)
+			if got.template != tt.template || got.resource != tt.resource || got.grantID != tt.grantID || got.resourceID != tt.resourceID {
+				t.Errorf("describePath() = %+v", got)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package nylas
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
 
@@ -153,12 +152,12 @@ func (s *AuthService) ExchangeCodeForToken(ctx context.Context, req *auth.CodeEx
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, path, req)
 	if err != nil {
-		return nil, fmt.Errorf("auth.ExchangeCodeForToken: %w", err)
+		return nil, newOpError("auth.ExchangeCodeForToken", "", "", err)
 	}
 
 	var resp auth.TokenExchangeResponse
 	if err := s.client.DoRaw(httpReq, &resp); err != nil {
-		return nil, fmt.Errorf("auth.ExchangeCodeForToken: %w", err)
+		return nil, newOpError("auth.ExchangeCodeForToken", "", "", err)
 	}
 
 	return &resp, nil
@@ -189,12 +188,12 @@ func (s *AuthService) RefreshAccessToken(ctx context.Context, req *auth.RefreshT
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, path, req)
 	if err != nil {
-		return nil, fmt.Errorf("auth.RefreshAccessToken: %w", err)
+		return nil, newOpError("auth.RefreshAccessToken", "", "", err)
 	}
 
 	var resp auth.TokenExchangeResponse
 	if err := s.client.DoRaw(httpReq, &resp); err != nil {
-		return nil, fmt.Errorf("auth.RefreshAccessToken: %w", err)
+		return nil, newOpError("auth.RefreshAccessToken", "", "", err)
 	}
 
 	return &resp, nil
@@ -207,13 +206,13 @@ func (s *AuthService) CustomAuthentication(ctx context.Context, req *auth.Custom
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, path, req)
 	if err != nil {
-		return nil, fmt.Errorf("auth.CustomAuthentication: %w", err)
+		return nil, newOpError("auth.CustomAuthentication", "", "", err)
 	}
 
 	var grant grants.Grant
 	_, err = s.client.Do(httpReq, &grant)
 	if err != nil {
-		return nil, fmt.Errorf("auth.CustomAuthentication: %w", err)
+		return nil, newOpError("auth.CustomAuthentication", "", "", err)
 	}
 
 	return &grant, nil
@@ -225,7 +224,7 @@ func (s *AuthService) IDTokenInfo(ctx context.Context, idToken string) (*auth.To
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("auth.IDTokenInfo: %w", err)
+		return nil, newOpError("auth.IDTokenInfo", "", "", err)
 	}
 
 	q := httpReq.URL.Query()
@@ -235,7 +234,7 @@ func (s *AuthService) IDTokenInfo(ctx context.Context, idToken string) (*auth.To
 	var resp auth.TokenInfoResponse
 	_, err = s.client.Do(httpReq, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("auth.IDTokenInfo: %w", err)
+		return nil, newOpError("auth.IDTokenInfo", "", "", err)
 	}
 
 	return &resp, nil
@@ -253,7 +252,7 @@ func (s *AuthService) AccessTokenInfo(ctx context.Context, accessToken string) (
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("auth.AccessTokenInfo: %w", err)
+		return nil, newOpError("auth.AccessTokenInfo", "", "", err)
 	}
 
 	q := httpReq.URL.Query()
@@ -263,7 +262,7 @@ func (s *AuthService) AccessTokenInfo(ctx context.Context, accessToken string) (
 	var resp auth.TokenInfoResponse
 	_, err = s.client.Do(httpReq, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("auth.AccessTokenInfo: %w", err)
+		return nil, newOpError("auth.AccessTokenInfo", "", "", err)
 	}
 
 	return &resp, nil
@@ -276,7 +275,7 @@ func (s *AuthService) Revoke(ctx context.Context, token string) error {
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
-		return fmt.Errorf("auth.Revoke: %w", err)
+		return newOpError("auth.Revoke", "", "", err)
 	}
 
 	q := httpReq.URL.Query()
@@ -284,7 +283,7 @@ func (s *AuthService) Revoke(ctx context.Context, token string) error {
 	httpReq.URL.RawQuery = q.Encode()
 
 	if err := s.client.DoRaw(httpReq, nil); err != nil {
-		return fmt.Errorf("auth.Revoke: %w", err)
+		return newOpError("auth.Revoke", "", "", err)
 	}
 
 	return nil
@@ -296,13 +295,13 @@ func (s *AuthService) DetectProvider(ctx context.Context, req *auth.ProviderDete
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, path, req)
 	if err != nil {
-		return nil, fmt.Errorf("auth.DetectProvider: %w", err)
+		return nil, newOpError("auth.DetectProvider", "", "", err)
 	}
 
 	var resp auth.ProviderDetectResponse
 	_, err = s.client.Do(httpReq, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("auth.DetectProvider: %w", err)
+		return nil, newOpError("auth.DetectProvider", "", "", err)
 	}
 
 	return &resp, nil
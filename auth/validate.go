@@ -0,0 +1,60 @@
+package auth
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+// ClientSecret and GrantType are filled in by the client and are not checked.
+func (r *CodeExchangeRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.ClientID == "" {
+		v.Add("client_id", "is required")
+	}
+	if r.Code == "" {
+		v.Add("code", "is required")
+	}
+	if r.RedirectURI != "" {
+		common.CheckURL(v, "redirect_uri", r.RedirectURI, "http", "https")
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+// ClientSecret and GrantType are filled in by the client and are not checked.
+func (r *RefreshTokenRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.ClientID == "" {
+		v.Add("client_id", "is required")
+	}
+	if r.RefreshToken == "" {
+		v.Add("refresh_token", "is required")
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *CustomAuthRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Provider == "" {
+		v.Add("provider", "is required")
+	}
+	if len(r.Settings) == 0 {
+		v.Add("settings", "is required")
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *RevokeRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Token == "" {
+		v.Add("token", "is required")
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *ProviderDetectRequest) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckEmail(v, "email", r.Email)
+	return v.Err()
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantPath string
+	}{
+		{"redirect uri scheme", (&CodeExchangeRequest{ClientID: "c", Code: "x", RedirectURI: "ftp://x"}).Validate(), "redirect_uri"},
+		{"redirect uri https", (&CodeExchangeRequest{ClientID: "c", Code: "x", RedirectURI: "https://app.example.com/cb"}).Validate(), "-"},
+		{"detect email format", (&ProviderDetectRequest{Email: "not-an-email"}).Validate(), "email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPath == "-" {
+				if tt.err != nil {
+					t.Errorf("Validate() error = %v, want nil", tt.err)
+				}
+				return
+			}
+			var verr *common.ValidationError
+			if !errors.As(tt.err, &verr) || verr.Fields[0].Path != tt.wantPath {
+				t.Errorf("Validate() error = %v, want problem at %q", tt.err, tt.wantPath)
+			}
+		})
+	}
+}
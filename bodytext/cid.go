@@ -0,0 +1,104 @@
+package bodytext
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// ContentIDs returns the content IDs referenced by cid: URLs in body, in order of
+// first use and without duplicates. They are decoded and have no angle brackets, so
+// they compare equal to a trimmed Attachment.ContentID.
+func ContentIDs(body string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	forEachCID(body, func(_, _ int, id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	})
+	return ids
+}
+
+// ReplaceContentIDs rewrites the cid: URLs in body, in attributes and in CSS url()
+// alike. replace is called with each decoded content ID and returns the URL to use
+// instead, or false to leave the reference as it is. The rest of body is unchanged.
+//
+// Quotes, spaces, angle brackets and parentheses in the returned URL are
+// percent-encoded so that it cannot end the attribute or url() it is written into.
+//
+// Example:
+//
+//	body := bodytext.ReplaceContentIDs(msg.Body, func(cid string) (string, bool) {
+//	    u, ok := uploaded[cid]
+//	    return u, ok
+//	})
+func ReplaceContentIDs(body string, replace func(cid string) (string, bool)) string {
+	var b strings.Builder
+	last := 0
+	forEachCID(body, func(start, end int, id string) {
+		u, ok := replace(id)
+		if !ok {
+			return
+		}
+		b.WriteString(body[last:start])
+		b.WriteString(urlEscaper.Replace(u))
+		last = end
+	})
+	if last == 0 {
+		return body
+	}
+	b.WriteString(body[last:])
+	return b.String()
+}
+
+var urlEscaper = strings.NewReplacer(
+	`"`, "%22", `'`, "%27", " ", "%20", "<", "%3C", ">", "%3E", "(", "%28", ")", "%29",
+)
+
+// forEachCID calls fn with the bounds and decoded content ID of every cid: URL in s.
+// A cid: URL starts after a quote, "=", "(" or white space and runs to the next
+// quote, ")", ">" or white space.
+func forEachCID(s string, fn func(start, end int, id string)) {
+	for i := 0; i+4 <= len(s); {
+		k := indexFold(s[i:], "cid:")
+		if k < 0 {
+			return
+		}
+		start := i + k
+		i = start + 4
+		if start > 0 && !strings.ContainsRune("\"'=( \t\r\n\f", rune(s[start-1])) {
+			continue
+		}
+		end := i
+		for end < len(s) && !strings.ContainsRune("\"')> \t\r\n\f", rune(s[end])) {
+			end++
+		}
+		if id := decodeCID(s[i:end]); id != "" {
+			fn(start, end, id)
+		}
+		i = end
+	}
+}
+
+// decodeCID decodes the part of a cid: URL after the scheme. Content IDs are
+// URL-encoded in cid: URLs (RFC 2392), and may also carry HTML entities from an
+// attribute value.
+func decodeCID(s string) string {
+	s = html.UnescapeString(s)
+	if u, err := url.PathUnescape(s); err == nil {
+		s = u
+	}
+	return strings.Trim(s, "<>")
+}
+
+// indexFold is strings.Index ignoring ASCII case, for an all lower-case substr.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
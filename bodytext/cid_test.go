@@ -0,0 +1,82 @@
+package bodytext
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestContentIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "attributes and css",
+			body: `<img src="cid:logo@example.com"><div style="background: url(cid:bg)"><img src='CID:logo@example.com'>`,
+			want: []string{"logo@example.com", "bg"},
+		},
+		{
+			name: "encoded",
+			body: `<img src="cid:part1%40example.com"><img src=cid:a&amp;b>`,
+			want: []string{"part1@example.com", "a&b"},
+		},
+		{
+			name: "not a url",
+			body: `<p>acid:test and cid: alone</p>`,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContentIDs(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContentIDs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceContentIDs(t *testing.T) {
+	urls := map[string]string{
+		"logo@example.com": "https://cdn.example.com/logo.png",
+		"bg":               "/tmp/inline/my bg(1).png",
+	}
+	replace := func(cid string) (string, bool) {
+		u, ok := urls[cid]
+		return u, ok
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "attribute",
+			body: `<p>Hi</p><img alt="Logo" src="cid:logo%40example.com" width="80">`,
+			want: `<p>Hi</p><img alt="Logo" src="https://cdn.example.com/logo.png" width="80">`,
+		},
+		{
+			name: "css url escaped",
+			body: `<td style="background:url(cid:bg)">`,
+			want: `<td style="background:url(/tmp/inline/my%20bg%281%29.png)">`,
+		},
+		{
+			name: "unknown left alone",
+			body: `<img src="cid:other"><img src="cid:logo@example.com">`,
+			want: `<img src="cid:other"><img src="https://cdn.example.com/logo.png">`,
+		},
+		{
+			name: "no references",
+			body: `plain text`,
+			want: `plain text`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReplaceContentIDs(tt.body, replace); got != tt.want {
+				t.Errorf("ReplaceContentIDs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
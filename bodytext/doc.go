@@ -0,0 +1,10 @@
+// Package bodytext converts and cleans the HTML bodies of Nylas messages offline.
+//
+// ToText turns provider HTML into readable plain text for previews and search
+// indexing, StripQuotes and StripQuotedText remove quoted reply history, and Sanitize
+// makes HTML safe to display by removing scripts, event handlers and tracking pixels.
+// ContentIDs and ReplaceContentIDs find and rewrite the cid: references of inline
+// images, and ReplaceImageSources rewrites the src of <img> elements.
+// Everything is deterministic and needs no network access; use
+// MessagesService.Clean for the server-side equivalent.
+package bodytext
@@ -0,0 +1,43 @@
+package bodytext
+
+import "strings"
+
+// ReplaceImageSources rewrites the src of every <img> element in body. replace is
+// called with each decoded src and returns the new one, or false to leave the element
+// as it is. Rewritten tags are re-serialised; the rest of body is unchanged.
+//
+// Example:
+//
+//	body := bodytext.ReplaceImageSources(req.Body, func(src string) (string, bool) {
+//	    return proxy.URL(src), strings.HasPrefix(src, "http:")
+//	})
+func ReplaceImageSources(body string, replace func(src string) (string, bool)) string {
+	var b strings.Builder
+	last := 0
+	for _, tok := range tokenize(body) {
+		if tok.typ != startTagToken || tok.name != "img" {
+			continue
+		}
+		src, ok := tok.attr("src")
+		if !ok {
+			continue
+		}
+		newSrc, ok := replace(src)
+		if !ok {
+			continue
+		}
+		for i := range tok.attrs {
+			if tok.attrs[i].key == "src" {
+				tok.attrs[i].val = newSrc
+			}
+		}
+		b.WriteString(body[last:tok.start])
+		writeToken(&b, &tok)
+		last = tok.end
+	}
+	if last == 0 {
+		return body
+	}
+	b.WriteString(body[last:])
+	return b.String()
+}
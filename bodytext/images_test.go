@@ -0,0 +1,40 @@
+package bodytext
+
+import "testing"
+
+func TestReplaceImageSources(t *testing.T) {
+	replace := func(src string) (string, bool) {
+		if src == "data:image/png;base64,AAAA" {
+			return "cid:img-1", true
+		}
+		return "", false
+	}
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "rewritten tag",
+			body: `<p class=x>Hi</p><IMG Alt='a &amp; b' SRC="data:image/png;base64,AAAA" width=10 /><img src="https://example.com/a.png">`,
+			want: `<p class=x>Hi</p><img alt="a &amp; b" src="cid:img-1" width="10" /><img src="https://example.com/a.png">`,
+		},
+		{
+			name: "scripts and comments untouched",
+			body: `<script>var s = '<img src="data:image/png;base64,AAAA">'</script><!-- <img src="data:image/png;base64,AAAA"> -->`,
+			want: `<script>var s = '<img src="data:image/png;base64,AAAA">'</script><!-- <img src="data:image/png;base64,AAAA"> -->`,
+		},
+		{
+			name: "plain text",
+			body: `no images`,
+			want: `no images`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReplaceImageSources(tt.body, replace); got != tt.want {
+				t.Errorf("ReplaceImageSources() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package bodytext
+
+import (
+	"regexp"
+	"strings"
+)
+
+// StripQuotes removes quoted reply history from an HTML body, keeping only the new
+// content. It recognises the markup added by Gmail, Apple Mail, Outlook, Thunderbird,
+// Yahoo and Proton Mail, plus the markup of messages.NewReply. Plain blockquotes
+// without a reply marker are kept. A body without HTML markup is passed to
+// StripQuotedText instead.
+//
+// The result is meant for previews and indexing: quoted sections are removed by
+// position, so markup after a truncation point is dropped and some elements may be
+// left unclosed.
+func StripQuotes(body string) string {
+	if !IsHTML(body) {
+		return StripQuotedText(body)
+	}
+
+	tokens := tokenize(body)
+	var (
+		b    strings.Builder
+		skip []string
+	)
+	for _, tok := range tokens {
+		if len(skip) > 0 {
+			switch {
+			case tok.typ == startTagToken && tok.name == skip[len(skip)-1] && !tok.selfClosing:
+				skip = append(skip, tok.name)
+			case tok.typ == endTagToken && tok.name == skip[len(skip)-1]:
+				skip = skip[:len(skip)-1]
+			}
+			continue
+		}
+
+		if tok.typ == startTagToken {
+			if startsQuotedTail(&tok) {
+				break
+			}
+			if isQuoteContainer(&tok) {
+				if !tok.selfClosing && !voidElements[tok.name] {
+					skip = append(skip, tok.name)
+				}
+				continue
+			}
+		}
+		writeToken(&b, &tok)
+	}
+	return b.String()
+}
+
+// isQuoteContainer reports whether tok starts an element that wraps quoted history.
+func isQuoteContainer(tok *token) bool {
+	class, _ := tok.attr("class")
+	classes := strings.Fields(class)
+	hasClass := func(names ...string) bool {
+		for _, c := range classes {
+			for _, n := range names {
+				if c == n {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	switch tok.name {
+	case "blockquote":
+		typ, _ := tok.attr("type")
+		return strings.EqualFold(typ, "cite") ||
+			hasClass("gmail_quote", "protonmail_quote", "yahoo_quoted")
+	case "div":
+		return hasClass("gmail_quote", "gmail_quote_container", "yahoo_quoted",
+			"moz-cite-prefix", "protonmail_quote")
+	}
+	return false
+}
+
+// startsQuotedTail reports whether tok marks the point after which everything is
+// quoted history, as Outlook and Zimbra do instead of wrapping it.
+func startsQuotedTail(tok *token) bool {
+	id, _ := tok.attr("id")
+	switch {
+	case tok.name == "div" && (id == "divRplyFwdMsg" || id == "appendonsend"):
+		return true
+	case tok.name == "hr" && id == "zwchr":
+		return true
+	}
+	return false
+}
+
+// writeToken writes tok back out as HTML.
+func writeToken(b *strings.Builder, tok *token) {
+	switch tok.typ {
+	case textToken:
+		b.WriteString(tok.data)
+	case commentToken:
+		b.WriteString("<!--" + tok.data + "-->")
+	case endTagToken:
+		b.WriteString("</" + tok.name + ">")
+	case startTagToken:
+		b.WriteString("<" + tok.name)
+		for _, a := range tok.attrs {
+			b.WriteString(" " + a.key + `="` + escapeAttr(a.val) + `"`)
+		}
+		if tok.selfClosing {
+			b.WriteString(" /")
+		}
+		b.WriteByte('>')
+	}
+}
+
+var attrEscaper = strings.NewReplacer(`&`, "&amp;", `"`, "&quot;", `<`, "&lt;", `>`, "&gt;")
+
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
+
+var (
+	// attributionLine matches "On <date>, <name> wrote:" and its common translations.
+	attributionLine = regexp.MustCompile(`(?i)^(on|am|le|el|il|op|em)\s.*\b(wrote|schrieb|a écrit|escribió|ha scritto|schreef|escreveu)\b[^:]*:\s*$`)
+	// attributionStart matches the first line of an attribution wrapped over two lines.
+	attributionStart = regexp.MustCompile(`(?i)^(on|am|le|el|il|op|em)\s`)
+	// originalMessage matches Outlook's "-----Original Message-----" separator.
+	originalMessage = regexp.MustCompile(`(?i)^-{2,}\s*(original message|ursprüngliche nachricht|message d'origine|mensaje original)\s*-{2,}\s*$`)
+	// headerBlock matches the first line of a quoted "From: ... Sent: ..." header block.
+	headerBlock = regexp.MustCompile(`(?i)^\*?(from|von|de)\s*:\*?\s+\S`)
+	// headerBlockNext matches the lines that follow it.
+	headerBlockNext = regexp.MustCompile(`(?i)^\*?(sent|date|to|subject|gesendet|datum|envoyé|enviado)\s*:`)
+)
+
+// StripQuotedText removes quoted reply history from a plain text body. Everything
+// from the first attribution line ("On ... wrote:"), "-----Original Message-----"
+// separator or Outlook-style "From:/Sent:" header block is dropped, as are lines
+// quoted with ">". Trailing blank lines are trimmed.
+func StripQuotedText(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var out []string
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if startsQuotedHistory(lines, i, trimmed) {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		out = append(out, strings.TrimRight(line, " \t"))
+	}
+	for len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
+
+func startsQuotedHistory(lines []string, i int, line string) bool {
+	if attributionLine.MatchString(line) || originalMessage.MatchString(line) {
+		return true
+	}
+	if attributionStart.MatchString(line) && i+1 < len(lines) {
+		if attributionLine.MatchString(line + " " + strings.TrimSpace(lines[i+1])) {
+			return true
+		}
+	}
+	if strings.Trim(line, "_") == "" && len(line) >= 10 && i+1 < len(lines) {
+		line = strings.TrimSpace(lines[i+1])
+		i++
+	}
+	if headerBlock.MatchString(line) {
+		for j := i + 1; j < len(lines) && j <= i+3; j++ {
+			if headerBlockNext.MatchString(strings.TrimSpace(lines[j])) {
+				return true
+			}
+		}
+	}
+	return false
+}
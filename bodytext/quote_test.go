@@ -0,0 +1,110 @@
+package bodytext
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStripQuotes(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "gmail",
+			html: `<div dir="ltr">Thanks!</div><br><div class="gmail_quote"><div class="gmail_attr">On Mon, Bob wrote:</div><blockquote class="gmail_quote"><div>old <div>nested</div></div></blockquote></div>`,
+			want: `<div dir="ltr">Thanks!</div><br>`,
+		},
+		{
+			name: "apple mail",
+			html: `<div>Sure</div><div><br><blockquote type="cite"><div>On Mon, Bob wrote:</div><blockquote type="cite">older</blockquote></blockquote></div>`,
+			want: `<div>Sure</div><div><br></div>`,
+		},
+		{
+			name: "thunderbird",
+			html: `<p>Yes</p><div class="moz-cite-prefix">On Mon, Bob wrote:<br></div><blockquote type="cite">old</blockquote>`,
+			want: `<p>Yes</p>`,
+		},
+		{
+			name: "outlook",
+			html: `<div>Agreed</div><hr style="display:inline-block"><div id="divRplyFwdMsg"><b>From:</b> Bob</div><div>old</div>`,
+			want: `<div>Agreed</div><hr style="display:inline-block">`,
+		},
+		{
+			name: "plain blockquote kept",
+			html: `<p>As the docs say:</p><blockquote>quoted text</blockquote>`,
+			want: `<p>As the docs say:</p><blockquote>quoted text</blockquote>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripQuotes(tt.html); got != tt.want {
+				t.Errorf("StripQuotes() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripQuotedText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "attribution",
+			text: "Sounds good.\n\nOn Mon, Jan 2, 2006 at 3:04 PM, Bob <bob@example.com> wrote:\n> old\n> older",
+			want: "Sounds good.",
+		},
+		{
+			name: "wrapped attribution",
+			text: "Ok\n\nOn Mon, Jan 2, 2006 at 3:04 PM, Bob Smith <\nbob@example.com> wrote:\n\n> old",
+			want: "Ok",
+		},
+		{
+			name: "german attribution",
+			text: "Danke\n\nAm 02.01.2006 um 15:04 schrieb Bob <bob@example.com>:\n> alt",
+			want: "Danke",
+		},
+		{
+			name: "original message",
+			text: "Done.\n\n-----Original Message-----\nFrom: Bob\nSent: Monday\n\nold",
+			want: "Done.",
+		},
+		{
+			name: "outlook header block",
+			text: "Approved\n\n________________________________\nFrom: Bob <bob@example.com>\nSent: Monday, January 2, 2006 3:04 PM\nTo: Me\n\nold",
+			want: "Approved",
+		},
+		{
+			name: "inline quotes dropped",
+			text: "> question one\nanswer one\n> question two\nanswer two",
+			want: "answer one\nanswer two",
+		},
+		{
+			name: "from line in body kept",
+			text: "From: the team\nWe shipped it.",
+			want: "From: the team\nWe shipped it.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripQuotedText(tt.text); got != tt.want {
+				t.Errorf("StripQuotedText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripQuotes_PlainText(t *testing.T) {
+	got := StripQuotes("New text\n\nOn Mon, Bob wrote:\n> old")
+	if got != "New text" {
+		t.Errorf("StripQuotes() = %q", got)
+	}
+	if strings.Contains(StripQuotes("<p>a</p><!-- note --><p>b</p>"), "note") == false {
+		t.Error("StripQuotes() dropped a comment outside quoted history")
+	}
+}
@@ -0,0 +1,283 @@
+package bodytext
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SanitizeOptions configures Sanitize. A nil *SanitizeOptions uses the defaults.
+type SanitizeOptions struct {
+	// BlockRemoteImages moves the src of images loaded over http or https to a
+	// data-src attribute, so nothing is fetched until the reader chooses to load them.
+	// Inline cid: and data: images are unaffected.
+	BlockRemoteImages bool
+	// IsTracker reports whether an image URL is a tracking pixel, in addition to the
+	// built-in check for hidden and 1x1 images. Tracker images are removed.
+	IsTracker func(src string) bool
+	// RewriteLink, if set, is called with every http, https, mailto and tel link and
+	// returns the href to use instead, e.g. a redirect through a link checker.
+	// Returning "" removes the href but keeps the link text.
+	RewriteLink func(href string) string
+	// AllowStyleSheets keeps <style> elements, with url(), @import and expression()
+	// removed. By default they are dropped.
+	AllowStyleSheets bool
+}
+
+// Sanitize makes an HTML message body safe to display in a web page.
+//
+// Only an allowlist of formatting elements and attributes is kept. Scripts, frames,
+// forms, objects, comments, event handler attributes and id attributes are removed,
+// as are links and images with schemes other than http, https, mailto, tel, cid and
+// data:image. Inline styles are kept without url(), expression() and similar
+// constructs, so they cannot load anything. Hidden and 1x1 images are removed as
+// likely trackers, and links open in a new window with rel="noopener noreferrer".
+// Text and attribute values are re-escaped and every open element is closed, so the
+// output can be embedded in a larger document.
+//
+// Example:
+//
+//	safe := bodytext.Sanitize(msg.Body, &bodytext.SanitizeOptions{BlockRemoteImages: true})
+func Sanitize(body string, opts *SanitizeOptions) string {
+	if opts == nil {
+		opts = &SanitizeOptions{}
+	}
+	if !IsHTML(body) {
+		return strings.ReplaceAll(html.EscapeString(body), "\n", "<br>")
+	}
+
+	var (
+		b    strings.Builder
+		skip []string
+		open []string
+	)
+	for _, tok := range tokenize(body) {
+		if len(skip) > 0 {
+			switch {
+			case tok.typ == startTagToken && tok.name == skip[len(skip)-1] && !tok.selfClosing:
+				skip = append(skip, tok.name)
+			case tok.typ == endTagToken && tok.name == skip[len(skip)-1]:
+				skip = skip[:len(skip)-1]
+			}
+			continue
+		}
+
+		switch tok.typ {
+		case textToken:
+			if tok.name == "style" {
+				b.WriteString(sanitizeCSS(tok.data))
+			} else {
+				b.WriteString(html.EscapeString(html.UnescapeString(tok.data)))
+			}
+
+		case startTagToken:
+			if dropElements[tok.name] && !(tok.name == "style" && opts.AllowStyleSheets) {
+				if !tok.selfClosing && !voidElements[tok.name] {
+					skip = append(skip, tok.name)
+				}
+				continue
+			}
+			if !allowedElements[tok.name] && !(tok.name == "style" && opts.AllowStyleSheets) {
+				continue
+			}
+			attrs, ok := sanitizeAttrs(&tok, opts)
+			if !ok {
+				continue
+			}
+			tok.attrs = attrs
+			tok.selfClosing = false
+			writeToken(&b, &tok)
+			if !voidElements[tok.name] {
+				open = append(open, tok.name)
+			}
+
+		case endTagToken:
+			// Close the element and anything left open inside it; ignore stray end tags.
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != tok.name {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					b.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+				break
+			}
+		}
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+	return b.String()
+}
+
+// dropElements are removed together with their content.
+var dropElements = map[string]bool{
+	"script": true, "style": true, "head": true, "title": true, "iframe": true,
+	"frame": true, "frameset": true, "object": true, "embed": true, "applet": true,
+	"template": true, "svg": true, "math": true, "textarea": true, "select": true,
+	"button": true, "noembed": true, "noframes": true, "xmp": true, "base": true,
+	"link": true, "meta": true, "input": true,
+}
+
+// allowedElements are kept; any other element is removed but its content kept.
+var allowedElements = map[string]bool{
+	"a": true, "abbr": true, "address": true, "article": true, "b": true, "bdi": true,
+	"bdo": true, "big": true, "blockquote": true, "br": true, "caption": true,
+	"center": true, "cite": true, "code": true, "col": true, "colgroup": true,
+	"dd": true, "del": true, "details": true, "dfn": true, "div": true, "dl": true,
+	"dt": true, "em": true, "figcaption": true, "figure": true, "font": true,
+	"footer": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "header": true, "hr": true, "i": true, "img": true, "ins": true,
+	"kbd": true, "li": true, "mark": true, "ol": true, "p": true, "pre": true,
+	"q": true, "s": true, "samp": true, "section": true, "small": true, "span": true,
+	"strike": true, "strong": true, "sub": true, "summary": true, "sup": true,
+	"table": true, "tbody": true, "td": true, "tfoot": true, "th": true,
+	"thead": true, "time": true, "tr": true, "tt": true, "u": true, "ul": true,
+	"var": true, "wbr": true,
+}
+
+// allowedAttrs are kept on any allowed element. URL attributes are handled separately.
+var allowedAttrs = map[string]bool{
+	"align": true, "alt": true, "bgcolor": true, "border": true, "cellpadding": true,
+	"cellspacing": true, "class": true, "color": true, "cols": true, "colspan": true,
+	"dir": true, "face": true, "height": true, "hspace": true, "lang": true,
+	"nowrap": true, "rows": true, "rowspan": true, "size": true, "span": true,
+	"start": true, "style": true, "summary": true, "title": true, "type": true,
+	"valign": true, "vspace": true, "width": true,
+}
+
+// sanitizeAttrs returns the attributes of tok that are safe to keep. It reports false
+// if the whole element should be dropped, as for tracking pixels.
+func sanitizeAttrs(tok *token, opts *SanitizeOptions) ([]attribute, bool) {
+	var out []attribute
+	for _, a := range tok.attrs {
+		switch {
+		case a.key == "style":
+			if css := sanitizeCSS(a.val); strings.TrimSpace(css) != "" {
+				out = append(out, attribute{key: "style", val: css})
+			}
+		case allowedAttrs[a.key]:
+			out = append(out, a)
+		}
+	}
+
+	switch tok.name {
+	case "a":
+		href, _ := tok.attr("href")
+		href = strings.TrimSpace(href)
+		if !hasScheme(href, "http", "https", "mailto", "tel") {
+			return out, true
+		}
+		if opts.RewriteLink != nil {
+			href = opts.RewriteLink(href)
+		}
+		if href == "" {
+			return out, true
+		}
+		out = append(out, attribute{key: "href", val: href})
+		if hasScheme(href, "http", "https") {
+			out = append(out,
+				attribute{key: "target", val: "_blank"},
+				attribute{key: "rel", val: "noopener noreferrer"})
+		}
+
+	case "img":
+		src, _ := tok.attr("src")
+		src = strings.TrimSpace(src)
+		remote := hasScheme(src, "http", "https")
+		switch {
+		case remote && (isHiddenImage(tok) || opts.IsTracker != nil && opts.IsTracker(src)):
+			return nil, false
+		case remote && opts.BlockRemoteImages:
+			out = append(out, attribute{key: "data-src", val: src})
+		case remote || hasScheme(src, "cid") || isDataImage(src):
+			out = append(out, attribute{key: "src", val: src})
+		}
+	}
+	return out, true
+}
+
+// hasScheme reports whether rawURL is absolute with one of the given schemes.
+func hasScheme(rawURL string, schemes ...string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	for _, s := range schemes {
+		if strings.EqualFold(u.Scheme, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// dataImage matches data: URLs of raster image types. SVG is excluded because it
+// can carry script.
+var dataImage = regexp.MustCompile(`(?i)^data:image/(png|gif|jpe?g|webp|bmp);base64,`)
+
+func isDataImage(src string) bool {
+	return dataImage.MatchString(src)
+}
+
+// isHiddenImage reports whether an image is invisible or at most 1x1 pixels,
+// which in email almost always means an open-tracking pixel.
+func isHiddenImage(tok *token) bool {
+	style, _ := tok.attr("style")
+	style = strings.ToLower(strings.ReplaceAll(style, " ", ""))
+	if strings.Contains(style, "display:none") || strings.Contains(style, "visibility:hidden") {
+		return true
+	}
+	tiny := func(attr, prop string) bool {
+		v, _ := tok.attr(attr)
+		if n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(v), "px")); err == nil && n <= 1 {
+			return true
+		}
+		return strings.Contains(";"+style, ";"+prop+":0px") || strings.Contains(";"+style, ";"+prop+":1px")
+	}
+	return tiny("width", "width") && tiny("height", "height")
+}
+
+// unsafeCSS matches CSS that can load resources or run code. Besides url(), the
+// image functions image(), image-set(), cross-fade() and src(), with or without a
+// vendor prefix, load bare strings as URLs.
+var unsafeCSS = regexp.MustCompile(`(?i)url\s*\(|(?:^|[^a-z0-9_])(?:image|image-set|cross-fade|src)\s*\(|expression\s*\(|javascript:|vbscript:|behavior\s*:|-moz-binding|@import|\\`)
+
+// sanitizeCSS removes the declarations, rules and at-rules of css that match unsafeCSS.
+// Backslashes are treated as unsafe because CSS escapes can hide the other patterns.
+func sanitizeCSS(css string) string {
+	if !unsafeCSS.MatchString(css) {
+		return css
+	}
+	var b strings.Builder
+	for _, decl := range splitCSS(css) {
+		switch {
+		case !unsafeCSS.MatchString(decl):
+			b.WriteString(decl)
+		case strings.HasSuffix(decl, "}"):
+			b.WriteByte('}')
+		}
+	}
+	return b.String()
+}
+
+// splitCSS splits css after each ";", "{" and "}" so that unsafe pieces can be
+// dropped individually. The closing brace of a dropped piece is kept so later rules
+// stay balanced.
+func splitCSS(css string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(css); i++ {
+		switch css[i] {
+		case ';', '{', '}':
+			parts = append(parts, css[start:i+1])
+			start = i + 1
+		}
+	}
+	if start < len(css) {
+		parts = append(parts, css[start:])
+	}
+	return parts
+}
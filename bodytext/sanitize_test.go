@@ -0,0 +1,82 @@
+package bodytext
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "scripts and handlers",
+			html: `<div onclick="steal()" id="x" class="note">Hi<script>alert(1)</script><img src="https://example.com/a.png" onerror="alert(2)" alt="a"></div>`,
+			want: `<div class="note">Hi<img alt="a" src="https://example.com/a.png"></div>`,
+		},
+		{
+			name: "dangerous links",
+			html: `<a href="javascript:alert(1)">x</a><a href="jav&#x09;ascript:alert(1)">y</a><a href="https://example.com/?a=1&amp;b=2" target="_self">z</a>`,
+			want: `<a>x</a><a>y</a><a href="https://example.com/?a=1&amp;b=2" target="_blank" rel="noopener noreferrer">z</a>`,
+		},
+		{
+			name: "tracking pixels",
+			html: `<p>Hi</p><img src="https://t.example.com/o.gif" width="1" height="1"><img src="https://t.example.com/p.gif" style="display: none"><img src="cid:logo">`,
+			want: `<p>Hi</p><img src="cid:logo">`,
+		},
+		{
+			name: "styles",
+			html: `<style>body{background:url(https://t.example.com/x)}p{color:red}</style><p style="color: red; background-image: url('https://t.example.com/y'); width: expression(alert(1))">x</p>`,
+			want: `<p style="color: red;">x</p>`,
+		},
+		{
+			name: "css image functions",
+			html: `<p style="color: red; background-image: image-set('https://t.example.com/a' 1x); background:-webkit-image-set('https://t.example.com/b' 1x); content: image('https://t.example.com/c'); background: cross-fade('https://t.example.com/d', 'e', 50%); background-image: linear-gradient(red, blue)">x</p>`,
+			want: `<p style="color: red; background-image: linear-gradient(red, blue)">x</p>`,
+		},
+		{
+			name: "unknown elements unwrapped and unclosed closed",
+			html: `<form action="https://evil.example.com"><input value="x"><custom>text</custom><table><tr><td>cell`,
+			want: `text<table><tr><td>cell</td></tr></table>`,
+		},
+		{
+			name: "text re-escaped",
+			html: `<p>a < b &amp; "c"</p><!-- <script>x</script> -->`,
+			want: `<p>a &lt; b &amp; &#34;c&#34;</p>`,
+		},
+		{
+			name: "data images",
+			html: `<img src="data:image/png;base64,AAAA"><img src="data:image/svg+xml;base64,AAAA">`,
+			want: `<img src="data:image/png;base64,AAAA"><img>`,
+		},
+		{
+			name: "plain text",
+			html: "a & b\nc",
+			want: "a &amp; b<br>c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.html, nil); got != tt.want {
+				t.Errorf("Sanitize() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitize_Options(t *testing.T) {
+	body := `<style>p{color:red}@import url(x.css);</style><a href="https://example.com">x</a><img src="https://cdn.example.com/banner.png"><img src="https://ads.example.com/px">`
+	got := Sanitize(body, &SanitizeOptions{
+		BlockRemoteImages: true,
+		AllowStyleSheets:  true,
+		IsTracker:         func(src string) bool { return strings.Contains(src, "ads.") },
+		RewriteLink:       func(href string) string { return "https://safe.example.com/?u=" + href },
+	})
+	want := `<style>p{color:red}</style><a href="https://safe.example.com/?u=https://example.com" target="_blank" rel="noopener noreferrer">x</a><img data-src="https://cdn.example.com/banner.png">`
+	if got != want {
+		t.Errorf("Sanitize() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,363 @@
+package bodytext
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TextOptions configures ToText. A nil *TextOptions uses the defaults.
+type TextOptions struct {
+	// OmitLinks leaves out link targets. By default a link is written as
+	// "text (url)" unless the text already shows the URL.
+	OmitLinks bool
+	// StripQuotes removes quoted reply history; see StripQuotes and StripQuotedText.
+	StripQuotes bool
+}
+
+// ToText converts an HTML message body to readable plain text.
+//
+// Paragraphs and headings are separated by blank lines, list items are written as
+// "* item" or "1. item" and indented by nesting level, table cells are joined with
+// " | ", blockquotes are prefixed with "> ", preformatted text keeps its spacing and
+// links are followed by their URL. Scripts, styles and the document head are dropped.
+// A body without HTML markup is returned with only line endings normalised.
+//
+// Example:
+//
+//	text := bodytext.ToText(msg.Body, &bodytext.TextOptions{StripQuotes: true})
+func ToText(body string, opts *TextOptions) string {
+	if opts == nil {
+		opts = &TextOptions{}
+	}
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+
+	var text string
+	if IsHTML(body) {
+		if opts.StripQuotes {
+			body = StripQuotes(body)
+		}
+		text = renderText(tokenize(body), opts)
+	} else {
+		text = strings.TrimSpace(body)
+	}
+
+	if opts.StripQuotes {
+		text = StripQuotedText(text)
+	}
+	return text
+}
+
+// skipElements are dropped with their content when converting to text.
+var skipElements = map[string]bool{
+	"script": true, "style": true, "head": true, "title": true, "template": true,
+	"svg": true, "math": true, "object": true, "iframe": true, "select": true,
+}
+
+// paragraphElements are separated from their surroundings by a blank line.
+var paragraphElements = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"pre": true, "table": true, "blockquote": true, "ul": true, "ol": true, "dl": true,
+}
+
+// blockElements start on a new line.
+var blockElements = map[string]bool{
+	"div": true, "section": true, "article": true, "header": true, "footer": true,
+	"nav": true, "main": true, "aside": true, "address": true, "figure": true,
+	"figcaption": true, "center": true, "form": true, "fieldset": true, "dt": true,
+	"dd": true, "tr": true, "caption": true, "details": true, "summary": true,
+	"body": true, "html": true, "tbody": true, "thead": true, "tfoot": true,
+}
+
+type list struct {
+	ordered bool
+	n       int
+}
+
+type cell struct {
+	count int
+	start int
+}
+
+type link struct {
+	href  string
+	start int
+}
+
+// textWriter lays out text, deferring line breaks and spaces until the next
+// word so that no line ends in whitespace and breaks never stack up.
+type textWriter struct {
+	b         strings.Builder
+	newlines  int
+	space     bool
+	cellSep   bool
+	started   bool
+	lineStart bool
+	quote     int
+	lowQuote  int
+	lists     []list
+	marker    string
+}
+
+func (w *textWriter) breakLine(n int) {
+	if n > w.newlines {
+		w.newlines = n
+	}
+	w.space = false
+	w.cellSep = false
+}
+
+func (w *textWriter) prefix() string {
+	p := strings.Repeat("> ", w.quote)
+	if len(w.lists) > 0 {
+		p += strings.Repeat("  ", len(w.lists)-1)
+		if w.marker != "" {
+			p += w.marker
+		} else {
+			p += "  "
+		}
+	}
+	return p
+}
+
+func (w *textWriter) write(s string) {
+	if !w.started {
+		w.lineStart = true
+	} else if w.newlines > 0 {
+		// Blank lines belong to the shallowest quote level since the last text,
+		// so a quote is separated from the text around it by an unquoted line.
+		blank := strings.TrimRight(strings.Repeat("> ", w.lowQuote), " ")
+		for i := 0; i < w.newlines; i++ {
+			if i > 0 {
+				w.b.WriteString(blank)
+			}
+			w.b.WriteByte('\n')
+		}
+		w.lineStart = true
+	}
+	w.newlines = 0
+	w.lowQuote = w.quote
+
+	switch {
+	case w.lineStart:
+		w.b.WriteString(w.prefix())
+		w.marker = ""
+		w.lineStart = false
+	case w.cellSep:
+		w.b.WriteString(" | ")
+	case w.space:
+		w.b.WriteByte(' ')
+	}
+	w.space = false
+	w.cellSep = false
+	w.b.WriteString(s)
+	w.started = true
+}
+
+// words writes text with runs of whitespace collapsed to one space.
+func (w *textWriter) words(s string) {
+	fields := strings.FieldsFunc(s, unicode.IsSpace)
+	if len(fields) == 0 {
+		if s != "" && w.started {
+			w.space = true
+		}
+		return
+	}
+	if unicode.IsSpace(firstRune(s)) && w.started {
+		w.space = true
+	}
+	w.write(strings.Join(fields, " "))
+	if unicode.IsSpace(lastRune(s)) {
+		w.space = true
+	}
+}
+
+// preformatted writes text keeping its spaces and line breaks.
+func (w *textWriter) preformatted(s string) {
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 && w.started {
+			w.newlines++
+		}
+		if line = strings.TrimRight(line, " \t\r"); line != "" {
+			w.write(line)
+		}
+	}
+}
+
+func renderText(tokens []token, opts *TextOptions) string {
+	w := &textWriter{}
+	var (
+		skip  []string
+		pre   int
+		links []link
+		cells []cell
+	)
+
+	for _, tok := range tokens {
+		if len(skip) > 0 {
+			switch {
+			case tok.typ == startTagToken && tok.name == skip[len(skip)-1] && !tok.selfClosing:
+				skip = append(skip, tok.name)
+			case tok.typ == endTagToken && tok.name == skip[len(skip)-1]:
+				skip = skip[:len(skip)-1]
+			}
+			continue
+		}
+
+		switch tok.typ {
+		case textToken:
+			text := html.UnescapeString(tok.data)
+			if pre > 0 {
+				w.preformatted(strings.ReplaceAll(text, "\u00a0", " "))
+			} else {
+				w.words(text)
+			}
+
+		case startTagToken:
+			if skipElements[tok.name] {
+				if !tok.selfClosing && !voidElements[tok.name] {
+					skip = append(skip, tok.name)
+				}
+				continue
+			}
+			switch {
+			case tok.name == "br":
+				if w.started {
+					w.newlines++
+				}
+				w.space = false
+			case tok.name == "hr":
+				w.breakLine(2)
+				w.write("---")
+				w.breakLine(2)
+			case tok.name == "img":
+				if alt, _ := tok.attr("alt"); strings.TrimSpace(alt) != "" {
+					w.words(alt)
+				}
+			case tok.name == "a":
+				href, _ := tok.attr("href")
+				links = append(links, link{href: strings.TrimSpace(href), start: w.b.Len()})
+			case tok.name == "li":
+				w.breakLine(1)
+				if len(w.lists) == 0 {
+					w.lists = append(w.lists, list{})
+				}
+				l := &w.lists[len(w.lists)-1]
+				l.n++
+				if l.ordered {
+					w.marker = fmt.Sprintf("%d. ", l.n)
+				} else {
+					w.marker = "* "
+				}
+			case tok.name == "ul" || tok.name == "ol":
+				if len(w.lists) > 0 {
+					w.breakLine(1)
+				} else {
+					w.breakLine(2)
+				}
+				w.lists = append(w.lists, list{ordered: tok.name == "ol"})
+			case tok.name == "blockquote":
+				w.breakLine(2)
+				w.quote++
+			case tok.name == "pre":
+				w.breakLine(2)
+				pre++
+			case tok.name == "tr":
+				w.breakLine(1)
+				cells = append(cells, cell{})
+			case tok.name == "td" || tok.name == "th":
+				if n := len(cells); n > 0 {
+					if cells[n-1].count > 0 && w.newlines == 0 {
+						w.cellSep = true
+					}
+					cells[n-1].start = w.b.Len()
+				}
+			case paragraphElements[tok.name]:
+				w.breakLine(2)
+			case blockElements[tok.name]:
+				w.breakLine(1)
+			}
+
+		case endTagToken:
+			switch {
+			case tok.name == "a" && len(links) > 0:
+				l := links[len(links)-1]
+				links = links[:len(links)-1]
+				if !opts.OmitLinks {
+					target := linkTarget(l.href)
+					if target != "" && w.b.Len() > l.start && !strings.Contains(w.b.String()[l.start:], target) {
+						w.space = true
+						w.write("(" + target + ")")
+					}
+				}
+			case tok.name == "ul" || tok.name == "ol":
+				if len(w.lists) > 0 {
+					w.lists = w.lists[:len(w.lists)-1]
+				}
+				w.marker = ""
+				if len(w.lists) > 0 {
+					w.breakLine(1)
+				} else {
+					w.breakLine(2)
+				}
+			case tok.name == "li":
+				w.marker = ""
+				w.breakLine(1)
+			case tok.name == "blockquote":
+				if w.quote > 0 {
+					w.quote--
+					w.lowQuote = min(w.lowQuote, w.quote)
+				}
+				w.breakLine(2)
+			case tok.name == "pre":
+				if pre > 0 {
+					pre--
+				}
+				w.breakLine(2)
+			case tok.name == "tr":
+				if len(cells) > 0 {
+					cells = cells[:len(cells)-1]
+				}
+				w.breakLine(1)
+			case tok.name == "td" || tok.name == "th":
+				// Only cells with text count, so empty spacer cells add no separators.
+				if n := len(cells); n > 0 && w.b.Len() > cells[n-1].start {
+					cells[n-1].count++
+				}
+			case paragraphElements[tok.name]:
+				w.breakLine(2)
+			case blockElements[tok.name]:
+				w.breakLine(1)
+			}
+		}
+	}
+
+	return strings.TrimSpace(w.b.String())
+}
+
+// linkTarget returns how a link's href is shown in text, or "" if it is not worth
+// showing: in-page anchors, scripts and cid: references.
+func linkTarget(href string) string {
+	lower := strings.ToLower(href)
+	switch {
+	case href == "", strings.HasPrefix(href, "#"),
+		strings.HasPrefix(lower, "javascript:"), strings.HasPrefix(lower, "cid:"):
+		return ""
+	case strings.HasPrefix(lower, "mailto:"):
+		addr, _, _ := strings.Cut(href[len("mailto:"):], "?")
+		return addr
+	}
+	return href
+}
+
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
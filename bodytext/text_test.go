@@ -0,0 +1,72 @@
+package bodytext
+
+import "testing"
+
+func TestToText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "paragraphs and breaks",
+			html: "<html><head><title>x</title><style>p{color:red}</style></head><body>" +
+				"<p>Hello   <b>there</b>,</p><p>line one<br>line two</p><script>alert(1)</script></body></html>",
+			want: "Hello there,\n\nline one\nline two",
+		},
+		{
+			name: "links",
+			html: `<p>See <a href="https://example.com/docs">the docs</a>, <a href="https://example.com">https://example.com</a>` +
+				` or <a href="mailto:help@example.com?subject=Hi">help@example.com</a> <a href="#top">top</a></p>`,
+			want: "See the docs (https://example.com/docs), https://example.com or help@example.com top",
+		},
+		{
+			name: "lists",
+			html: "<ul><li>one</li><li>two<ol><li>a</li><li>b</li></ol></li></ul><p>after</p>",
+			want: "* one\n* two\n  1. a\n  2. b\n\nafter",
+		},
+		{
+			name: "table",
+			html: "<table><tr><th>Item</th><td></td><th>Qty</th></tr><tr><td>Apples</td><td></td><td>3</td></tr></table>",
+			want: "Item | Qty\nApples | 3",
+		},
+		{
+			name: "blockquote",
+			html: "<p>Reply</p><blockquote><p>first</p><p>second</p></blockquote><p>end</p>",
+			want: "Reply\n\n> first\n>\n> second\n\nend",
+		},
+		{
+			name: "pre and entities",
+			html: "<p>a &amp; b&nbsp;&lt;c&gt;</p><pre>  x = 1\n  y = 2</pre>",
+			want: "a & b <c>\n\n  x = 1\n  y = 2",
+		},
+		{
+			name: "images and rules",
+			html: `<div><img src="cid:logo" alt="Acme"></div><hr><div>bye</div>`,
+			want: "Acme\n\n---\n\nbye",
+		},
+		{
+			name: "plain text",
+			html: "just text\r\nand a < b\n",
+			want: "just text\nand a < b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToText(tt.html, nil); got != tt.want {
+				t.Errorf("ToText() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToText_Options(t *testing.T) {
+	body := `<div>Sounds good, see <a href="https://example.com/x">this</a>.</div>` +
+		`<div class="gmail_quote"><div>On Mon, Bob wrote:</div><blockquote>old</blockquote></div>`
+
+	got := ToText(body, &TextOptions{OmitLinks: true, StripQuotes: true})
+	if want := "Sounds good, see this."; got != want {
+		t.Errorf("ToText() = %q, want %q", got, want)
+	}
+}
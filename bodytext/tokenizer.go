@@ -0,0 +1,234 @@
+package bodytext
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+type tokenType int
+
+const (
+	textToken tokenType = iota
+	startTagToken
+	endTagToken
+	commentToken
+)
+
+// token is one piece of an HTML document. For tags, name is lower-cased;
+// for text, data is the raw source with entities still encoded. For start tags,
+// start and end are the byte offsets of the tag in the source.
+type token struct {
+	typ         tokenType
+	name        string
+	data        string
+	attrs       []attribute
+	selfClosing bool
+	start, end  int
+}
+
+type attribute struct {
+	key string
+	val string
+}
+
+// attr returns the decoded value of the named attribute and whether it is present.
+func (t *token) attr(key string) (string, bool) {
+	for _, a := range t.attrs {
+		if a.key == key {
+			return a.val, true
+		}
+	}
+	return "", false
+}
+
+// rawTextElements hold text up to their end tag with no markup inside.
+var rawTextElements = map[string]bool{
+	"script": true, "style": true, "textarea": true, "title": true,
+	"xmp": true, "iframe": true, "noembed": true, "noframes": true,
+}
+
+// voidElements never have content or an end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true,
+	"img": true, "input": true, "link": true, "meta": true, "source": true,
+	"track": true, "wbr": true,
+}
+
+// htmlTag matches anything that looks like an HTML tag or comment.
+var htmlTag = regexp.MustCompile(`<(?:[a-zA-Z][^>]*|/[a-zA-Z][^>]*|!--[\s\S]*?--)>`)
+
+// IsHTML reports whether s contains HTML markup. Bodies without any tags are
+// treated as plain text by ToText and StripQuotes.
+func IsHTML(s string) bool {
+	return htmlTag.MatchString(s)
+}
+
+// tokenize splits s into tokens. It is lenient in the way browsers are: a "<" that
+// does not start a tag is text, and unterminated tags and comments run to the end.
+// Attribute values are decoded; text is left encoded.
+func tokenize(s string) []token {
+	var tokens []token
+	text := 0
+	flush := func(end int) {
+		if end > text {
+			tokens = append(tokens, token{typ: textToken, data: s[text:end]})
+		}
+	}
+
+	i := 0
+	for i < len(s) {
+		if s[i] != '<' || i+1 >= len(s) {
+			i++
+			continue
+		}
+		next := s[i+1]
+		switch {
+		case strings.HasPrefix(s[i:], "<!--"):
+			flush(i)
+			end := strings.Index(s[i+4:], "-->")
+			if end < 0 {
+				tokens = append(tokens, token{typ: commentToken, data: s[i+4:]})
+				return tokens
+			}
+			tokens = append(tokens, token{typ: commentToken, data: s[i+4 : i+4+end]})
+			i += 4 + end + 3
+		case next == '!' || next == '?':
+			// Doctype, CDATA or processing instruction: dropped.
+			flush(i)
+			end := strings.IndexByte(s[i:], '>')
+			if end < 0 {
+				return tokens
+			}
+			i += end + 1
+		case next == '/' && i+2 < len(s) && isLetter(s[i+2]):
+			flush(i)
+			name, n := readName(s[i+2:])
+			end := strings.IndexByte(s[i+2+n:], '>')
+			tokens = append(tokens, token{typ: endTagToken, name: name})
+			if end < 0 {
+				return tokens
+			}
+			i += 2 + n + end + 1
+		case isLetter(next):
+			flush(i)
+			tok, n := readStartTag(s[i:])
+			tok.start, tok.end = i, i+n
+			tokens = append(tokens, tok)
+			i += n
+			if rawTextElements[tok.name] && !tok.selfClosing {
+				end := indexEndTag(s[i:], tok.name)
+				if end < 0 {
+					end = len(s) - i
+				}
+				if end > 0 {
+					tokens = append(tokens, token{typ: textToken, name: tok.name, data: s[i : i+end]})
+				}
+				i += end
+			}
+		default:
+			i++
+			continue
+		}
+		text = i
+	}
+	flush(len(s))
+	return tokens
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// readName reads a tag or attribute name and returns it lower-cased with its length.
+func readName(s string) (string, int) {
+	n := 0
+	for n < len(s) && !isSpace(s[n]) && s[n] != '/' && s[n] != '>' && s[n] != '=' {
+		n++
+	}
+	return strings.ToLower(s[:n]), n
+}
+
+// readStartTag parses the start tag at the beginning of s and returns its length.
+func readStartTag(s string) (token, int) {
+	tok := token{typ: startTagToken}
+	name, n := readName(s[1:])
+	tok.name = name
+	i := 1 + n
+
+	for i < len(s) {
+		for i < len(s) && (isSpace(s[i]) || s[i] == '/') {
+			if s[i] == '/' && i+1 < len(s) && s[i+1] == '>' {
+				tok.selfClosing = true
+			}
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		if s[i] == '>' {
+			return tok, i + 1
+		}
+
+		key, n := readName(s[i:])
+		if n == 0 {
+			// A stray "=": skip it.
+			i++
+			continue
+		}
+		i += n
+		j := i
+		for j < len(s) && isSpace(s[j]) {
+			j++
+		}
+		var val string
+		if j < len(s) && s[j] == '=' {
+			j++
+			for j < len(s) && isSpace(s[j]) {
+				j++
+			}
+			switch {
+			case j < len(s) && (s[j] == '"' || s[j] == '\''):
+				end := strings.IndexByte(s[j+1:], s[j])
+				if end < 0 {
+					val, j = s[j+1:], len(s)
+				} else {
+					val, j = s[j+1:j+1+end], j+1+end+1
+				}
+			default:
+				k := j
+				for k < len(s) && !isSpace(s[k]) && s[k] != '>' {
+					k++
+				}
+				val, j = s[j:k], k
+			}
+			i = j
+		}
+		if _, dup := tok.attr(key); !dup {
+			tok.attrs = append(tok.attrs, attribute{key: key, val: html.UnescapeString(val)})
+		}
+	}
+	return tok, len(s)
+}
+
+// indexEndTag returns the index of the "</name" that closes a raw text element, or -1.
+func indexEndTag(s, name string) int {
+	from := 0
+	for {
+		k := strings.Index(s[from:], "</")
+		if k < 0 {
+			return -1
+		}
+		k += from
+		after := k + 2 + len(name)
+		if after <= len(s) && strings.EqualFold(s[k+2:after], name) &&
+			(after == len(s) || isSpace(s[after]) || s[after] == '>' || s[after] == '/') {
+			return k
+		}
+		from = k + 2
+	}
+}
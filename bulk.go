@@ -0,0 +1,333 @@
+package nylas
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mqasimca/nylas-go/common"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// DefaultBulkConcurrency is the number of requests a bulk operation keeps in flight
+// unless WithBulkConcurrency is given.
+const DefaultBulkConcurrency = 5
+
+// DefaultBulkRateLimitRetries is how many extra attempts a bulk operation makes for
+// an ID that is still rate limited after the client's own retries.
+const DefaultBulkRateLimitRetries = 5
+
+// maxBulkPause caps how long a bulk operation pauses after a rate limit.
+const maxBulkPause = time.Minute
+
+// BulkOption configures a bulk operation.
+type BulkOption func(*bulkConfig)
+
+type bulkConfig struct {
+	concurrency      int
+	rateLimitRetries int
+	progress         func(done, total int)
+	dryRun           bool
+}
+
+// WithBulkConcurrency sets the number of requests in flight at once. Values below 1 mean 1.
+func WithBulkConcurrency(n int) BulkOption {
+	return func(c *bulkConfig) { c.concurrency = max(n, 1) }
+}
+
+// WithBulkRateLimitRetries sets how many extra attempts are made for an ID that is
+// still rate limited after the client's own retries. Every worker pauses until the
+// rate limit window resets before the ID is tried again.
+func WithBulkRateLimitRetries(n int) BulkOption {
+	return func(c *bulkConfig) { c.rateLimitRetries = max(n, 0) }
+}
+
+// WithBulkProgress sets a function called after each ID completes, with the number
+// of IDs done so far and the total. Calls are serialised.
+func WithBulkProgress(fn func(done, total int)) BulkOption {
+	return func(c *bulkConfig) { c.progress = fn }
+}
+
+// WithBulkDryRun resolves the IDs without changing anything. The report lists every
+// ID that would be affected, with DryRun set and no results recorded.
+func WithBulkDryRun() BulkOption {
+	return func(c *bulkConfig) { c.dryRun = true }
+}
+
+func newBulkConfig(opts []BulkOption) *bulkConfig {
+	c := &bulkConfig{concurrency: DefaultBulkConcurrency, rateLimitRetries: DefaultBulkRateLimitRetries}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BulkResult is the outcome of a bulk operation for one ID.
+type BulkResult struct {
+	// ID is the ID of the object operated on.
+	ID string
+	// Err is the error for this ID, or nil if it succeeded. IDs that were not
+	// attempted because the context ended carry the context's error.
+	Err error
+	// Attempts is the number of requests made for this ID.
+	Attempts int
+}
+
+// BulkReport is the outcome of a bulk operation.
+type BulkReport struct {
+	// Results has one entry per ID, in input order.
+	Results []BulkResult
+	// Succeeded is the number of IDs that were updated or deleted.
+	Succeeded int
+	// Failed is the number of IDs with an error.
+	Failed int
+	// DryRun is true if the report comes from WithBulkDryRun and nothing was changed.
+	DryRun bool
+}
+
+// IDs returns every ID in the report, in input order.
+func (r *BulkReport) IDs() []string {
+	ids := make([]string, len(r.Results))
+	for i, res := range r.Results {
+		ids[i] = res.ID
+	}
+	return ids
+}
+
+// FailedIDs returns the IDs with an error, in input order, e.g. to retry them later.
+func (r *BulkReport) FailedIDs() []string {
+	var ids []string
+	for _, res := range r.Results {
+		if res.Err != nil {
+			ids = append(ids, res.ID)
+		}
+	}
+	return ids
+}
+
+// Err returns the errors of all failed IDs joined together, or nil if none failed.
+func (r *BulkReport) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if res.Err != nil {
+			errs = append(errs, res.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runBulk calls do for each ID with bounded concurrency. An ID that fails with
+// ErrRateLimited pauses every worker until the rate limit resets and is retried.
+func (c *Client) runBulk(ctx context.Context, ids []string, cfg *bulkConfig, do func(ctx context.Context, id string) error) *BulkReport {
+	report := &BulkReport{Results: make([]BulkResult, len(ids)), DryRun: cfg.dryRun}
+	for i, id := range ids {
+		report.Results[i].ID = id
+	}
+	if cfg.dryRun {
+		return report
+	}
+
+	var (
+		pauseMu    sync.Mutex
+		pauseUntil time.Time
+		progressMu sync.Mutex
+		done       int
+	)
+	pause := func(d time.Duration) {
+		pauseMu.Lock()
+		defer pauseMu.Unlock()
+		if t := time.Now().Add(d); t.After(pauseUntil) {
+			pauseUntil = t
+		}
+	}
+	waitForPause := func() error {
+		pauseMu.Lock()
+		d := time.Until(pauseUntil)
+		pauseMu.Unlock()
+		if d <= 0 {
+			return ctx.Err()
+		}
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+			return nil
+		}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(cfg.concurrency, len(ids)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res := &report.Results[i]
+				for {
+					if res.Err = waitForPause(); res.Err != nil {
+						break
+					}
+					res.Attempts++
+					res.Err = do(ctx, res.ID)
+					if res.Err == nil || !errors.Is(res.Err, ErrRateLimited) || res.Attempts > cfg.rateLimitRetries {
+						break
+					}
+					pause(c.rateLimitPause(res.Attempts - 1))
+				}
+
+				if cfg.progress != nil {
+					progressMu.Lock()
+					done++
+					cfg.progress(done, len(ids))
+					progressMu.Unlock()
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range ids {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			for j := i; j < len(ids); j++ {
+				report.Results[j].Err = ctx.Err()
+			}
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, res := range report.Results {
+		if res.Err != nil {
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+	}
+	return report
+}
+
+// rateLimitPause returns how long to pause after a rate limit: until the reset time
+// from the last response if it is known, otherwise an exponential backoff.
+func (c *Client) rateLimitPause(attempt int) time.Duration {
+	if reset := c.RateLimits().Reset; !reset.IsZero() {
+		if d := time.Until(reset); d > 0 {
+			return min(d, maxBulkPause)
+		}
+	}
+	base := c.RetryWait
+	if base <= 0 {
+		base = time.Second
+	}
+	return min(base*time.Duration(1<<min(attempt, 10)), maxBulkPause)
+}
+
+// BulkUpdate applies update to every message in ids, e.g. to mark them read, star
+// them or move them to a folder, and reports the outcome for each ID.
+//
+// Requests run concurrently (see WithBulkConcurrency), and rate-limited IDs are
+// retried after pausing until the limit resets. The error is non-nil only if the
+// operation could not start, e.g. because update is invalid; per-ID failures are in
+// the report.
+//
+// Example:
+//
+//	report, err := client.Messages.BulkUpdate(ctx, grantID, ids, &messages.UpdateRequest{Unread: nylas.Ptr(false)})
+//	if err == nil && report.Failed > 0 {
+//	    log.Printf("%d messages failed: %v", report.Failed, report.Err())
+//	}
+func (s *MessagesService) BulkUpdate(ctx context.Context, grantID string, ids []string, update *messages.UpdateRequest, opts ...BulkOption) (*BulkReport, error) {
+	if err := s.client.validate(update); err != nil {
+		return nil, newOpError("messages.BulkUpdate", grantID, "", err)
+	}
+	return s.client.runBulk(ctx, ids, newBulkConfig(opts), func(ctx context.Context, id string) error {
+		_, err := s.Update(ctx, grantID, id, update)
+		return err
+	}), nil
+}
+
+// BulkDelete permanently deletes every message in ids, as Delete does, and reports the
+// outcome for each ID. It runs like BulkUpdate. To move messages to the trash
+// instead, call Trash for each of them.
+func (s *MessagesService) BulkDelete(ctx context.Context, grantID string, ids []string, opts ...BulkOption) (*BulkReport, error) {
+	return s.client.runBulk(ctx, ids, newBulkConfig(opts), func(ctx context.Context, id string) error {
+		return s.Delete(ctx, grantID, id)
+	}), nil
+}
+
+// BulkUpdateMatching applies update to every message matching query. All matching IDs
+// are listed first, so messages that stop matching because of the update are not
+// skipped. Use WithBulkDryRun to see how many messages would be affected:
+//
+//	preview, err := client.Messages.BulkUpdateMatching(ctx, grantID, query, update, nylas.WithBulkDryRun())
+//	log.Printf("would update %d messages", len(preview.Results))
+//	report, err := client.Messages.BulkUpdate(ctx, grantID, preview.IDs(), update)
+func (s *MessagesService) BulkUpdateMatching(ctx context.Context, grantID string, query *messages.ListOptions, update *messages.UpdateRequest, opts ...BulkOption) (*BulkReport, error) {
+	if err := s.client.validate(update); err != nil {
+		return nil, newOpError("messages.BulkUpdateMatching", grantID, "", err)
+	}
+	ids, err := s.matchingIDs(ctx, grantID, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.BulkUpdate(ctx, grantID, ids, update, opts...)
+}
+
+// BulkDeleteMatching permanently deletes every message matching query. All matching
+// IDs are listed before anything is deleted. Use WithBulkDryRun to count them first.
+//
+// The query must set at least one filter; a nil query, or one with only Limit,
+// PageToken or Fields, fails with ErrValidation rather than delete every message of
+// the grant.
+func (s *MessagesService) BulkDeleteMatching(ctx context.Context, grantID string, query *messages.ListOptions, opts ...BulkOption) (*BulkReport, error) {
+	if !hasFilter(query) {
+		v := &common.ValidationError{}
+		v.Add("", "a query with at least one filter is required")
+		return nil, newOpError("messages.BulkDeleteMatching", grantID, "", v)
+	}
+	ids, err := s.matchingIDs(ctx, grantID, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.BulkDelete(ctx, grantID, ids, opts...)
+}
+
+// hasFilter reports whether query narrows down the messages listed, beyond paging
+// and the fields returned.
+func hasFilter(query *messages.ListOptions) bool {
+	values := query.Values()
+	delete(values, "limit")
+	delete(values, "page_token")
+	delete(values, "fields")
+	return len(values) > 0
+}
+
+// matchingIDs lists the IDs of every message matching query, 200 per page by default.
+// The caller's options are not modified.
+func (s *MessagesService) matchingIDs(ctx context.Context, grantID string, query *messages.ListOptions) ([]string, error) {
+	var q messages.ListOptions
+	if query != nil {
+		q = *query
+	}
+	if q.Limit == nil {
+		q.Limit = Ptr(200)
+	}
+
+	var ids []string
+	it := s.ListAll(ctx, grantID, &q)
+	for {
+		msg, err := it.Next()
+		if errors.Is(err, ErrDone) {
+			return ids, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, msg.ID)
+	}
+}
@@ -0,0 +1,182 @@
+package nylas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+func TestMessagesService_BulkUpdate(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Method = %s, want PUT", r.Method)
+		}
+		if strings.HasSuffix(r.URL.Path, "/msg-2") {
+			w.WriteHeader(404)
+			_, _ = w.Write([]byte(`{"message": "not found", "type": "error"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"id": "x"}, "request_id": "req-1"})
+	})
+
+	var (
+		mu    sync.Mutex
+		calls []int
+	)
+	report, err := client.Messages.BulkUpdate(context.Background(), "grant-123",
+		[]string{"msg-1", "msg-2", "msg-3"}, &messages.UpdateRequest{Unread: Ptr(false)},
+		WithBulkConcurrency(2),
+		WithBulkProgress(func(done, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			if total != 3 {
+				t.Errorf("progress total = %d, want 3", total)
+			}
+			calls = append(calls, done)
+		}))
+	if err != nil {
+		t.Fatalf("BulkUpdate() error = %v", err)
+	}
+	if report.Succeeded != 2 || report.Failed != 1 {
+		t.Errorf("Succeeded, Failed = %d, %d, want 2, 1", report.Succeeded, report.Failed)
+	}
+	if got := report.FailedIDs(); len(got) != 1 || got[0] != "msg-2" {
+		t.Errorf("FailedIDs() = %v, want [msg-2]", got)
+	}
+	if !errors.Is(report.Err(), ErrNotFound) {
+		t.Errorf("Err() = %v, want ErrNotFound", report.Err())
+	}
+	if got := report.IDs(); strings.Join(got, ",") != "msg-1,msg-2,msg-3" {
+		t.Errorf("IDs() = %v, want input order", got)
+	}
+	if len(calls) != 3 || calls[2] != 3 {
+		t.Errorf("progress calls = %v, want 1..3", calls)
+	}
+}
+
+func TestMessagesService_BulkUpdate_Invalid(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	_, err := client.Messages.BulkUpdate(context.Background(), "grant-123", []string{"msg-1"},
+		&messages.UpdateRequest{Metadata: map[string]string{"": "x"}})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("BulkUpdate() error = %v, want ErrValidation", err)
+	}
+}
+
+func TestMessagesService_BulkDeleteMatching_NoFilter(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	for _, query := range []*messages.ListOptions{nil, {}, {Limit: Ptr(50), Fields: Ptr("include_headers")}} {
+		_, err := client.Messages.BulkDeleteMatching(context.Background(), "grant-123", query, WithBulkDryRun())
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("BulkDeleteMatching(%+v) error = %v, want ErrValidation", query, err)
+		}
+	}
+}
+
+func TestMessagesService_BulkDelete_RateLimited(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(429)
+			_, _ = w.Write([]byte(`{"message": "rate limit exceeded", "type": "error"}`))
+			return
+		}
+		w.WriteHeader(200)
+	})
+	client.RetryWait = time.Millisecond
+
+	report, err := client.Messages.BulkDelete(context.Background(), "grant-123", []string{"msg-1"})
+	if err != nil {
+		t.Fatalf("BulkDelete() error = %v", err)
+	}
+	if report.Succeeded != 1 {
+		t.Errorf("Succeeded = %d, want 1 (err %v)", report.Succeeded, report.Err())
+	}
+	if got := report.Results[0].Attempts; got != 2 {
+		t.Errorf("Attempts = %d, want 2", got)
+	}
+
+	requests.Store(0)
+	report, _ = client.Messages.BulkDelete(context.Background(), "grant-123", []string{"msg-1"},
+		WithBulkRateLimitRetries(0))
+	if !errors.Is(report.Err(), ErrRateLimited) {
+		t.Errorf("Err() = %v, want ErrRateLimited", report.Err())
+	}
+}
+
+func TestMessagesService_BulkDelete_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(200)
+	})
+
+	report, err := client.Messages.BulkDelete(ctx, "grant-123", []string{"msg-1", "msg-2", "msg-3"},
+		WithBulkConcurrency(1))
+	if err != nil {
+		t.Fatalf("BulkDelete() error = %v", err)
+	}
+	if report.Failed == 0 || !errors.Is(report.Err(), context.Canceled) {
+		t.Errorf("Failed = %d, Err() = %v, want context.Canceled", report.Failed, report.Err())
+	}
+	if report.Succeeded+report.Failed != 3 {
+		t.Errorf("Succeeded+Failed = %d, want 3", report.Succeeded+report.Failed)
+	}
+}
+
+func TestMessagesService_BulkDeleteMatching(t *testing.T) {
+	var deletes atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if got := r.URL.Query().Get("limit"); got != "200" {
+				t.Errorf("limit = %q, want 200", got)
+			}
+			if got := r.URL.Query().Get("in"); got != "SPAM" {
+				t.Errorf("in = %q, want SPAM", got)
+			}
+			resp := map[string]any{"data": []map[string]string{{"id": "msg-1"}, {"id": "msg-2"}}, "next_cursor": "p2"}
+			if r.URL.Query().Get("page_token") == "p2" {
+				resp = map[string]any{"data": []map[string]string{{"id": "msg-3"}}}
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		case http.MethodDelete:
+			deletes.Add(1)
+			w.WriteHeader(200)
+		}
+	})
+	query := &messages.ListOptions{In: Ptr("SPAM")}
+
+	preview, err := client.Messages.BulkDeleteMatching(context.Background(), "grant-123", query, WithBulkDryRun())
+	if err != nil {
+		t.Fatalf("BulkDeleteMatching() error = %v", err)
+	}
+	if !preview.DryRun || len(preview.Results) != 3 || deletes.Load() != 0 {
+		t.Errorf("dry run: DryRun = %v, results = %d, deletes = %d", preview.DryRun, len(preview.Results), deletes.Load())
+	}
+	if query.Limit != nil || query.PageToken != "" {
+		t.Error("query was modified")
+	}
+
+	report, err := client.Messages.BulkDeleteMatching(context.Background(), "grant-123", query)
+	if err != nil {
+		t.Fatalf("BulkDeleteMatching() error = %v", err)
+	}
+	if report.Succeeded != 3 || deletes.Load() != 3 {
+		t.Errorf("Succeeded = %d, deletes = %d, want 3, 3", report.Succeeded, deletes.Load())
+	}
+}
@@ -14,7 +14,7 @@ func (s *CalendarsService) List(ctx context.Context, grantID string, opts *calen
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.List: %w", err)
+		return nil, newOpError("calendars.List", grantID, "", err)
 	}
 
 	q := req.URL.Query()
@@ -30,7 +30,7 @@ func (s *CalendarsService) List(ctx context.Context, grantID string, opts *calen
 	var data []calendars.Calendar
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.List: %w", err)
+		return nil, newOpError("calendars.List", grantID, "", err)
 	}
 
 	return &ListResponse[calendars.Calendar]{
@@ -46,13 +46,13 @@ func (s *CalendarsService) Get(ctx context.Context, grantID, calendarID string)
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.Get(%s): %w", calendarID, err)
+		return nil, newOpError("calendars.Get", grantID, calendarID, err)
 	}
 
 	var cal calendars.Calendar
 	_, err = s.client.Do(req, &cal)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.Get(%s): %w", calendarID, err)
+		return nil, newOpError("calendars.Get", grantID, calendarID, err)
 	}
 
 	return &cal, nil
@@ -64,13 +64,13 @@ func (s *CalendarsService) Create(ctx context.Context, grantID string, create *c
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, create)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.Create: %w", err)
+		return nil, newOpError("calendars.Create", grantID, "", err)
 	}
 
 	var cal calendars.Calendar
 	_, err = s.client.Do(req, &cal)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.Create: %w", err)
+		return nil, newOpError("calendars.Create", grantID, "", err)
 	}
 
 	return &cal, nil
@@ -82,13 +82,13 @@ func (s *CalendarsService) Update(ctx context.Context, grantID, calendarID strin
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.Update(%s): %w", calendarID, err)
+		return nil, newOpError("calendars.Update", grantID, calendarID, err)
 	}
 
 	var cal calendars.Calendar
 	_, err = s.client.Do(req, &cal)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.Update(%s): %w", calendarID, err)
+		return nil, newOpError("calendars.Update", grantID, calendarID, err)
 	}
 
 	return &cal, nil
@@ -100,12 +100,12 @@ func (s *CalendarsService) Delete(ctx context.Context, grantID, calendarID strin
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("calendars.Delete(%s): %w", calendarID, err)
+		return newOpError("calendars.Delete", grantID, calendarID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("calendars.Delete(%s): %w", calendarID, err)
+		return newOpError("calendars.Delete", grantID, calendarID, err)
 	}
 
 	return nil
@@ -134,13 +134,13 @@ func (s *CalendarsService) Availability(ctx context.Context, avail *calendars.Av
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, avail)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.Availability: %w", err)
+		return nil, newOpError("calendars.Availability", "", "", err)
 	}
 
 	var result calendars.AvailabilityResponse
 	_, err = s.client.Do(req, &result)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.Availability: %w", err)
+		return nil, newOpError("calendars.Availability", "", "", err)
 	}
 
 	return &result, nil
@@ -152,13 +152,13 @@ func (s *CalendarsService) FreeBusy(ctx context.Context, grantID string, freeBus
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, freeBusy)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.FreeBusy: %w", err)
+		return nil, newOpError("calendars.FreeBusy", grantID, "", err)
 	}
 
 	var result []calendars.FreeBusyResponse
 	_, err = s.client.Do(req, &result)
 	if err != nil {
-		return nil, fmt.Errorf("calendars.FreeBusy: %w", err)
+		return nil, newOpError("calendars.FreeBusy", grantID, "", err)
 	}
 
 	return result, nil
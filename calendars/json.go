@@ -0,0 +1,17 @@
+package calendars
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes c, keeping fields this package does not declare in Extra.
+func (c *Calendar) UnmarshalJSON(data []byte) error {
+	type alias Calendar
+	extra, err := common.UnmarshalExtra(data, (*alias)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes c together with the fields kept in Extra.
+func (c Calendar) MarshalJSON() ([]byte, error) {
+	type alias Calendar
+	return common.MarshalExtra(alias(c), c.Extra)
+}
@@ -0,0 +1,43 @@
+package calendars
+
+import (
+	"time"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// SetTimeRange sets the window to check availability in.
+//
+// Example:
+//
+//	req := (&calendars.AvailabilityRequest{Participants: participants}).
+//	    SetTimeRange(time.Now(), time.Now().Add(7*24*time.Hour)).
+//	    SetDuration(30 * time.Minute)
+func (r *AvailabilityRequest) SetTimeRange(start, end time.Time) *AvailabilityRequest {
+	r.StartTime, r.EndTime = start.Unix(), end.Unix()
+	return r
+}
+
+// SetDuration sets the meeting duration, truncated to whole minutes.
+func (r *AvailabilityRequest) SetDuration(d time.Duration) *AvailabilityRequest {
+	r.DurationMinutes = common.Minutes(d)
+	return r
+}
+
+// SetInterval sets the interval between available slots, truncated to whole minutes.
+func (r *AvailabilityRequest) SetInterval(d time.Duration) *AvailabilityRequest {
+	minutes := common.Minutes(d)
+	r.IntervalMinutes = &minutes
+	return r
+}
+
+// SetTimeRange sets the window to query free/busy data for.
+func (r *FreeBusyRequest) SetTimeRange(start, end time.Time) *FreeBusyRequest {
+	r.StartTime, r.EndTime = start.Unix(), end.Unix()
+	return r
+}
+
+// NewBuffer returns buffer times before and after meetings, truncated to whole minutes.
+func NewBuffer(before, after time.Duration) *Buffer {
+	return &Buffer{Before: common.Minutes(before), After: common.Minutes(after)}
+}
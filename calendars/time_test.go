@@ -0,0 +1,39 @@
+package calendars
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAvailabilityRequest_TimeSetters(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	req := (&AvailabilityRequest{Participants: []AvailabilityParticipant{{Email: "a@example.com"}}}).
+		SetTimeRange(start, start.Add(8*time.Hour)).
+		SetDuration(45 * time.Minute).
+		SetInterval(15*time.Minute + 30*time.Second)
+
+	if req.StartTime != 1700000000 || req.EndTime != 1700028800 || req.DurationMinutes != 45 || *req.IntervalMinutes != 15 {
+		t.Errorf("AvailabilityRequest = %+v", req)
+	}
+	if err := req.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	if err := req.SetDuration(30 * time.Second).Validate(); err == nil {
+		t.Error("Validate() with sub-minute duration error = nil")
+	}
+
+	fb := (&FreeBusyRequest{Emails: []string{"a@example.com"}}).SetTimeRange(start, start.Add(time.Hour))
+	if fb.StartTime != 1700000000 || fb.EndTime != 1700003600 || fb.Validate() != nil {
+		t.Errorf("FreeBusyRequest = %+v", fb)
+	}
+
+	fb.StartTime, fb.EndTime = start.UnixMilli(), start.Add(time.Hour).UnixMilli()
+	if err := fb.Validate(); err == nil {
+		t.Error("Validate() with milliseconds error = nil")
+	}
+
+	if b := NewBuffer(10*time.Minute, 5*time.Minute); b.Before != 10 || b.After != 5 {
+		t.Errorf("NewBuffer() = %+v", b)
+	}
+}
@@ -1,5 +1,7 @@
 package calendars
 
+import "encoding/json"
+
 // Calendar represents a calendar in the Nylas API.
 type Calendar struct {
 	// ID is the unique identifier for this calendar.
@@ -28,6 +30,9 @@ type Calendar struct {
 	HexForegroundColor string `json:"hex_foreground_color,omitempty"`
 	// Metadata contains custom key-value pairs.
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ListOptions specifies options for listing calendars.
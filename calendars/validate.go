@@ -0,0 +1,97 @@
+package calendars
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks that Days are 0-6, Start and End are "HH:MM" with End after Start,
+// and Exdates are YYYY-MM-DD dates.
+func (h *OpenHours) Validate() error {
+	v := &common.ValidationError{}
+	for i, d := range h.Days {
+		if d < 0 || d > 6 {
+			v.Add(common.IndexPath("days", i), "%d is not a day of the week (0-6)", d)
+		}
+	}
+	common.CheckClockRange(v, "start", h.Start, "end", h.End)
+	for i, d := range h.Exdates {
+		if !common.ValidDate(d) {
+			v.Add(common.IndexPath("exdates", i), "%q is not a YYYY-MM-DD date", d)
+		}
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *CreateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Name == "" {
+		v.Add("name", "is required")
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Name != nil && *r.Name == "" {
+		v.Add("name", "must not be empty")
+	}
+	if r.HexColor != nil && !common.ValidHexColor(*r.HexColor) {
+		v.Add("hex_color", "%q is not a #RRGGBB color", *r.HexColor)
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *AvailabilityRequest) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckTimeRange(v, "start_time", r.StartTime, "end_time", r.EndTime)
+	if r.DurationMinutes <= 0 {
+		v.Add("duration_minutes", "must be positive")
+	}
+	if r.IntervalMinutes != nil && *r.IntervalMinutes <= 0 {
+		v.Add("interval_minutes", "must be positive")
+	}
+	if len(r.Participants) == 0 {
+		v.Add("participants", "at least one participant is required")
+	}
+	for i, p := range r.Participants {
+		path := common.IndexPath("participants", i)
+		common.CheckEmail(v, common.JoinPath(path, "email"), p.Email)
+		checkOpenHours(v, common.JoinPath(path, "open_hours"), p.OpenHours)
+	}
+	if rules := r.AvailabilityRules; rules != nil {
+		if rules.AvailabilityMethod != "" {
+			common.CheckOneOf(v, "availability_rules.availability_method", rules.AvailabilityMethod,
+				"collective", "max-fairness", "max-availability")
+		}
+		if rules.Buffer != nil {
+			if rules.Buffer.Before < 0 {
+				v.Add("availability_rules.buffer.before", "must not be negative")
+			}
+			if rules.Buffer.After < 0 {
+				v.Add("availability_rules.buffer.after", "must not be negative")
+			}
+		}
+		checkOpenHours(v, "availability_rules.default_open_hours", rules.DefaultOpenHours)
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *FreeBusyRequest) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckTimeRange(v, "start_time", r.StartTime, "end_time", r.EndTime)
+	if len(r.Emails) == 0 {
+		v.Add("emails", "at least one email is required")
+	}
+	for i, e := range r.Emails {
+		common.CheckEmail(v, common.IndexPath("emails", i), e)
+	}
+	return v.Err()
+}
+
+func checkOpenHours(v *common.ValidationError, path string, list []OpenHours) {
+	for i := range list {
+		v.Merge(common.IndexPath(path, i), list[i].Validate())
+	}
+}
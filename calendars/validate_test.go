@@ -0,0 +1,88 @@
+package calendars
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestOpenHours_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours OpenHours
+		want  []string
+	}{
+		{"valid", OpenHours{Days: []int{1, 2, 3}, Start: "09:00", End: "17:00", Exdates: []string{"2024-12-25"}}, nil},
+		{"malformed times", OpenHours{Start: "9am", End: "25:00"}, []string{"start", "end"}},
+		{"end before start", OpenHours{Start: "17:00", End: "09:00"}, []string{"end"}},
+		{"bad day and exdate", OpenHours{Days: []int{7}, Start: "09:00", End: "10:00", Exdates: []string{"Dec 25"}}, []string{"days[0]", "exdates[0]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldPaths(t, tt.hours.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() paths = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvailabilityRequest_Validate(t *testing.T) {
+	req := &AvailabilityRequest{
+		StartTime: 200,
+		EndTime:   100,
+		Participants: []AvailabilityParticipant{
+			{Email: "a@example.com", OpenHours: []OpenHours{{Start: "09:00", End: "9:30"}}},
+		},
+		AvailabilityRules: &AvailabilityRules{AvailabilityMethod: "random", Buffer: &Buffer{Before: -1}},
+	}
+	want := []string{"end_time", "duration_minutes", "participants[0].open_hours[0].end", "availability_rules.availability_method", "availability_rules.buffer.before"}
+	if got := fieldPaths(t, req.Validate()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() paths = %q, want %q", got, want)
+	}
+
+	valid := &AvailabilityRequest{StartTime: 100, EndTime: 200, DurationMinutes: 30, Participants: []AvailabilityParticipant{{Email: "a@example.com"}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestCalendarRequests_Validate(t *testing.T) {
+	empty, badColor := "", "blue"
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"create valid", (&CreateRequest{Name: "Team"}).Validate(), nil},
+		{"update", (&UpdateRequest{Name: &empty, HexColor: &badColor}).Validate(), []string{"name", "hex_color"}},
+		{"free/busy", (&FreeBusyRequest{StartTime: 100, EndTime: 200, Emails: []string{"nope"}}).Validate(), []string{"emails[0]"}},
+		{"free/busy empty", (&FreeBusyRequest{}).Validate(), []string{"start_time", "end_time", "emails"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldPaths(t, tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() paths = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func fieldPaths(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verr *common.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *common.ValidationError", err)
+	}
+	paths := make([]string, len(verr.Fields))
+	for i, f := range verr.Fields {
+		paths[i] = f.Path
+	}
+	return paths
+}
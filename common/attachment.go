@@ -0,0 +1,311 @@
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultMaxAttachmentSize is the size limit applied by the attachment constructors
+// unless WithMaxSize is given. It matches the Nylas limit for a whole message.
+const DefaultMaxAttachmentSize = 25 << 20
+
+// sniffLen is the number of bytes http.DetectContentType looks at.
+const sniffLen = 512
+
+// ErrAttachmentConsumed is returned when an attachment made by AttachmentFromFile
+// or AttachmentFromReader is read again after it was sent, instead of sending an
+// empty file.
+var ErrAttachmentConsumed = errors.New("nylas: attachment already consumed")
+
+// ErrAttachmentTooLarge matches every *AttachmentTooLargeError. Use errors.Is to check for it.
+var ErrAttachmentTooLarge = errors.New("nylas: attachment too large")
+
+// AttachmentTooLargeError is returned by the attachment constructors when the data
+// exceeds the size limit.
+type AttachmentTooLargeError struct {
+	// Filename is the name of the attachment.
+	Filename string
+	// Size is the attachment size in bytes. For readers it is the number of bytes
+	// read before giving up, which is just over Limit.
+	Size int64
+	// Limit is the size limit that was exceeded.
+	Limit int64
+}
+
+// Error implements the error interface.
+func (e *AttachmentTooLargeError) Error() string {
+	return fmt.Sprintf("nylas: attachment %q is %d bytes, over the limit of %d", e.Filename, e.Size, e.Limit)
+}
+
+// Is implements errors.Is for matching against ErrAttachmentTooLarge.
+func (e *AttachmentTooLargeError) Is(target error) bool {
+	return target == ErrAttachmentTooLarge
+}
+
+// AttachmentOption configures the attachment constructors.
+type AttachmentOption func(*attachmentConfig)
+
+type attachmentConfig struct {
+	maxSize     int64
+	contentType string
+}
+
+// WithMaxSize sets the size limit in bytes. Zero or a negative value disables the limit.
+func WithMaxSize(n int64) AttachmentOption {
+	return func(c *attachmentConfig) { c.maxSize = n }
+}
+
+// WithContentType sets the content type instead of detecting it.
+func WithContentType(contentType string) AttachmentOption {
+	return func(c *attachmentConfig) { c.contentType = contentType }
+}
+
+func newAttachmentConfig(opts []AttachmentOption) *attachmentConfig {
+	c := &attachmentConfig{maxSize: DefaultMaxAttachmentSize}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// AttachmentFromFile returns an attachment for the file at path, named after its base name.
+// The file is not read into memory: it is opened when the request is sent and streamed
+// from disk, which makes the request multipart/form-data. The content type comes from
+// the extension, or from the first bytes of the file if the extension is unknown.
+//
+// The attachment can be sent once. Reading it again fails with ErrAttachmentConsumed;
+// call AttachmentFromFile again to send the file in another request.
+//
+// Example:
+//
+//	att, err := common.AttachmentFromFile("contract.pdf")
+//	if err != nil {
+//	    return err
+//	}
+//	req.Attachments = append(req.Attachments, att)
+func AttachmentFromFile(path string, opts ...AttachmentOption) (AttachmentRequest, error) {
+	cfg := newAttachmentConfig(opts)
+	name := filepath.Base(path)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return AttachmentRequest{}, err
+	}
+	if info.IsDir() {
+		return AttachmentRequest{}, fmt.Errorf("nylas: attachment %q is a directory", path)
+	}
+	if cfg.maxSize > 0 && info.Size() > cfg.maxSize {
+		return AttachmentRequest{}, &AttachmentTooLargeError{Filename: name, Size: info.Size(), Limit: cfg.maxSize}
+	}
+
+	contentType := cfg.contentType
+	if contentType == "" {
+		contentType = typeByExtension(name)
+	}
+	if contentType == "" {
+		head, err := readFileHead(path)
+		if err != nil {
+			return AttachmentRequest{}, err
+		}
+		contentType = http.DetectContentType(head)
+	}
+
+	return AttachmentRequest{
+		Filename:    name,
+		ContentType: contentType,
+		Reader: &onceReader{
+			open:  func() (io.ReadCloser, error) { return os.Open(path) },
+			name:  name,
+			limit: cfg.maxSize,
+		},
+	}, nil
+}
+
+// AttachmentFromReader returns an attachment named name that streams the data from
+// r when the request is sent, which makes the request multipart/form-data. Only the
+// first bytes are read up front, to detect the content type if the extension of
+// name is unknown. The size limit is checked as the data is sent.
+//
+// Like AttachmentFromFile, the attachment can be sent once and is not retried;
+// r is not closed.
+func AttachmentFromReader(name string, r io.Reader, opts ...AttachmentOption) (AttachmentRequest, error) {
+	cfg := newAttachmentConfig(opts)
+	head, err := readHead(name, r, cfg)
+	if err != nil {
+		return AttachmentRequest{}, err
+	}
+	body := io.NopCloser(io.MultiReader(bytes.NewReader(head), r))
+	return AttachmentRequest{
+		Filename:    name,
+		ContentType: detectType(name, head, cfg, true),
+		Reader: &onceReader{
+			open:  func() (io.ReadCloser, error) { return body, nil },
+			name:  name,
+			limit: cfg.maxSize,
+		},
+	}, nil
+}
+
+// readHead reads the first sniffLen bytes of r, failing if they already exceed
+// the size limit.
+func readHead(name string, r io.Reader, cfg *attachmentConfig) ([]byte, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	if cfg.maxSize > 0 && int64(n) > cfg.maxSize {
+		return nil, &AttachmentTooLargeError{Filename: name, Size: int64(n), Limit: cfg.maxSize}
+	}
+	return head[:n], nil
+}
+
+// detectType returns cfg.contentType if set, else that of the name's extension if
+// byExtension is true, else the type detected from head.
+func detectType(name string, head []byte, cfg *attachmentConfig, byExtension bool) string {
+	if cfg.contentType != "" {
+		return cfg.contentType
+	}
+	if byExtension {
+		if t := typeByExtension(name); t != "" {
+			return t
+		}
+	}
+	return http.DetectContentType(head)
+}
+
+// InlineImage returns an inline image attachment that an HTML body can reference
+// as <img src="cid:...">. The image type is detected from the data unless
+// WithContentType is given, never from cid, and the file name is derived from cid.
+// It fails if the type is not an image type.
+//
+// Example:
+//
+//	logo, err := common.InlineImage("logo", bytes.NewReader(png))
+//	req.Body = `<p>Hello</p><img src="cid:logo">`
+//	req.Attachments = append(req.Attachments, logo)
+func InlineImage(cid string, r io.Reader, opts ...AttachmentOption) (AttachmentRequest, error) {
+	att, err := readInline(cid, r, newAttachmentConfig(opts))
+	if err != nil {
+		return AttachmentRequest{}, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(att.ContentType)
+	if !strings.HasPrefix(mediaType, "image/") {
+		return AttachmentRequest{}, fmt.Errorf("nylas: inline image %q has content type %q, want image/*", cid, att.ContentType)
+	}
+	if filepath.Ext(cid) == "" {
+		att.Filename = cid + imageExtensions[mediaType]
+	}
+	att.ContentID = cid
+	att.IsInline = true
+	return att, nil
+}
+
+// readInline reads r into a base64 attachment named cid. Inline images are small
+// and referenced from the body, so they are kept as Content, which a request can
+// resend, rather than streamed.
+func readInline(cid string, r io.Reader, cfg *attachmentConfig) (AttachmentRequest, error) {
+	head, err := readHead(cid, r, cfg)
+	if err != nil {
+		return AttachmentRequest{}, err
+	}
+	var src io.Reader = io.MultiReader(bytes.NewReader(head), r)
+	if cfg.maxSize > 0 {
+		src = io.LimitReader(src, cfg.maxSize+1)
+	}
+	var buf strings.Builder
+	enc := base64.NewEncoder(base64.StdEncoding, &buf)
+	size, err := io.Copy(enc, src)
+	if err != nil {
+		return AttachmentRequest{}, err
+	}
+	if cfg.maxSize > 0 && size > cfg.maxSize {
+		return AttachmentRequest{}, &AttachmentTooLargeError{Filename: cid, Size: size, Limit: cfg.maxSize}
+	}
+	if err := enc.Close(); err != nil {
+		return AttachmentRequest{}, err
+	}
+	return AttachmentRequest{Filename: cid, ContentType: detectType(cid, head, cfg, false), Content: buf.String()}, nil
+}
+
+// imageExtensions maps image types to the file extension used for inline image names.
+var imageExtensions = map[string]string{
+	"image/png":     ".png",
+	"image/jpeg":    ".jpg",
+	"image/gif":     ".gif",
+	"image/webp":    ".webp",
+	"image/bmp":     ".bmp",
+	"image/svg+xml": ".svg",
+	"image/x-icon":  ".ico",
+}
+
+func typeByExtension(name string) string {
+	ext := filepath.Ext(name)
+	if ext == "" {
+		return ""
+	}
+	return mime.TypeByExtension(strings.ToLower(ext))
+}
+
+func readFileHead(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return head[:n], nil
+}
+
+// onceReader streams attachment data from the reader open returns, calling it on
+// the first Read and closing the reader at EOF or on error, so an attachment that
+// is never sent holds no file descriptor. It fails once more than limit bytes are
+// read, and once the data has been read to EOF, further reads fail with
+// ErrAttachmentConsumed.
+type onceReader struct {
+	open  func() (io.ReadCloser, error)
+	name  string
+	limit int64
+	rc    io.ReadCloser
+	n     int64
+	err   error
+}
+
+func (r *onceReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.rc == nil {
+		rc, err := r.open()
+		if err != nil {
+			r.err = err
+			return 0, err
+		}
+		r.rc = rc
+	}
+	n, err := r.rc.Read(p)
+	r.n += int64(n)
+	if err == nil && r.limit > 0 && r.n > r.limit {
+		err = &AttachmentTooLargeError{Filename: r.name, Size: r.n, Limit: r.limit}
+	}
+	if err != nil {
+		r.err = err
+		if err == io.EOF {
+			r.err = fmt.Errorf("%w: %q was already sent", ErrAttachmentConsumed, r.name)
+		}
+		_ = r.rc.Close()
+	}
+	return n, err
+}
@@ -0,0 +1,135 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestAttachmentFromFile(t *testing.T) {
+	dir := t.TempDir()
+	pdf := filepath.Join(dir, "contract.pdf")
+	if err := os.WriteFile(pdf, []byte("%PDF-1.4 body"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	noExt := filepath.Join(dir, "scan")
+	if err := os.WriteFile(noExt, pngHeader, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	att, err := AttachmentFromFile(pdf)
+	if err != nil {
+		t.Fatalf("AttachmentFromFile() error = %v", err)
+	}
+	if att.Filename != "contract.pdf" || att.ContentType != "application/pdf" || att.Content != "" {
+		t.Errorf("attachment = %+v", att)
+	}
+	if err := att.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	data, err := io.ReadAll(att.Reader)
+	if err != nil || string(data) != "%PDF-1.4 body" {
+		t.Errorf("Reader = %q, %v", data, err)
+	}
+	if _, err := io.ReadAll(att.Reader); !errors.Is(err, ErrAttachmentConsumed) {
+		t.Errorf("second read error = %v, want ErrAttachmentConsumed", err)
+	}
+
+	att, err = AttachmentFromFile(noExt)
+	if err != nil {
+		t.Fatalf("AttachmentFromFile() error = %v", err)
+	}
+	if att.ContentType != "image/png" {
+		t.Errorf("sniffed ContentType = %q, want image/png", att.ContentType)
+	}
+
+	_, err = AttachmentFromFile(pdf, WithMaxSize(4))
+	var tooLarge *AttachmentTooLargeError
+	if !errors.As(err, &tooLarge) || !errors.Is(err, ErrAttachmentTooLarge) {
+		t.Fatalf("AttachmentFromFile() error = %v, want *AttachmentTooLargeError", err)
+	}
+	if tooLarge.Size != 13 || tooLarge.Limit != 4 || tooLarge.Filename != "contract.pdf" {
+		t.Errorf("error = %+v", tooLarge)
+	}
+
+	if _, err := AttachmentFromFile(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("AttachmentFromFile() of missing file error = nil")
+	}
+}
+
+func TestAttachmentFromReader(t *testing.T) {
+	data := strings.Repeat("a,b,c\n", 200)
+	att, err := AttachmentFromReader("report.json", strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("AttachmentFromReader() error = %v", err)
+	}
+	if att.ContentType != "application/json" {
+		t.Errorf("ContentType = %q, want application/json", att.ContentType)
+	}
+	if att.Content != "" || att.Reader == nil {
+		t.Fatalf("attachment = %+v, want it streamed from Reader", att)
+	}
+	if got, err := io.ReadAll(att.Reader); err != nil || string(got) != data {
+		t.Errorf("Reader = %d bytes, %v, want the original data", len(got), err)
+	}
+	if _, err := io.ReadAll(att.Reader); !errors.Is(err, ErrAttachmentConsumed) {
+		t.Errorf("second read error = %v, want ErrAttachmentConsumed", err)
+	}
+
+	att, err = AttachmentFromReader("blob", bytes.NewReader([]byte("plain words")), WithContentType("application/x-custom"))
+	if err != nil || att.ContentType != "application/x-custom" {
+		t.Errorf("WithContentType: %+v, %v", att, err)
+	}
+
+	_, err = AttachmentFromReader("big.bin", strings.NewReader(data), WithMaxSize(100))
+	if !errors.Is(err, ErrAttachmentTooLarge) {
+		t.Errorf("AttachmentFromReader() error = %v, want ErrAttachmentTooLarge", err)
+	}
+	// Past the sniffed head, the limit is checked as the data is sent.
+	att, err = AttachmentFromReader("big.bin", strings.NewReader(data), WithMaxSize(1000))
+	if err != nil {
+		t.Fatalf("AttachmentFromReader() error = %v", err)
+	}
+	if _, err := io.ReadAll(att.Reader); !errors.Is(err, ErrAttachmentTooLarge) {
+		t.Errorf("Reader error = %v, want ErrAttachmentTooLarge", err)
+	}
+	att, err = AttachmentFromReader("big.bin", strings.NewReader(data), WithMaxSize(0))
+	if err != nil {
+		t.Fatalf("AttachmentFromReader() without limit error = %v", err)
+	}
+	if got, err := io.ReadAll(att.Reader); err != nil || len(got) != len(data) {
+		t.Errorf("Reader without limit = %d bytes, %v", len(got), err)
+	}
+}
+
+func TestInlineImage(t *testing.T) {
+	att, err := InlineImage("logo", bytes.NewReader(pngHeader))
+	if err != nil {
+		t.Fatalf("InlineImage() error = %v", err)
+	}
+	if att.Filename != "logo.png" || att.ContentID != "logo" || !att.IsInline || att.ContentType != "image/png" {
+		t.Errorf("attachment = %+v", att)
+	}
+
+	if _, err := InlineImage("notes", strings.NewReader("just text")); err == nil {
+		t.Error("InlineImage() of text error = nil")
+	}
+	// The type comes from the data, not from an extension-like cid.
+	if _, err := InlineImage("chart.png", strings.NewReader("just text")); err == nil {
+		t.Error("InlineImage() of text with a .png cid error = nil")
+	}
+	att, err = InlineImage("photo.jpg", bytes.NewReader(pngHeader))
+	if err != nil || att.ContentType != "image/png" || att.Filename != "photo.jpg" {
+		t.Errorf("InlineImage(photo.jpg) = %+v, %v", att, err)
+	}
+	att, err = InlineImage("chart.svg", strings.NewReader("<svg/>"), WithContentType("image/svg+xml"))
+	if err != nil || att.ContentType != "image/svg+xml" {
+		t.Errorf("InlineImage() WithContentType = %+v, %v", att, err)
+	}
+}
@@ -0,0 +1,256 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mqasimca/nylas-go/bodytext"
+)
+
+// EmbedOptions configures EmbedImages. A nil *EmbedOptions embeds data: images only.
+type EmbedOptions struct {
+	// MaxSize caps the size of each image in bytes. Defaults to
+	// DefaultMaxAttachmentSize; a negative value disables the limit.
+	MaxSize int64
+	// MaxTotalSize caps the size of all embedded images together. Defaults to
+	// DefaultMaxAttachmentSize; a negative value disables the limit.
+	MaxTotalSize int64
+	// BaseDir enables images from local files. Relative paths and file: URLs are
+	// resolved against it, and files outside it, including those reached through
+	// a symbolic link, are left alone.
+	BaseDir string
+	// HTTPClient, if set, downloads http and https images so they are embedded too.
+	HTTPClient *http.Client
+}
+
+// EmbedImages turns the images of an HTML body into inline attachments, so they
+// display in clients that strip data: URIs and never load remote images. It
+// returns the body with each embedded <img> pointing at a cid: URL, and
+// attachments with the new inline images appended.
+//
+// data: images are always embedded; local files and remote images only when
+// enabled in opts. Identical images share one attachment. Content IDs are derived
+// from the image data, so an image already in attachments is not added again. Other
+// images, and data: URIs that are not images, are left as they are.
+//
+// An image over the size limits fails with an *AttachmentTooLargeError, as do
+// images that together exceed MaxTotalSize.
+//
+// Use SendRequest.EmbedImages in package messages or CreateRequest.EmbedImages in
+// package drafts to update a request in place.
+func EmbedImages(ctx context.Context, body string, attachments []AttachmentRequest, opts *EmbedOptions) (string, []AttachmentRequest, error) {
+	// Clip attachments so appending never writes into the caller's array.
+	e := &embedder{ctx: ctx, attachments: attachments[:len(attachments):len(attachments)], cids: make(map[string]bool)}
+	for _, a := range attachments {
+		if a.ContentID != "" {
+			e.cids[a.ContentID] = true
+		}
+	}
+	if opts != nil {
+		e.opts = *opts
+	}
+	if e.opts.MaxSize == 0 {
+		e.opts.MaxSize = DefaultMaxAttachmentSize
+	}
+	if e.opts.MaxTotalSize == 0 {
+		e.opts.MaxTotalSize = DefaultMaxAttachmentSize
+	}
+
+	var err error
+	out := bodytext.ReplaceImageSources(body, func(src string) (string, bool) {
+		if err != nil {
+			return "", false
+		}
+		var cid string
+		cid, err = e.embed(src)
+		if err != nil {
+			err = fmt.Errorf("nylas: embed image %s: %w", describeSrc(src), err)
+		}
+		return "cid:" + cid, cid != ""
+	})
+	if err != nil {
+		return body, attachments, err
+	}
+	return out, e.attachments, nil
+}
+
+type embedder struct {
+	ctx         context.Context
+	opts        EmbedOptions
+	attachments []AttachmentRequest
+	cids        map[string]bool
+	total       int64
+}
+
+// embed returns the content ID of the image at src, adding an attachment unless an
+// identical image was already embedded. It returns "" for images it does not embed.
+func (e *embedder) embed(src string) (string, error) {
+	data, contentType, err := e.load(src)
+	if err != nil || data == nil {
+		return "", err
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "" {
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+	}
+	if !strings.HasPrefix(mediaType, "image/") {
+		return "", nil
+	}
+
+	sum := sha256.Sum256(data)
+	cid := "img-" + hex.EncodeToString(sum[:8])
+	if e.cids[cid] {
+		return cid, nil
+	}
+	if e.opts.MaxTotalSize > 0 && e.total+int64(len(data)) > e.opts.MaxTotalSize {
+		return "", &AttachmentTooLargeError{Filename: "inline images", Size: e.total + int64(len(data)), Limit: e.opts.MaxTotalSize}
+	}
+	att, err := InlineImage(cid, bytes.NewReader(data), WithContentType(mediaType), WithMaxSize(max(e.opts.MaxSize, 0)))
+	if err != nil {
+		return "", err
+	}
+	e.cids[cid] = true
+	e.total += int64(len(data))
+	e.attachments = append(e.attachments, att)
+	return cid, nil
+}
+
+// load returns the data and declared content type of the image at src, or nil data
+// for sources that are not embedded.
+func (e *embedder) load(src string) ([]byte, string, error) {
+	u, err := url.Parse(strings.TrimSpace(src))
+	if err != nil {
+		return nil, "", nil
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "data":
+		return decodeDataURI(src)
+	case "http", "https":
+		if e.opts.HTTPClient == nil {
+			return nil, "", nil
+		}
+		return e.fetch(u.String())
+	case "file":
+		if e.opts.BaseDir == "" {
+			return nil, "", nil
+		}
+		return e.readFile(u.Path)
+	case "":
+		if e.opts.BaseDir == "" || u.Path == "" {
+			return nil, "", nil
+		}
+		return e.readFile(u.Path)
+	}
+	return nil, "", nil
+}
+
+func (e *embedder) fetch(rawURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(e.ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := e.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", fmt.Errorf("status %d", resp.StatusCode)
+	}
+	data, err := e.readLimited(resp.Body)
+	return data, resp.Header.Get("Content-Type"), err
+}
+
+// readFile reads a local image, which must be inside BaseDir once symbolic links
+// are resolved, so a link inside BaseDir cannot expose a file outside it.
+func (e *embedder) readFile(path string) ([]byte, string, error) {
+	base, err := filepath.Abs(e.opts.BaseDir)
+	if err == nil {
+		base, err = filepath.EvalSymlinks(base)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	path = filepath.FromSlash(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	if !within(base, path) {
+		return nil, "", nil
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if !within(base, resolved) {
+		return nil, "", nil
+	}
+	f, err := os.Open(resolved)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = f.Close() }()
+	data, err := e.readLimited(f)
+	return data, typeByExtension(path), err
+}
+
+// within reports whether path is dir or inside it. Both must be clean and absolute.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// readLimited reads r, failing once it exceeds MaxSize.
+func (e *embedder) readLimited(r io.Reader) ([]byte, error) {
+	if e.opts.MaxSize > 0 {
+		r = io.LimitReader(r, e.opts.MaxSize+1)
+	}
+	data, err := io.ReadAll(r)
+	if err == nil && e.opts.MaxSize > 0 && int64(len(data)) > e.opts.MaxSize {
+		return nil, &AttachmentTooLargeError{Filename: "image", Size: int64(len(data)), Limit: e.opts.MaxSize}
+	}
+	return data, err
+}
+
+// decodeDataURI decodes a data: URI, returning its data and media type.
+func decodeDataURI(src string) ([]byte, string, error) {
+	meta, payload, ok := strings.Cut(strings.TrimSpace(src)[len("data:"):], ",")
+	if !ok {
+		return nil, "", fmt.Errorf("malformed data URI")
+	}
+	contentType, isBase64 := strings.CutSuffix(meta, ";base64")
+	if !isBase64 {
+		data, err := url.PathUnescape(payload)
+		return []byte(data), contentType, err
+	}
+	payload = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\t' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, payload)
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(payload, "="))
+	}
+	return data, contentType, err
+}
+
+// describeSrc shortens an image source for error messages.
+func describeSrc(src string) string {
+	if len(src) > 40 {
+		return src[:40] + "..."
+	}
+	return src
+}
@@ -0,0 +1,155 @@
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEmbedImages(t *testing.T) {
+	png := base64.StdEncoding.EncodeToString(pngHeader)
+	gif := []byte("GIF89a\x01\x00\x01\x00")
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "chart.gif"), gif, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logo.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(pngHeader)
+	}))
+	defer srv.Close()
+
+	body := `<p>Hi</p><img src="data:image/png;base64,` + png + `" alt="a">` +
+		`<img alt="b" src='data:image/png;base64,` + png + `'>` +
+		`<img src="chart.gif"><img src="` + srv.URL + `/logo.png">` +
+		`<img src="data:text/plain,hello"><img src="../outside.gif">`
+
+	t.Run("data only", func(t *testing.T) {
+		out, atts, err := EmbedImages(context.Background(), body, nil, nil)
+		if err != nil {
+			t.Fatalf("EmbedImages() error = %v", err)
+		}
+		if len(atts) != 1 {
+			t.Fatalf("attachments = %+v, want one for the two identical images", atts)
+		}
+		cid := atts[0].ContentID
+		if !atts[0].IsInline || atts[0].ContentType != "image/png" || atts[0].Filename != cid+".png" {
+			t.Errorf("attachment = %+v", atts[0])
+		}
+		want := `<p>Hi</p><img src="cid:` + cid + `" alt="a"><img alt="b" src="cid:` + cid + `">` +
+			`<img src="chart.gif"><img src="` + srv.URL + `/logo.png">` +
+			`<img src="data:text/plain,hello"><img src="../outside.gif">`
+		if out != want {
+			t.Errorf("body = %s\nwant %s", out, want)
+		}
+	})
+
+	t.Run("files and remote", func(t *testing.T) {
+		existing := []AttachmentRequest{{Filename: "report.pdf", Content: "JVBERg=="}}
+		out, atts, err := EmbedImages(context.Background(), body, existing, &EmbedOptions{BaseDir: dir, HTTPClient: srv.Client()})
+		if err != nil {
+			t.Fatalf("EmbedImages() error = %v", err)
+		}
+		// The remote logo is the same PNG as the data: images.
+		if len(atts) != 3 || atts[0].Filename != "report.pdf" || atts[2].ContentType != "image/gif" {
+			t.Fatalf("attachments = %+v", atts)
+		}
+		if strings.Contains(out, "chart.gif") || strings.Contains(out, "/logo.png") || !strings.Contains(out, `src="../outside.gif"`) {
+			t.Errorf("body = %s", out)
+		}
+
+		// Embedding again changes nothing.
+		again, more, err := EmbedImages(context.Background(), out, atts, &EmbedOptions{BaseDir: dir, HTTPClient: srv.Client()})
+		if err != nil || again != out || len(more) != len(atts) {
+			t.Errorf("second EmbedImages() = %d attachments, %v", len(more), err)
+		}
+	})
+
+	t.Run("symlink outside base", func(t *testing.T) {
+		outside := t.TempDir()
+		if err := os.WriteFile(filepath.Join(outside, "secret.gif"), gif, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		base := t.TempDir()
+		if err := os.Symlink(filepath.Join(outside, "secret.gif"), filepath.Join(base, "link.gif")); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+		if err := os.Symlink(outside, filepath.Join(base, "dir")); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(base, "real.gif"), gif, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink("real.gif", filepath.Join(base, "alias.gif")); err != nil {
+			t.Fatal(err)
+		}
+		baseLink := filepath.Join(outside, "base")
+		if err := os.Symlink(base, baseLink); err != nil {
+			t.Fatal(err)
+		}
+
+		in := `<img src="link.gif"><img src="dir/secret.gif">`
+		out, atts, err := EmbedImages(context.Background(), in, nil, &EmbedOptions{BaseDir: base})
+		if err != nil || out != in || len(atts) != 0 {
+			t.Errorf("EmbedImages() = %s, %d attachments, %v, want links outside BaseDir left alone", out, len(atts), err)
+		}
+		// A link to a file inside BaseDir is followed, also when BaseDir is a link.
+		_, atts, err = EmbedImages(context.Background(), `<img src="alias.gif">`, nil, &EmbedOptions{BaseDir: baseLink})
+		if err != nil || len(atts) != 1 {
+			t.Errorf("EmbedImages() inside BaseDir = %d attachments, %v", len(atts), err)
+		}
+	})
+
+	t.Run("size limits", func(t *testing.T) {
+		_, atts, err := EmbedImages(context.Background(), body, nil, &EmbedOptions{MaxSize: 8})
+		if !errors.Is(err, ErrAttachmentTooLarge) || atts != nil {
+			t.Errorf("MaxSize: error = %v, attachments = %v", err, atts)
+		}
+		_, _, err = EmbedImages(context.Background(), body, nil, &EmbedOptions{MaxTotalSize: int64(len(pngHeader) + 2), BaseDir: dir})
+		var tooLarge *AttachmentTooLargeError
+		if !errors.As(err, &tooLarge) || tooLarge.Limit != int64(len(pngHeader)+2) {
+			t.Errorf("MaxTotalSize: error = %v", err)
+		}
+	})
+
+	t.Run("remote error", func(t *testing.T) {
+		_, _, err := EmbedImages(context.Background(), `<img src="`+srv.URL+`/missing.png">`, nil, &EmbedOptions{HTTPClient: srv.Client()})
+		if err == nil || !strings.Contains(err.Error(), "status 404") {
+			t.Errorf("error = %v, want status 404", err)
+		}
+	})
+}
+
+func TestDecodeDataURI(t *testing.T) {
+	tests := []struct {
+		src      string
+		wantData string
+		wantType string
+		wantErr  bool
+	}{
+		{src: "data:image/gif;base64,R0lG ODlh", wantData: "GIF89a", wantType: "image/gif"},
+		{src: "DATA:image/svg+xml,%3Csvg%2F%3E", wantData: "<svg/>", wantType: "image/svg+xml"},
+		{src: "data:;base64,R0lGODlh", wantData: "GIF89a"},
+		{src: "data:image/png", wantErr: true},
+	}
+	for _, tt := range tests {
+		data, contentType, err := decodeDataURI(tt.src)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("decodeDataURI(%q) error = %v", tt.src, err)
+			continue
+		}
+		if string(data) != tt.wantData || contentType != tt.wantType {
+			t.Errorf("decodeDataURI(%q) = %q, %q", tt.src, data, contentType)
+		}
+	}
+}
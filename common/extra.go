@@ -0,0 +1,134 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// knownFieldsCache maps a struct type to the lower-cased JSON names of its fields.
+var knownFieldsCache sync.Map
+
+// UnmarshalExtra decodes data into v, a pointer to a struct, and returns the object
+// fields that v does not declare, or nil if there are none. Types call it from
+// UnmarshalJSON through a method-less alias to fill their Extra field.
+//
+// Example:
+//
+//	func (m *Message) UnmarshalJSON(data []byte) error {
+//	    type alias Message
+//	    extra, err := common.UnmarshalExtra(data, (*alias)(m))
+//	    m.Extra = extra
+//	    return err
+//	}
+func UnmarshalExtra(data []byte, v any) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return nil, err
+	}
+	known := knownFields(reflect.TypeOf(v))
+	for name := range fields {
+		if known[strings.ToLower(name)] {
+			delete(fields, name)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// MarshalExtra encodes v, a struct, and appends the fields in extra that v does
+// not declare, in name order. Types call it from MarshalJSON through a method-less
+// alias so that fields kept by UnmarshalExtra survive a round trip.
+func MarshalExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 || len(b) < 2 || b[0] != '{' {
+		return b, err
+	}
+
+	known := knownFields(reflect.TypeOf(v))
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		if !known[strings.ToLower(name)] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return b, nil
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.Write(b[:len(b)-1])
+	for i, name := range names {
+		if i > 0 || len(b) > 2 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		if raw := extra[name]; len(raw) > 0 {
+			buf.Write(raw)
+		} else {
+			buf.WriteString("null")
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// knownFields returns the lower-cased JSON names declared by t, following
+// pointers and untagged embedded structs the way encoding/json does.
+func knownFields(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+	known := map[string]bool{}
+	collectFields(t, known)
+	knownFieldsCache.Store(t, known)
+	return known
+}
+
+func collectFields(t reflect.Type, known map[string]bool) {
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectFields(ft, known)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+}
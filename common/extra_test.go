@@ -0,0 +1,71 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type extraBase struct {
+	ID string `json:"id"`
+}
+
+type extraModel struct {
+	extraBase
+	Name  string                     `json:"name,omitempty"`
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (m *extraModel) UnmarshalJSON(data []byte) error {
+	type alias extraModel
+	extra, err := UnmarshalExtra(data, (*alias)(m))
+	m.Extra = extra
+	return err
+}
+
+func (m extraModel) MarshalJSON() ([]byte, error) {
+	type alias extraModel
+	return MarshalExtra(alias(m), m.Extra)
+}
+
+func TestExtraRoundTrip(t *testing.T) {
+	in := `{"id":"a","NAME":"n","color":"red","nested":{"x":[1,2]}}`
+	var m extraModel
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if m.ID != "a" || m.Name != "n" {
+		t.Errorf("known fields = %+v", m)
+	}
+	if len(m.Extra) != 2 || string(m.Extra["color"]) != `"red"` {
+		t.Errorf("Extra = %v, want color and nested", m.Extra)
+	}
+
+	m.Name = "changed"
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	want := `{"id":"a","name":"changed","color":"red","nested":{"x":[1,2]}}`
+	if string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+}
+
+func TestExtraNoUnknownFields(t *testing.T) {
+	var m extraModel
+	if err := json.Unmarshal([]byte(`{"id":"a"}`), &m); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if m.Extra != nil {
+		t.Errorf("Extra = %v, want nil", m.Extra)
+	}
+
+	m.Extra = map[string]json.RawMessage{"id": json.RawMessage(`"shadowed"`), "flag": nil}
+	out, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if want := `{"id":"a","flag":null}`; string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+}
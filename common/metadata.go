@@ -0,0 +1,216 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits on the Metadata field of Nylas objects.
+const (
+	// MaxMetadataPairs is the maximum number of key-value pairs on one object.
+	MaxMetadataPairs = 50
+	// MaxMetadataKeyLength is the maximum length of a key, in characters.
+	MaxMetadataKeyLength = 40
+	// MaxMetadataValueLength is the maximum length of a value, in characters.
+	MaxMetadataValueLength = 500
+)
+
+// MetadataFilterKeys are the keys that can be used in a metadata_pair list filter.
+// Values stored under other keys are kept but cannot be searched for.
+var MetadataFilterKeys = []string{"key1", "key2", "key3", "key4", "key5"}
+
+// ErrMetadataNotFound is returned by the Metadata getters when the key is absent.
+var ErrMetadataNotFound = errors.New("nylas: metadata key not found")
+
+// Metadata is a set of custom key-value pairs, as stored in the Metadata field of
+// events, calendars, messages and drafts. Convert a field to Metadata to store and
+// read typed values. The setters return the map they stored into, which is a new
+// one if m is nil, so assign the result back.
+//
+// Example:
+//
+//	md := common.Metadata(req.Metadata).SetInt("key1", 4711)
+//	md, err := md.SetJSON("crm", crmRecord)
+//	if err != nil {
+//	    return err
+//	}
+//	req.Metadata = md
+//
+//	id, err := common.Metadata(msg.Metadata).Int("key1")
+type Metadata map[string]string
+
+// SetString stores s under key.
+func (m Metadata) SetString(key, s string) Metadata {
+	m = m.alloc()
+	m[key] = s
+	return m
+}
+
+// SetInt stores n under key in decimal.
+func (m Metadata) SetInt(key string, n int64) Metadata {
+	m = m.alloc()
+	m[key] = strconv.FormatInt(n, 10)
+	return m
+}
+
+// SetBool stores b under key as "true" or "false".
+func (m Metadata) SetBool(key string, b bool) Metadata {
+	m = m.alloc()
+	m[key] = strconv.FormatBool(b)
+	return m
+}
+
+// SetTime stores t under key in RFC 3339 format, in UTC.
+func (m Metadata) SetTime(key string, t time.Time) Metadata {
+	m = m.alloc()
+	m[key] = t.UTC().Format(time.RFC3339)
+	return m
+}
+
+// SetJSON stores the JSON encoding of v under key. It fails, leaving m unchanged,
+// if v cannot be encoded or the encoding is longer than MaxMetadataValueLength.
+func (m Metadata) SetJSON(key string, v any) (Metadata, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return m, fmt.Errorf("nylas: metadata %q: %w", key, err)
+	}
+	if n := len([]rune(string(b))); n > MaxMetadataValueLength {
+		return m, fmt.Errorf("nylas: metadata %q: encoded value is %d characters, over the limit of %d", key, n, MaxMetadataValueLength)
+	}
+	m = m.alloc()
+	m[key] = string(b)
+	return m, nil
+}
+
+// alloc returns m, or a new map if m is nil.
+func (m Metadata) alloc() Metadata {
+	if m == nil {
+		return Metadata{}
+	}
+	return m
+}
+
+// String returns the value stored under key, or ErrMetadataNotFound.
+func (m Metadata) String(key string) (string, error) {
+	s, ok := m[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrMetadataNotFound, key)
+	}
+	return s, nil
+}
+
+// Int parses the value stored under key as a decimal integer.
+func (m Metadata) Int(key string) (int64, error) {
+	s, err := m.String(key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("nylas: metadata %q: %w", key, err)
+	}
+	return n, nil
+}
+
+// Bool parses the value stored under key as a boolean.
+func (m Metadata) Bool(key string) (bool, error) {
+	s, err := m.String(key)
+	if err != nil {
+		return false, err
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return false, fmt.Errorf("nylas: metadata %q: %w", key, err)
+	}
+	return b, nil
+}
+
+// Time parses the value stored under key as an RFC 3339 timestamp.
+func (m Metadata) Time(key string) (time.Time, error) {
+	s, err := m.String(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("nylas: metadata %q: %w", key, err)
+	}
+	return t, nil
+}
+
+// JSON decodes the JSON value stored under key into v.
+func (m Metadata) JSON(key string, v any) error {
+	s, err := m.String(key)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(s), v); err != nil {
+		return fmt.Errorf("nylas: metadata %q: %w", key, err)
+	}
+	return nil
+}
+
+// MetadataPair returns the "key:value" filter for the metadata_pair list option.
+//
+// Example:
+//
+//	opts := &messages.ListOptions{MetadataPair: nylas.Ptr(common.MetadataPair("key1", "4711"))}
+func MetadataPair(key, value string) string {
+	return key + ":" + value
+}
+
+// IsMetadataFilterKey reports whether key is one of MetadataFilterKeys.
+func IsMetadataFilterKey(key string) bool {
+	for _, k := range MetadataFilterKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckMetadata records problems if m has too many pairs or a key or value is
+// empty or too long.
+func CheckMetadata(v *ValidationError, path string, m map[string]string) {
+	if len(m) > MaxMetadataPairs {
+		v.Add(path, "has %d pairs, over the limit of %d", len(m), MaxMetadataPairs)
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := m[key]
+		p := JoinPath(path, key)
+		switch n := len([]rune(key)); {
+		case n == 0:
+			v.Add(path, "keys must not be empty")
+		case n > MaxMetadataKeyLength:
+			v.Add(p, "key is %d characters, over the limit of %d", n, MaxMetadataKeyLength)
+		}
+		if n := len([]rune(value)); n > MaxMetadataValueLength {
+			v.Add(p, "value is %d characters, over the limit of %d", n, MaxMetadataValueLength)
+		}
+	}
+}
+
+// CheckMetadataPair records a problem at path if pair is set and not in "key:value"
+// form with one of MetadataFilterKeys as the key.
+func CheckMetadataPair(v *ValidationError, path string, pair *string) {
+	if pair == nil {
+		return
+	}
+	key, _, ok := strings.Cut(*pair, ":")
+	switch {
+	case !ok:
+		v.Add(path, "%q must be in key:value form", *pair)
+	case !IsMetadataFilterKey(key):
+		v.Add(path, "key %q cannot be filtered on; use one of %s", key, strings.Join(MetadataFilterKeys, ", "))
+	}
+}
@@ -0,0 +1,142 @@
+package common
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetadata_TypedValues(t *testing.T) {
+	type record struct {
+		System string `json:"system"`
+		ID     int    `json:"id"`
+	}
+	when := time.Date(2026, 3, 1, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	md := Metadata{}
+	md.SetString("key1", "crm-42").SetInt("key2", -7).SetBool("key3", true).SetTime("key4", when)
+	if _, err := md.SetJSON("crm", record{System: "acme", ID: 42}); err != nil {
+		t.Fatalf("SetJSON() error = %v", err)
+	}
+
+	want := Metadata{
+		"key1": "crm-42",
+		"key2": "-7",
+		"key3": "true",
+		"key4": "2026-03-01T08:30:00Z",
+		"crm":  `{"system":"acme","id":42}`,
+	}
+	if !reflect.DeepEqual(md, want) {
+		t.Fatalf("metadata = %v, want %v", md, want)
+	}
+
+	if s, err := md.String("key1"); err != nil || s != "crm-42" {
+		t.Errorf("String() = %q, %v", s, err)
+	}
+	if n, err := md.Int("key2"); err != nil || n != -7 {
+		t.Errorf("Int() = %d, %v", n, err)
+	}
+	if b, err := md.Bool("key3"); err != nil || !b {
+		t.Errorf("Bool() = %v, %v", b, err)
+	}
+	if got, err := md.Time("key4"); err != nil || !got.Equal(when) {
+		t.Errorf("Time() = %v, %v", got, err)
+	}
+	var rec record
+	if err := md.JSON("crm", &rec); err != nil || rec != (record{System: "acme", ID: 42}) {
+		t.Errorf("JSON() = %+v, %v", rec, err)
+	}
+}
+
+func TestMetadata_NilMap(t *testing.T) {
+	var md Metadata
+	if got := md.SetString("key1", "a"); !reflect.DeepEqual(got, Metadata{"key1": "a"}) {
+		t.Errorf("SetString() on nil = %v", got)
+	}
+	if got := md.SetInt("key1", 1); !reflect.DeepEqual(got, Metadata{"key1": "1"}) {
+		t.Errorf("SetInt() on nil = %v", got)
+	}
+	if got := md.SetBool("key1", true); !reflect.DeepEqual(got, Metadata{"key1": "true"}) {
+		t.Errorf("SetBool() on nil = %v", got)
+	}
+	if got := md.SetTime("key1", time.Unix(0, 0)); !reflect.DeepEqual(got, Metadata{"key1": "1970-01-01T00:00:00Z"}) {
+		t.Errorf("SetTime() on nil = %v", got)
+	}
+	if got, err := md.SetJSON("key1", []int{1}); err != nil || !reflect.DeepEqual(got, Metadata{"key1": "[1]"}) {
+		t.Errorf("SetJSON() on nil = %v, %v", got, err)
+	}
+	if got, err := md.SetJSON("key1", func() {}); err == nil || got != nil {
+		t.Errorf("SetJSON() of a bad value on nil = %v, %v", got, err)
+	}
+	if md != nil {
+		t.Errorf("receiver = %v, want it left nil", md)
+	}
+}
+
+func TestMetadata_Errors(t *testing.T) {
+	md := Metadata{"key1": "not a number"}
+
+	if _, err := md.Int("missing"); !errors.Is(err, ErrMetadataNotFound) {
+		t.Errorf("Int(missing) error = %v, want ErrMetadataNotFound", err)
+	}
+	if _, err := md.Int("key1"); err == nil || errors.Is(err, ErrMetadataNotFound) {
+		t.Errorf("Int(key1) error = %v, want a parse error", err)
+	}
+	if _, err := md.SetJSON("big", strings.Repeat("x", MaxMetadataValueLength)); err == nil {
+		t.Error("SetJSON() accepted a value over the length limit")
+	}
+	if _, err := md.SetJSON("bad", func() {}); err == nil {
+		t.Error("SetJSON() accepted a value that cannot be encoded")
+	}
+}
+
+func TestCheckMetadata(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i <= MaxMetadataPairs; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+
+	tests := []struct {
+		name string
+		md   map[string]string
+		want []string
+	}{
+		{"valid", map[string]string{"key1": "v"}, nil},
+		{"empty key", map[string]string{"": "v"}, []string{"metadata"}},
+		{"long value", map[string]string{"key1": strings.Repeat("é", MaxMetadataValueLength+1)}, []string{"metadata.key1"}},
+		{"too many", tooMany, []string{"metadata", "metadata." + strings.Repeat("k", 41), "metadata." + strings.Repeat("k", 42)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &ValidationError{}
+			CheckMetadata(v, "metadata", tt.md)
+			var got []string
+			for _, f := range v.Fields {
+				got = append(got, f.Path)
+			}
+			if len(got) > 3 {
+				got = got[:3]
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paths = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMetadataPair(t *testing.T) {
+	for pair, ok := range map[string]bool{
+		MetadataPair("key1", "crm:42"): true,
+		"key5:":                        true,
+		"key6:x":                       false,
+		"key1":                         false,
+	} {
+		v := &ValidationError{}
+		CheckMetadataPair(v, "metadata_pair", &pair)
+		if (v.Err() == nil) != ok {
+			t.Errorf("CheckMetadataPair(%q) error = %v, want ok = %v", pair, v.Err(), ok)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package common
+
+import "time"
+
+// UnixPtr returns a pointer to the Unix time of t in seconds.
+func UnixPtr(t time.Time) *int64 {
+	ts := t.Unix()
+	return &ts
+}
+
+// Minutes converts d to whole minutes, truncating any remainder.
+func Minutes(d time.Duration) int {
+	return int(d / time.Minute)
+}
+
+// Seconds converts d to whole seconds, truncating any remainder.
+func Seconds(d time.Duration) int {
+	return int(d / time.Second)
+}
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeConversions(t *testing.T) {
+	if got := *UnixPtr(time.Unix(1700000000, 999)); got != 1700000000 {
+		t.Errorf("UnixPtr() = %d", got)
+	}
+	if got := Minutes(90*time.Minute + 59*time.Second); got != 90 {
+		t.Errorf("Minutes() = %d, want 90", got)
+	}
+	if got := Seconds(1500 * time.Millisecond); got != 1 {
+		t.Errorf("Seconds() = %d, want 1", got)
+	}
+}
+
+func TestCheckUnixTime(t *testing.T) {
+	v := &ValidationError{}
+	CheckUnixTime(v, "ok", 1700000000)
+	CheckUnixTime(v, "zero", 0)
+	CheckUnixTime(v, "negative", time.Time{}.Unix())
+	CheckUnixTime(v, "millis", 1700000000000)
+	start, end := int64(200), int64(100)
+	CheckOptionalTimeRange(v, "after", &start, "before", &end)
+	CheckOptionalTimeRange(v, "a", nil, "b", &end)
+
+	assertPaths(t, v.Err(), []string{"zero", "negative", "millis", "before"})
+}
@@ -1,6 +1,8 @@
 // Package common provides shared types used across Nylas API resources.
 package common
 
+import "io"
+
 // Participant represents an email participant (from, to, cc, bcc).
 type Participant struct {
 	Name  string `json:"name,omitempty"`
@@ -29,6 +31,18 @@ type AttachmentRequest struct {
 	ContentID string `json:"content_id,omitempty"`
 	// IsInline indicates whether this is an inline attachment (e.g., embedded image).
 	IsInline bool `json:"is_inline,omitempty"`
+	// Reader streams the file data instead of Content, without base64 or buffering.
+	// Requests with a Reader are always sent as multipart/form-data. The caller owns
+	// the reader and closes it if needed; it cannot be re-read, so such requests are not retried.
+	Reader io.Reader `json:"-"`
+}
+
+// Header represents a single email header as a name/value pair.
+type Header struct {
+	// Name is the header field name (e.g., "X-Campaign-ID").
+	Name string `json:"name"`
+	// Value is the header field value.
+	Value string `json:"value"`
 }
 
 // TrackingOptions specifies email tracking options for sent messages.
@@ -0,0 +1,295 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrValidation matches every *ValidationError. Use errors.Is to check for it.
+var ErrValidation = errors.New("nylas: invalid request")
+
+// FieldError describes a single invalid field in a request.
+type FieldError struct {
+	// Path is the JSON path of the field, e.g. "to[0].email" or "when.start_time".
+	// It is empty for problems that concern the request as a whole.
+	Path string `json:"path"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// String returns "path: message".
+func (e FieldError) String() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError lists every problem found while validating a request.
+//
+// Example:
+//
+//	var verr *common.ValidationError
+//	if errors.As(err, &verr) {
+//	    for _, f := range verr.Fields {
+//	        log.Printf("%s: %s", f.Path, f.Message)
+//	    }
+//	}
+type ValidationError struct {
+	// Fields lists each problem with the JSON path of the offending field.
+	Fields []FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = f.String()
+	}
+	return "nylas: invalid request: " + strings.Join(parts, "; ")
+}
+
+// Is implements errors.Is for matching against ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Add records a problem with the field at path.
+func (e *ValidationError) Add(path, format string, args ...any) {
+	e.Fields = append(e.Fields, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Merge records the problems in err under prefix. A nil err is ignored; an error that
+// is not a *ValidationError is recorded as a single problem at prefix.
+func (e *ValidationError) Merge(prefix string, err error) {
+	if err == nil {
+		return
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		e.Fields = append(e.Fields, FieldError{Path: prefix, Message: err.Error()})
+		return
+	}
+	for _, f := range verr.Fields {
+		e.Fields = append(e.Fields, FieldError{Path: JoinPath(prefix, f.Path), Message: f.Message})
+	}
+}
+
+// Err returns e if any problems were recorded, or nil otherwise.
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// JoinPath joins two JSON path segments, e.g. JoinPath("to[0]", "email") is "to[0].email".
+func JoinPath(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	}
+	return parent + "." + child
+}
+
+// IndexPath returns the JSON path of the i-th element of the array at path, e.g. "to[0]".
+func IndexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// Validate checks that the participant has a valid email address.
+func (p Participant) Validate() error {
+	v := &ValidationError{}
+	CheckEmail(v, "email", p.Email)
+	return v.Err()
+}
+
+// Validate checks that the attachment has a filename and content.
+func (a AttachmentRequest) Validate() error {
+	v := &ValidationError{}
+	if a.Filename == "" {
+		v.Add("filename", "is required")
+	}
+	switch {
+	case a.Content == "" && a.Reader == nil:
+		v.Add("content", "is required unless reader is set")
+	case a.Content != "" && a.Reader != nil:
+		v.Add("content", "must be empty when reader is set")
+	}
+	if a.IsInline && a.ContentID == "" {
+		v.Add("content_id", "is required for inline attachments")
+	}
+	return v.Err()
+}
+
+// headerNamePattern matches RFC 5322 header field names.
+var headerNamePattern = regexp.MustCompile(`^[!-9;-~]+$`)
+
+// Validate checks that the header has a valid name and a single-line value.
+func (h Header) Validate() error {
+	v := &ValidationError{}
+	if h.Name == "" {
+		v.Add("name", "is required")
+	} else if !headerNamePattern.MatchString(h.Name) {
+		v.Add("name", "%q is not a valid header name", h.Name)
+	}
+	if strings.ContainsAny(h.Value, "\r\n") {
+		v.Add("value", "must not contain line breaks")
+	}
+	return v.Err()
+}
+
+// CheckEmail records a problem at path if addr is empty or not a bare email address.
+func CheckEmail(v *ValidationError, path, addr string) {
+	if addr == "" {
+		v.Add(path, "is required")
+		return
+	}
+	if !ValidEmail(addr) {
+		v.Add(path, "%q is not a valid email address", addr)
+	}
+}
+
+// ValidEmail reports whether addr is a bare email address such as "ada@example.com".
+func ValidEmail(addr string) bool {
+	parsed, err := mail.ParseAddress(addr)
+	return err == nil && parsed.Address == addr
+}
+
+// CheckParticipants validates each participant in list, recording problems under path.
+func CheckParticipants(v *ValidationError, path string, list []Participant) {
+	for i, p := range list {
+		v.Merge(IndexPath(path, i), p.Validate())
+	}
+}
+
+// CheckAttachments validates each attachment in list, recording problems under path.
+func CheckAttachments(v *ValidationError, path string, list []AttachmentRequest) {
+	for i, a := range list {
+		v.Merge(IndexPath(path, i), a.Validate())
+	}
+}
+
+// CheckHeaders validates each header in list, recording problems under path.
+func CheckHeaders(v *ValidationError, path string, list []Header) {
+	for i, h := range list {
+		v.Merge(IndexPath(path, i), h.Validate())
+	}
+}
+
+// maxUnixSeconds is the largest plausible Unix timestamp in seconds (year 5138).
+// Larger values are almost always milliseconds passed by mistake.
+const maxUnixSeconds = 1e11
+
+// CheckUnixTime records a problem at path if ts is not a positive Unix timestamp in seconds.
+func CheckUnixTime(v *ValidationError, path string, ts int64) {
+	switch {
+	case ts <= 0:
+		v.Add(path, "must be a positive Unix timestamp")
+	case ts >= maxUnixSeconds:
+		v.Add(path, "%d is too large for Unix seconds; was it given in milliseconds?", ts)
+	}
+}
+
+// CheckTimeRange records problems if start or end is missing or not a Unix timestamp
+// in seconds, or if end is not after start.
+func CheckTimeRange(v *ValidationError, startPath string, start int64, endPath string, end int64) {
+	if start == 0 {
+		v.Add(startPath, "is required")
+	} else {
+		CheckUnixTime(v, startPath, start)
+	}
+	if end == 0 {
+		v.Add(endPath, "is required")
+	} else {
+		CheckUnixTime(v, endPath, end)
+	}
+	if start > 0 && end > 0 && end <= start {
+		v.Add(endPath, "must be after %s", startPath)
+	}
+}
+
+// CheckOptionalTimeRange is CheckTimeRange for optional bounds: nil values are
+// not checked, and end must be after start only when both are set.
+func CheckOptionalTimeRange(v *ValidationError, startPath string, start *int64, endPath string, end *int64) {
+	if start != nil {
+		CheckUnixTime(v, startPath, *start)
+	}
+	if end != nil {
+		CheckUnixTime(v, endPath, *end)
+	}
+	if start != nil && end != nil && *start > 0 && *end > 0 && *end <= *start {
+		v.Add(endPath, "must be after %s", startPath)
+	}
+}
+
+// CheckURL records a problem at path if raw is not an absolute URL with one of schemes.
+func CheckURL(v *ValidationError, path, raw string, schemes ...string) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		v.Add(path, "%q is not a valid URL", raw)
+		return
+	}
+	for _, s := range schemes {
+		if strings.EqualFold(u.Scheme, s) {
+			return
+		}
+	}
+	v.Add(path, "must use %s", strings.Join(schemes, " or "))
+}
+
+// CheckOneOf records a problem at path if value is not one of allowed.
+func CheckOneOf(v *ValidationError, path, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.Add(path, "%q must be one of %s", value, strings.Join(allowed, ", "))
+}
+
+// ValidDate reports whether s is a calendar date in YYYY-MM-DD format.
+func ValidDate(s string) bool {
+	_, err := time.Parse(time.DateOnly, s)
+	return err == nil
+}
+
+// clockPattern matches a 24-hour "HH:MM" time of day.
+var clockPattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// ValidClock reports whether s is a 24-hour time of day in "HH:MM" format.
+func ValidClock(s string) bool {
+	return clockPattern.MatchString(s)
+}
+
+// hexColorPattern matches "#RGB" and "#RRGGBB" colors.
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ValidHexColor reports whether s is a "#RGB" or "#RRGGBB" color.
+func ValidHexColor(s string) bool {
+	return hexColorPattern.MatchString(s)
+}
+
+// CheckClockRange records problems if start or end is not an "HH:MM" time
+// or if end is not after start.
+func CheckClockRange(v *ValidationError, startPath, start, endPath, end string) {
+	validStart, validEnd := ValidClock(start), ValidClock(end)
+	if !validStart {
+		v.Add(startPath, "%q is not an HH:MM time", start)
+	}
+	if !validEnd {
+		v.Add(endPath, "%q is not an HH:MM time", end)
+	}
+	if validStart && validEnd && end <= start {
+		v.Add(endPath, "must be after %s", startPath)
+	}
+}
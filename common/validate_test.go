@@ -0,0 +1,159 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidationError(t *testing.T) {
+	inner := &ValidationError{}
+	inner.Add("email", "is required")
+	inner.Add("", "whole object")
+
+	v := &ValidationError{}
+	v.Merge("to[0]", inner)
+	v.Merge("cc", errors.New("plain error"))
+	v.Merge("bcc", nil)
+
+	want := []FieldError{
+		{Path: "to[0].email", Message: "is required"},
+		{Path: "to[0]", Message: "whole object"},
+		{Path: "cc", Message: "plain error"},
+	}
+	if len(v.Fields) != len(want) {
+		t.Fatalf("Fields = %+v, want %+v", v.Fields, want)
+	}
+	for i := range want {
+		if v.Fields[i] != want[i] {
+			t.Errorf("Fields[%d] = %+v, want %+v", i, v.Fields[i], want[i])
+		}
+	}
+
+	err := v.Err()
+	if !errors.Is(err, ErrValidation) {
+		t.Error("errors.Is(err, ErrValidation) = false")
+	}
+	if got := err.Error(); got != "nylas: invalid request: to[0].email: is required; to[0]: whole object; cc: plain error" {
+		t.Errorf("Error() = %q", got)
+	}
+	if (&ValidationError{}).Err() != nil {
+		t.Error("empty ValidationError.Err() != nil")
+	}
+
+	var verr *ValidationError
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &verr) || len(verr.Fields) != 3 {
+		t.Error("errors.As through wrapping failed")
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	tests := []struct{ parent, child, want string }{
+		{"", "email", "email"},
+		{"to[0]", "", "to[0]"},
+		{"to[0]", "email", "to[0].email"},
+		{"participants", "[1]", "participants[1]"},
+	}
+	for _, tt := range tests {
+		if got := JoinPath(tt.parent, tt.child); got != tt.want {
+			t.Errorf("JoinPath(%q, %q) = %q, want %q", tt.parent, tt.child, got, tt.want)
+		}
+	}
+}
+
+func TestValidEmail(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"ada@example.com", true},
+		{"first.last+tag@sub.example.co.uk", true},
+		{"Ada <ada@example.com>", false},
+		{"ada", false},
+		{"ada@", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidEmail(tt.addr); got != tt.want {
+			t.Errorf("ValidEmail(%q) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestValidClockDateColor(t *testing.T) {
+	for _, s := range []string{"00:00", "09:30", "23:59"} {
+		if !ValidClock(s) {
+			t.Errorf("ValidClock(%q) = false", s)
+		}
+	}
+	for _, s := range []string{"9:30", "24:00", "12:60", "12:00:00", ""} {
+		if ValidClock(s) {
+			t.Errorf("ValidClock(%q) = true", s)
+		}
+	}
+	if !ValidDate("2024-02-29") || ValidDate("2023-02-29") || ValidDate("2024/01/01") {
+		t.Error("ValidDate() gave wrong result")
+	}
+	if !ValidHexColor("#fff") || !ValidHexColor("#A1B2C3") || ValidHexColor("fff") || ValidHexColor("#abcd") {
+		t.Error("ValidHexColor() gave wrong result")
+	}
+}
+
+func TestRequestPartValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantPaths []string
+	}{
+		{"valid participant", Participant{Email: "a@example.com"}.Validate(), nil},
+		{"missing email", Participant{Name: "Ada"}.Validate(), []string{"email"}},
+		{"valid attachment", AttachmentRequest{Filename: "a.txt", Content: "aGk="}.Validate(), nil},
+		{"empty attachment", AttachmentRequest{IsInline: true}.Validate(), []string{"filename", "content", "content_id"}},
+		{"reader attachment", AttachmentRequest{Filename: "a.pdf", Reader: strings.NewReader("%PDF")}.Validate(), nil},
+		{"reader and content", AttachmentRequest{Filename: "a.pdf", Content: "aGk=", Reader: strings.NewReader("%PDF")}.Validate(), []string{"content"}},
+		{"valid header", Header{Name: "X-Campaign", Value: "q3"}.Validate(), nil},
+		{"header injection", Header{Name: "X Bad:", Value: "a\r\nBcc: x@example.com"}.Validate(), []string{"name", "value"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPaths(t, tt.err, tt.wantPaths)
+		})
+	}
+}
+
+func TestCheckHelpers(t *testing.T) {
+	v := &ValidationError{}
+	CheckTimeRange(v, "start_time", 200, "end_time", 100)
+	CheckTimeRange(v, "from", 0, "to", 0)
+	CheckClockRange(v, "start", "17:00", "end", "09:00")
+	CheckURL(v, "webhook_url", "http://example.com/hook", "https")
+	CheckURL(v, "link", "not a url", "https")
+	CheckOneOf(v, "status", "perhaps", "yes", "no")
+	CheckParticipants(v, "to", []Participant{{Email: "ok@example.com"}, {Email: "bad"}})
+
+	assertPaths(t, v.Err(), []string{"end_time", "from", "to", "end", "webhook_url", "link", "status", "to[1].email"})
+}
+
+func assertPaths(t *testing.T, err error, want []string) {
+	t.Helper()
+	if len(want) == 0 {
+		if err != nil {
+			t.Errorf("error = %v, want nil", err)
+		}
+		return
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *ValidationError", err)
+	}
+	if len(verr.Fields) != len(want) {
+		t.Fatalf("Fields = %+v, want paths %v", verr.Fields, want)
+	}
+	for i, p := range want {
+		if verr.Fields[i].Path != p {
+			t.Errorf("Fields[%d].Path = %q, want %q", i, verr.Fields[i].Path, p)
+		}
+	}
+}
@@ -0,0 +1,508 @@
+package nylas
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by NewClientFromEnv.
+const (
+	EnvAPIKey     = "NYLAS_API_KEY"
+	EnvRegion     = "NYLAS_REGION"
+	EnvBaseURL    = "NYLAS_BASE_URL"
+	EnvTimeout    = "NYLAS_TIMEOUT"
+	EnvMaxRetries = "NYLAS_MAX_RETRIES"
+	EnvProxy      = "NYLAS_PROXY"
+	EnvClientID   = "NYLAS_CLIENT_ID"
+	EnvConfigFile = "NYLAS_CONFIG_FILE"
+	EnvProfile    = "NYLAS_PROFILE"
+)
+
+// DefaultProfile is the profile used when neither NYLAS_PROFILE nor the file's default is set.
+const DefaultProfile = "default"
+
+// Config holds client settings loaded from the environment or a profile file.
+// Zero values mean "not set" and leave the client defaults in place.
+type Config struct {
+	// APIKey is the Nylas API key.
+	APIKey string
+	// Region is the API region ("us" or "eu").
+	Region Region
+	// BaseURL overrides the region's base URL.
+	BaseURL string
+	// Timeout is the HTTP client timeout.
+	Timeout time.Duration
+	// MaxRetries is the maximum number of retry attempts; nil keeps the default.
+	MaxRetries *int
+	// Proxy is the URL of an HTTP proxy to route requests through.
+	Proxy string
+	// ClientID is the Nylas application's client ID, for building auth.Config values.
+	// It does not configure the client itself.
+	ClientID string
+}
+
+// ConfigFile is a parsed profile file containing one or more named profiles.
+//
+// Both JSON and a YAML-style subset are accepted:
+//
+//	default: dev
+//	profiles:
+//	  dev:
+//	    api_key: nyk_v0_dev
+//	    region: us
+//	  prod:
+//	    api_key: nyk_v0_prod
+//	    region: eu
+//	    timeout: 30s
+//	    max_retries: 5
+type ConfigFile struct {
+	// Path is the file the profiles were loaded from.
+	Path string
+	// Default is the profile used when no profile name is given.
+	Default string
+	// Profiles maps profile names to their settings.
+	Profiles map[string]*Config
+}
+
+// NewClientFromEnv creates a client configured from environment variables.
+//
+// If NYLAS_CONFIG_FILE is set, the profile named by NYLAS_PROFILE (or the file's default)
+// is loaded first and individual NYLAS_* variables override it. Explicit opts are applied
+// last and override everything loaded.
+//
+// Example:
+//
+//	client, err := nylas.NewClientFromEnv(nylas.WithMaxRetries(5))
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewClient(opts...)
+}
+
+// ConfigFromEnv loads the configuration NewClientFromEnv uses: the profile named by
+// NYLAS_PROFILE from NYLAS_CONFIG_FILE, if set, overridden by individual NYLAS_*
+// variables.
+func ConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		file, err := LoadConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		cfg, err = file.Profile(os.Getenv(EnvProfile))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	env, err := configFromValues("environment", func(key string) (string, bool) {
+		return os.LookupEnv(envKeys[key])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cfg.merge(env), nil
+}
+
+// LoadConfig reads a profile file in JSON or YAML-style format.
+//
+// Example:
+//
+//	file, err := nylas.LoadConfig("nylas.yaml")
+//	if err != nil {
+//	    return err
+//	}
+//	client, err := file.NewClient("staging", nylas.WithTimeout(time.Minute))
+func LoadConfig(path string) (*ConfigFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("nylas: config: %w", err)
+	}
+
+	var raw map[string]any
+	trimmed := bytes.TrimSpace(data)
+	if strings.EqualFold(filepath.Ext(path), ".json") || bytes.HasPrefix(trimmed, []byte("{")) {
+		if err := json.Unmarshal(trimmed, &raw); err != nil {
+			return nil, fmt.Errorf("nylas: config %s: %w", path, err)
+		}
+	} else {
+		raw, err = parseYAMLMap(data)
+		if err != nil {
+			return nil, fmt.Errorf("nylas: config %s: %w", path, err)
+		}
+	}
+
+	file := &ConfigFile{Path: path, Profiles: make(map[string]*Config)}
+	for key, val := range raw {
+		switch key {
+		case "default":
+			s, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("nylas: config %s: default must be a string", path)
+			}
+			file.Default = s
+		case "profiles":
+			profiles, ok := val.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("nylas: config %s: profiles must be a map", path)
+			}
+			for name, p := range profiles {
+				values, ok := p.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("nylas: config %s: profile %q must be a map", path, name)
+				}
+				cfg, err := configFromMap(fmt.Sprintf("%s profile %q", path, name), values)
+				if err != nil {
+					return nil, err
+				}
+				file.Profiles[name] = cfg
+			}
+		default:
+			return nil, fmt.Errorf("nylas: config %s: unknown key %q", path, key)
+		}
+	}
+
+	if file.Default != "" && file.Profiles[file.Default] == nil {
+		return nil, fmt.Errorf("nylas: config %s: default profile %q is not defined", path, file.Default)
+	}
+
+	return file, nil
+}
+
+// Profile returns the named profile. An empty name selects the file's default profile,
+// falling back to DefaultProfile.
+func (f *ConfigFile) Profile(name string) (*Config, error) {
+	if name == "" {
+		name = f.Default
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	cfg, ok := f.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(f.Profiles))
+		for n := range f.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("nylas: config %s: profile %q not found (available: %s)", f.Path, name, strings.Join(names, ", "))
+	}
+	c := *cfg
+	return &c, nil
+}
+
+// NewClient creates a client from the named profile. Explicit opts override the profile.
+func (f *ConfigFile) NewClient(profile string, opts ...Option) (*Client, error) {
+	cfg, err := f.Profile(profile)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewClient(opts...)
+}
+
+// Validate checks the configuration for invalid values.
+func (c *Config) Validate() error {
+	if c.Region != "" && c.Region != RegionUS && c.Region != RegionEU {
+		return fmt.Errorf("nylas: config: invalid region %q (want %q or %q)", c.Region, RegionUS, RegionEU)
+	}
+	if c.BaseURL != "" {
+		if err := validateHTTPURL(c.BaseURL); err != nil {
+			return fmt.Errorf("nylas: config: invalid base_url: %w", err)
+		}
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("nylas: config: timeout must not be negative, got %v", c.Timeout)
+	}
+	if c.MaxRetries != nil && *c.MaxRetries < 0 {
+		return fmt.Errorf("nylas: config: max_retries must not be negative, got %d", *c.MaxRetries)
+	}
+	if c.Proxy != "" {
+		if err := validateHTTPURL(c.Proxy); err != nil {
+			return fmt.Errorf("nylas: config: invalid proxy: %w", err)
+		}
+	}
+	return nil
+}
+
+// Options converts the configuration into client options.
+// Fields that are not set produce no option.
+func (c *Config) Options() []Option {
+	var opts []Option
+	if c.APIKey != "" {
+		opts = append(opts, WithAPIKey(c.APIKey))
+	}
+	if c.Region != "" {
+		opts = append(opts, WithRegion(c.Region))
+	}
+	if c.BaseURL != "" {
+		opts = append(opts, WithBaseURL(c.BaseURL))
+	}
+	if c.Timeout > 0 {
+		opts = append(opts, WithTimeout(c.Timeout))
+	}
+	if c.MaxRetries != nil {
+		opts = append(opts, WithMaxRetries(*c.MaxRetries))
+	}
+	if c.Proxy != "" {
+		opts = append(opts, WithProxy(c.Proxy))
+	}
+	return opts
+}
+
+// NewClient validates the configuration and creates a client from it.
+// Explicit opts are applied after the configuration and override it.
+func (c *Config) NewClient(opts ...Option) (*Client, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return NewClient(append(c.Options(), opts...)...)
+}
+
+// merge returns a copy of c with every field set in o overriding it.
+func (c *Config) merge(o *Config) *Config {
+	out := *c
+	if o.APIKey != "" {
+		out.APIKey = o.APIKey
+	}
+	if o.Region != "" {
+		out.Region = o.Region
+	}
+	if o.BaseURL != "" {
+		out.BaseURL = o.BaseURL
+	}
+	if o.Timeout != 0 {
+		out.Timeout = o.Timeout
+	}
+	if o.MaxRetries != nil {
+		out.MaxRetries = o.MaxRetries
+	}
+	if o.Proxy != "" {
+		out.Proxy = o.Proxy
+	}
+	if o.ClientID != "" {
+		out.ClientID = o.ClientID
+	}
+	return &out
+}
+
+// envKeys maps profile keys to their environment variable names.
+var envKeys = map[string]string{
+	"api_key":     EnvAPIKey,
+	"region":      EnvRegion,
+	"base_url":    EnvBaseURL,
+	"timeout":     EnvTimeout,
+	"max_retries": EnvMaxRetries,
+	"proxy":       EnvProxy,
+	"client_id":   EnvClientID,
+}
+
+// configFromMap builds a Config from a decoded profile, rejecting unknown keys.
+func configFromMap(source string, values map[string]any) (*Config, error) {
+	for key := range values {
+		if _, ok := envKeys[key]; !ok {
+			return nil, fmt.Errorf("nylas: config %s: unknown key %q", source, key)
+		}
+	}
+	return configFromValues(source, func(key string) (string, bool) {
+		v, ok := values[key]
+		if !ok || v == nil {
+			return "", false
+		}
+		switch val := v.(type) {
+		case string:
+			return val, true
+		case float64:
+			return strconv.FormatFloat(val, 'f', -1, 64), true
+		default:
+			return fmt.Sprint(val), true
+		}
+	})
+}
+
+// configFromValues builds a Config from string values looked up by profile key.
+func configFromValues(source string, lookup func(key string) (string, bool)) (*Config, error) {
+	cfg := &Config{}
+	get := func(key string) string {
+		v, _ := lookup(key)
+		return strings.TrimSpace(v)
+	}
+
+	cfg.APIKey = get("api_key")
+	cfg.Region = Region(strings.ToLower(get("region")))
+	cfg.BaseURL = get("base_url")
+	cfg.Proxy = get("proxy")
+	cfg.ClientID = get("client_id")
+
+	if v := get("timeout"); v != "" {
+		d, err := parseConfigDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("nylas: config %s: invalid timeout %q: %w", source, v, err)
+		}
+		cfg.Timeout = d
+	}
+	if v := get("max_retries"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("nylas: config %s: invalid max_retries %q: must be an integer", source, v)
+		}
+		cfg.MaxRetries = &n
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w (from %s)", err, source)
+	}
+	return cfg, nil
+}
+
+// parseConfigDuration accepts Go duration strings ("30s", "2m") or a bare number of seconds.
+func parseConfigDuration(s string) (time.Duration, error) {
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		if math.IsNaN(secs) || math.IsInf(secs, 0) || math.Abs(secs) > maxDurationSeconds {
+			return 0, fmt.Errorf("out of range")
+		}
+		return time.Duration(secs * float64(time.Second)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+// maxDurationSeconds is the largest number of seconds a time.Duration can hold.
+var maxDurationSeconds = float64(math.MaxInt64) / float64(time.Second)
+
+func validateHTTPURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must use http or https", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", raw)
+	}
+	return nil
+}
+
+// parseYAMLMap parses the small YAML subset used by profile files: nested maps of
+// "key: value" pairs using space indentation, with optional quoting and # comments.
+func parseYAMLMap(data []byte) (map[string]any, error) {
+	type frame struct {
+		indent int
+		m      map[string]any
+	}
+
+	root := make(map[string]any)
+	stack := []frame{{indent: 0, m: root}}
+	var pendingKey string
+	var pendingIndent int
+	hasPending := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(stripYAMLComment(scanner.Text()), " \t\r")
+		if strings.TrimSpace(line) == "" || strings.TrimSpace(line) == "---" {
+			continue
+		}
+		if strings.ContainsRune(line[:len(line)-len(strings.TrimLeft(line, " \t"))], '\t') {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", lineNo)
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		content := strings.TrimSpace(line)
+
+		if hasPending {
+			if indent <= pendingIndent {
+				return nil, fmt.Errorf("line %d: key %q has no value", lineNo-1, pendingKey)
+			}
+			child := make(map[string]any)
+			stack[len(stack)-1].m[pendingKey] = child
+			stack = append(stack, frame{indent: indent, m: child})
+			hasPending = false
+		}
+
+		for len(stack) > 1 && indent < stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		if indent != stack[len(stack)-1].indent {
+			return nil, fmt.Errorf("line %d: inconsistent indentation", lineNo)
+		}
+
+		key, value, ok := strings.Cut(content, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key == "" {
+			return nil, fmt.Errorf("line %d: empty key", lineNo)
+		}
+		current := stack[len(stack)-1].m
+		if _, dup := current[key]; dup {
+			return nil, fmt.Errorf("line %d: duplicate key %q", lineNo, key)
+		}
+
+		if value == "" {
+			pendingKey, pendingIndent, hasPending = key, indent, true
+			continue
+		}
+		unquoted, err := unquoteYAML(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		current[key] = unquoted
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if hasPending {
+		stack[len(stack)-1].m[pendingKey] = make(map[string]any)
+	}
+	return root, nil
+}
+
+// stripYAMLComment removes a trailing # comment that is not inside quotes.
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func unquoteYAML(v string) (string, error) {
+	if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' {
+		s, err := strconv.Unquote(v)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted value %s", v)
+		}
+		return s, nil
+	}
+	if len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'' {
+		return strings.ReplaceAll(v[1:len(v)-1], "''", "'"), nil
+	}
+	if v[0] == '"' || v[0] == '\'' {
+		return "", fmt.Errorf("unterminated quoted value %s", v)
+	}
+	return v, nil
+}
@@ -0,0 +1,266 @@
+package nylas
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func clearNylasEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{EnvAPIKey, EnvRegion, EnvBaseURL, EnvTimeout, EnvMaxRetries, EnvProxy, EnvClientID, EnvConfigFile, EnvProfile} {
+		t.Setenv(key, "")
+		_ = os.Unsetenv(key)
+	}
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	return path
+}
+
+const testYAMLConfig = `# Nylas profiles
+default: dev
+profiles:
+  dev:
+    api_key: "dev-key"   # inline comment
+    region: us
+    timeout: 15s
+  prod:
+    api_key: 'prod-key'
+    region: eu
+    max_retries: 5
+    timeout: 30
+`
+
+func TestNewClientFromEnv(t *testing.T) {
+	clearNylasEnv(t)
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvRegion, "EU")
+	t.Setenv(EnvTimeout, "45s")
+	t.Setenv(EnvMaxRetries, "4")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv() error = %v", err)
+	}
+	if client.APIKey != "env-key" {
+		t.Errorf("APIKey = %q, want env-key", client.APIKey)
+	}
+	if client.BaseURL != "https://api.eu.nylas.com" {
+		t.Errorf("BaseURL = %q, want EU URL", client.BaseURL)
+	}
+	if client.HTTPClient.Timeout != 45*time.Second {
+		t.Errorf("Timeout = %v, want 45s", client.HTTPClient.Timeout)
+	}
+	if client.MaxRetries != 4 {
+		t.Errorf("MaxRetries = %d, want 4", client.MaxRetries)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	clearNylasEnv(t)
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvClientID, "client-123")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.APIKey != "env-key" || cfg.ClientID != "client-123" {
+		t.Errorf("ConfigFromEnv() = %+v", cfg)
+	}
+}
+
+func TestNewClientFromEnv_ExplicitOptionsOverride(t *testing.T) {
+	clearNylasEnv(t)
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvMaxRetries, "4")
+
+	client, err := NewClientFromEnv(WithAPIKey("explicit-key"), WithMaxRetries(0))
+	if err != nil {
+		t.Fatalf("NewClientFromEnv() error = %v", err)
+	}
+	if client.APIKey != "explicit-key" {
+		t.Errorf("APIKey = %q, want explicit-key", client.APIKey)
+	}
+	if client.MaxRetries != 0 {
+		t.Errorf("MaxRetries = %d, want 0", client.MaxRetries)
+	}
+}
+
+func TestNewClientFromEnv_ProfileFile(t *testing.T) {
+	clearNylasEnv(t)
+	path := writeConfigFile(t, "nylas.yaml", testYAMLConfig)
+	t.Setenv(EnvConfigFile, path)
+	t.Setenv(EnvProfile, "prod")
+	t.Setenv(EnvMaxRetries, "1")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv() error = %v", err)
+	}
+	if client.APIKey != "prod-key" {
+		t.Errorf("APIKey = %q, want prod-key", client.APIKey)
+	}
+	if client.BaseURL != "https://api.eu.nylas.com" {
+		t.Errorf("BaseURL = %q, want EU URL", client.BaseURL)
+	}
+	if client.MaxRetries != 1 {
+		t.Errorf("MaxRetries = %d, want env override 1", client.MaxRetries)
+	}
+}
+
+func TestNewClientFromEnv_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{"missing API key", nil, "API key required"},
+		{"bad timeout", map[string]string{EnvAPIKey: "k", EnvTimeout: "soon"}, "invalid timeout"},
+		{"NaN timeout", map[string]string{EnvAPIKey: "k", EnvTimeout: "NaN"}, "invalid timeout"},
+		{"infinite timeout", map[string]string{EnvAPIKey: "k", EnvTimeout: "+Inf"}, "invalid timeout"},
+		{"overflowing timeout", map[string]string{EnvAPIKey: "k", EnvTimeout: "1e12"}, "invalid timeout"},
+		{"bad retries", map[string]string{EnvAPIKey: "k", EnvMaxRetries: "many"}, "invalid max_retries"},
+		{"negative retries", map[string]string{EnvAPIKey: "k", EnvMaxRetries: "-1"}, "max_retries must not be negative"},
+		{"bad region", map[string]string{EnvAPIKey: "k", EnvRegion: "apac"}, "invalid region"},
+		{"bad base URL", map[string]string{EnvAPIKey: "k", EnvBaseURL: "ftp://x"}, "invalid base_url"},
+		{"bad proxy", map[string]string{EnvAPIKey: "k", EnvProxy: "not a url"}, "invalid proxy"},
+		{"missing file", map[string]string{EnvConfigFile: "/does/not/exist.yaml"}, "no such file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearNylasEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			_, err := NewClientFromEnv()
+			if err == nil {
+				t.Fatal("NewClientFromEnv() expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want substring %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_YAML(t *testing.T) {
+	file, err := LoadConfig(writeConfigFile(t, "nylas.yaml", testYAMLConfig))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if file.Default != "dev" {
+		t.Errorf("Default = %q, want dev", file.Default)
+	}
+
+	dev, err := file.Profile("")
+	if err != nil {
+		t.Fatalf("Profile() error = %v", err)
+	}
+	if dev.APIKey != "dev-key" || dev.Region != RegionUS || dev.Timeout != 15*time.Second {
+		t.Errorf("dev profile = %+v", dev)
+	}
+
+	prod, err := file.Profile("prod")
+	if err != nil {
+		t.Fatalf("Profile(prod) error = %v", err)
+	}
+	if prod.APIKey != "prod-key" || prod.Timeout != 30*time.Second || prod.MaxRetries == nil || *prod.MaxRetries != 5 {
+		t.Errorf("prod profile = %+v", prod)
+	}
+
+	client, err := file.NewClient("prod", WithBaseURL("http://localhost:8080"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.BaseURL != "http://localhost:8080" {
+		t.Errorf("BaseURL = %q, want explicit override", client.BaseURL)
+	}
+}
+
+func TestLoadConfig_JSON(t *testing.T) {
+	content := `{
+		"default": "staging",
+		"profiles": {
+			"staging": {"api_key": "stg-key", "base_url": "https://staging.example.com", "max_retries": 3}
+		}
+	}`
+	file, err := LoadConfig(writeConfigFile(t, "nylas.json", content))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	cfg, err := file.Profile("")
+	if err != nil {
+		t.Fatalf("Profile() error = %v", err)
+	}
+	if cfg.APIKey != "stg-key" || cfg.BaseURL != "https://staging.example.com" || *cfg.MaxRetries != 3 {
+		t.Errorf("staging profile = %+v", cfg)
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"unknown profile key", "a.yaml", "profiles:\n  dev:\n    apikey: x\n", `unknown key "apikey"`},
+		{"unknown top-level key", "a.yaml", "profile:\n  dev:\n    api_key: x\n", `unknown key "profile"`},
+		{"missing default profile", "a.yaml", "default: prod\nprofiles:\n  dev:\n    api_key: x\n", `default profile "prod" is not defined`},
+		{"bad line", "a.yaml", "profiles\n", "line 1"},
+		{"bad indentation", "a.yaml", "profiles:\n    dev:\n      api_key: x\n  prod:\n", "inconsistent indentation"},
+		{"bad timeout", "a.yaml", "profiles:\n  dev:\n    timeout: later\n", `profile "dev": invalid timeout`},
+		{"invalid JSON", "a.json", "{", "unexpected end of JSON input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfigFile(t, tt.file, tt.content))
+			if err == nil {
+				t.Fatal("LoadConfig() expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want substring %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigFile_ProfileNotFound(t *testing.T) {
+	file, err := LoadConfig(writeConfigFile(t, "nylas.yaml", testYAMLConfig))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	_, err = file.Profile("qa")
+	if err == nil || !strings.Contains(err.Error(), "available: dev, prod") {
+		t.Errorf("Profile(qa) error = %v, want list of available profiles", err)
+	}
+}
+
+func TestConfig_Proxy(t *testing.T) {
+	cfg := &Config{APIKey: "k", Proxy: "http://proxy.internal:3128"}
+	client, err := cfg.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", client.HTTPClient.Transport)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.us.nylas.com", nil)
+	u, err := transport.Proxy(req)
+	if err != nil || u == nil || u.Host != "proxy.internal:3128" {
+		t.Errorf("Proxy() = %v, %v; want proxy.internal:3128", u, err)
+	}
+}
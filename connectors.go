@@ -14,7 +14,7 @@ func (s *ConnectorsService) List(ctx context.Context, opts *connectors.ListOptio
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("connectors.List: %w", err)
+		return nil, newOpError("connectors.List", "", "", err)
 	}
 
 	if opts != nil {
@@ -26,7 +26,7 @@ func (s *ConnectorsService) List(ctx context.Context, opts *connectors.ListOptio
 	var data []connectors.Connector
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("connectors.List: %w", err)
+		return nil, newOpError("connectors.List", "", "", err)
 	}
 
 	return &ListResponse[connectors.Connector]{
@@ -42,13 +42,13 @@ func (s *ConnectorsService) Get(ctx context.Context, provider connectors.Provide
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("connectors.Get(%s): %w", provider, err)
+		return nil, newOpError("connectors.Get", "", string(provider), err)
 	}
 
 	var connector connectors.Connector
 	_, err = s.client.Do(req, &connector)
 	if err != nil {
-		return nil, fmt.Errorf("connectors.Get(%s): %w", provider, err)
+		return nil, newOpError("connectors.Get", "", string(provider), err)
 	}
 
 	return &connector, nil
@@ -60,13 +60,13 @@ func (s *ConnectorsService) Create(ctx context.Context, create *connectors.Creat
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, create)
 	if err != nil {
-		return nil, fmt.Errorf("connectors.Create: %w", err)
+		return nil, newOpError("connectors.Create", "", "", err)
 	}
 
 	var connector connectors.Connector
 	_, err = s.client.Do(req, &connector)
 	if err != nil {
-		return nil, fmt.Errorf("connectors.Create: %w", err)
+		return nil, newOpError("connectors.Create", "", "", err)
 	}
 
 	return &connector, nil
@@ -78,13 +78,13 @@ func (s *ConnectorsService) Update(ctx context.Context, provider connectors.Prov
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("connectors.Update(%s): %w", provider, err)
+		return nil, newOpError("connectors.Update", "", string(provider), err)
 	}
 
 	var connector connectors.Connector
 	_, err = s.client.Do(req, &connector)
 	if err != nil {
-		return nil, fmt.Errorf("connectors.Update(%s): %w", provider, err)
+		return nil, newOpError("connectors.Update", "", string(provider), err)
 	}
 
 	return &connector, nil
@@ -96,12 +96,12 @@ func (s *ConnectorsService) Delete(ctx context.Context, provider connectors.Prov
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("connectors.Delete(%s): %w", provider, err)
+		return newOpError("connectors.Delete", "", string(provider), err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("connectors.Delete(%s): %w", provider, err)
+		return newOpError("connectors.Delete", "", string(provider), err)
 	}
 
 	return nil
@@ -0,0 +1,17 @@
+package connectors
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes c, keeping fields this package does not declare in Extra.
+func (c *Connector) UnmarshalJSON(data []byte) error {
+	type alias Connector
+	extra, err := common.UnmarshalExtra(data, (*alias)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes c together with the fields kept in Extra.
+func (c Connector) MarshalJSON() ([]byte, error) {
+	type alias Connector
+	return common.MarshalExtra(alias(c), c.Extra)
+}
@@ -1,6 +1,8 @@
 // Package connectors provides types for the Nylas Connectors API.
 package connectors
 
+import "encoding/json"
+
 // Provider represents supported email provider types.
 type Provider string
 
@@ -17,6 +19,9 @@ type Connector struct {
 	Name     string                 `json:"name,omitempty"`
 	Settings map[string]interface{} `json:"settings,omitempty"`
 	Scope    []string               `json:"scope,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // GoogleSettings contains Google-specific connector settings.
@@ -0,0 +1,31 @@
+package connectors
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+func (r *CreateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Provider == "" {
+		v.Add("provider", "is required")
+	}
+	checkScope(v, r.Scope)
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Name != nil && *r.Name == "" {
+		v.Add("name", "must not be empty")
+	}
+	checkScope(v, r.Scope)
+	return v.Err()
+}
+
+func checkScope(v *common.ValidationError, scope []string) {
+	for i, s := range scope {
+		if s == "" {
+			v.Add(common.IndexPath("scope", i), "must not be empty")
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package connectors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantPath string
+	}{
+		{"update empty scope", (&UpdateRequest{Scope: []string{"email", ""}}).Validate(), "scope[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPath == "-" {
+				if tt.err != nil {
+					t.Errorf("Validate() error = %v, want nil", tt.err)
+				}
+				return
+			}
+			var verr *common.ValidationError
+			if !errors.As(tt.err, &verr) || verr.Fields[0].Path != tt.wantPath {
+				t.Errorf("Validate() error = %v, want problem at %q", tt.err, tt.wantPath)
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ func (s *ContactsService) List(ctx context.Context, grantID string, opts *contac
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("contacts.List: %w", err)
+		return nil, newOpError("contacts.List", grantID, "", err)
 	}
 
 	if opts != nil {
@@ -26,7 +26,7 @@ func (s *ContactsService) List(ctx context.Context, grantID string, opts *contac
 	var data []contacts.Contact
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("contacts.List: %w", err)
+		return nil, newOpError("contacts.List", grantID, "", err)
 	}
 
 	return &ListResponse[contacts.Contact]{
@@ -42,13 +42,13 @@ func (s *ContactsService) Get(ctx context.Context, grantID, contactID string) (*
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("contacts.Get(%s): %w", contactID, err)
+		return nil, newOpError("contacts.Get", grantID, contactID, err)
 	}
 
 	var contact contacts.Contact
 	_, err = s.client.Do(req, &contact)
 	if err != nil {
-		return nil, fmt.Errorf("contacts.Get(%s): %w", contactID, err)
+		return nil, newOpError("contacts.Get", grantID, contactID, err)
 	}
 
 	return &contact, nil
@@ -60,13 +60,13 @@ func (s *ContactsService) Create(ctx context.Context, grantID string, create *co
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, create)
 	if err != nil {
-		return nil, fmt.Errorf("contacts.Create: %w", err)
+		return nil, newOpError("contacts.Create", grantID, "", err)
 	}
 
 	var contact contacts.Contact
 	_, err = s.client.Do(req, &contact)
 	if err != nil {
-		return nil, fmt.Errorf("contacts.Create: %w", err)
+		return nil, newOpError("contacts.Create", grantID, "", err)
 	}
 
 	return &contact, nil
@@ -78,13 +78,13 @@ func (s *ContactsService) Update(ctx context.Context, grantID, contactID string,
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("contacts.Update(%s): %w", contactID, err)
+		return nil, newOpError("contacts.Update", grantID, contactID, err)
 	}
 
 	var contact contacts.Contact
 	_, err = s.client.Do(req, &contact)
 	if err != nil {
-		return nil, fmt.Errorf("contacts.Update(%s): %w", contactID, err)
+		return nil, newOpError("contacts.Update", grantID, contactID, err)
 	}
 
 	return &contact, nil
@@ -96,12 +96,12 @@ func (s *ContactsService) Delete(ctx context.Context, grantID, contactID string)
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("contacts.Delete(%s): %w", contactID, err)
+		return newOpError("contacts.Delete", grantID, contactID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("contacts.Delete(%s): %w", contactID, err)
+		return newOpError("contacts.Delete", grantID, contactID, err)
 	}
 
 	return nil
@@ -130,13 +130,13 @@ func (s *ContactsService) ListGroups(ctx context.Context, grantID string) ([]con
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("contacts.ListGroups: %w", err)
+		return nil, newOpError("contacts.ListGroups", grantID, "", err)
 	}
 
 	var groups []contacts.Group
 	_, err = s.client.Do(req, &groups)
 	if err != nil {
-		return nil, fmt.Errorf("contacts.ListGroups: %w", err)
+		return nil, newOpError("contacts.ListGroups", grantID, "", err)
 	}
 
 	return groups, nil
@@ -0,0 +1,31 @@
+package contacts
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes c, keeping fields this package does not declare in Extra.
+func (c *Contact) UnmarshalJSON(data []byte) error {
+	type alias Contact
+	extra, err := common.UnmarshalExtra(data, (*alias)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes c together with the fields kept in Extra.
+func (c Contact) MarshalJSON() ([]byte, error) {
+	type alias Contact
+	return common.MarshalExtra(alias(c), c.Extra)
+}
+
+// UnmarshalJSON decodes g, keeping fields this package does not declare in Extra.
+func (g *Group) UnmarshalJSON(data []byte) error {
+	type alias Group
+	extra, err := common.UnmarshalExtra(data, (*alias)(g))
+	g.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes g together with the fields kept in Extra.
+func (g Group) MarshalJSON() ([]byte, error) {
+	type alias Group
+	return common.MarshalExtra(alias(g), g.Extra)
+}
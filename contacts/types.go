@@ -1,5 +1,7 @@
 package contacts
 
+import "encoding/json"
+
 // Contact represents a contact in the Nylas API.
 type Contact struct {
 	// ID is the unique identifier for this contact.
@@ -50,6 +52,9 @@ type Contact struct {
 	Source string `json:"source,omitempty"`
 	// WebPages contains the contact's websites and social profiles.
 	WebPages []WebPage `json:"web_pages,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Email represents an email address for a contact.
@@ -108,6 +113,9 @@ type Group struct {
 	ID string `json:"id"`
 	// Name is the group's display name.
 	Name string `json:"name,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ListOptions specifies options for listing contacts.
@@ -0,0 +1,35 @@
+package contacts
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+func (r *CreateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Birthday != "" {
+		checkBirthday(v, r.Birthday)
+	}
+	checkEmails(v, r.Emails)
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Birthday != nil && *r.Birthday != "" {
+		checkBirthday(v, *r.Birthday)
+	}
+	checkEmails(v, r.Emails)
+	return v.Err()
+}
+
+func checkBirthday(v *common.ValidationError, birthday string) {
+	if !common.ValidDate(birthday) {
+		v.Add("birthday", "%q is not a YYYY-MM-DD date", birthday)
+	}
+}
+
+func checkEmails(v *common.ValidationError, emails []Email) {
+	for i, e := range emails {
+		common.CheckEmail(v, common.JoinPath(common.IndexPath("emails", i), "email"), e.Email)
+	}
+}
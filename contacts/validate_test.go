@@ -0,0 +1,35 @@
+package contacts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantPath string
+	}{
+		{"birthday", (&CreateRequest{Birthday: "1990-05-17"}).Validate(), "-"},
+		{"birthday format", (&CreateRequest{Birthday: "May 17"}).Validate(), "birthday"},
+		{"email format", (&UpdateRequest{Emails: []Email{{Email: "a@example.com"}, {Email: "a"}}}).Validate(), "emails[1].email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPath == "-" {
+				if tt.err != nil {
+					t.Errorf("Validate() error = %v, want nil", tt.err)
+				}
+				return
+			}
+			var verr *common.ValidationError
+			if !errors.As(tt.err, &verr) || verr.Fields[0].Path != tt.wantPath {
+				t.Errorf("Validate() error = %v, want problem at %q", tt.err, tt.wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package nylas
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/mqasimca/nylas-go/drafts"
+	"github.com/mqasimca/nylas-go/messages"
+	"github.com/mqasimca/nylas-go/threads"
+)
+
+// conversationConcurrency is the number of requests GetConversation keeps in flight
+// when fetching messages and drafts one by one.
+const conversationConcurrency = 5
+
+// conversationPageSize is the page size GetConversation lists messages and drafts with.
+const conversationPageSize = 200
+
+// GetConversation returns a thread with all of its messages and drafts, ordered by
+// date and arranged as a reply tree (see threads.NewConversation).
+//
+// The thread, its messages and its drafts are fetched concurrently, listing messages
+// and drafts with the thread_id filter. Any message or draft of the thread the
+// filter did not return is then fetched individually. Messages include their
+// headers, which the reply tree is built from. Messages and drafts deleted while the
+// conversation is fetched are left out.
+//
+// Example:
+//
+//	conv, err := client.Threads.GetConversation(ctx, grantID, threadID)
+//	if err != nil {
+//	    return err
+//	}
+//	conv.Walk(func(n *threads.Node) {
+//	    fmt.Printf("%s%s\n", strings.Repeat("  ", n.Depth), n.ID())
+//	})
+func (s *ThreadsService) GetConversation(ctx context.Context, grantID, threadID string) (*threads.Conversation, error) {
+	var (
+		wg                          sync.WaitGroup
+		thread                      *threads.Thread
+		msgs                        []*messages.Message
+		ds                          []*drafts.Draft
+		threadErr, msgErr, draftErr error
+	)
+	limit := conversationPageSize
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		thread, threadErr = s.Get(ctx, grantID, threadID)
+	}()
+	go func() {
+		defer wg.Done()
+		opts := &messages.ListOptions{ThreadID: &threadID, Limit: &limit}
+		msgs, msgErr = s.client.Messages.ListAll(ctx, grantID, opts.SetIncludeHeaders()).Collect()
+	}()
+	go func() {
+		defer wg.Done()
+		ds, draftErr = s.client.Drafts.ListAll(ctx, grantID, &drafts.ListOptions{ThreadID: &threadID, Limit: &limit}).Collect()
+	}()
+	wg.Wait()
+	if err := firstError(threadErr, msgErr, draftErr); err != nil {
+		return nil, newOpError("threads.GetConversation", grantID, threadID, err)
+	}
+
+	gotMessages := make(map[string]bool)
+	var convMessages []messages.Message
+	for _, m := range msgs {
+		if m.ThreadID == threadID && !gotMessages[m.ID] {
+			gotMessages[m.ID] = true
+			convMessages = append(convMessages, *m)
+		}
+	}
+	gotDrafts := make(map[string]bool)
+	var convDrafts []drafts.Draft
+	for _, d := range ds {
+		if d.ThreadID == threadID && !gotDrafts[d.ID] {
+			gotDrafts[d.ID] = true
+			convDrafts = append(convDrafts, *d)
+		}
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		var more []messages.Message
+		more, msgErr = fetchEach(ctx, missing(thread.MessageIDs, gotMessages), func(ctx context.Context, id string) (*messages.Message, error) {
+			return s.client.Messages.GetWithHeaders(ctx, grantID, id)
+		})
+		convMessages = append(convMessages, more...)
+	}()
+	go func() {
+		defer wg.Done()
+		var more []drafts.Draft
+		more, draftErr = fetchEach(ctx, missing(thread.DraftIDs, gotDrafts), func(ctx context.Context, id string) (*drafts.Draft, error) {
+			return s.client.Drafts.Get(ctx, grantID, id)
+		})
+		convDrafts = append(convDrafts, more...)
+	}()
+	wg.Wait()
+	if err := firstError(msgErr, draftErr); err != nil {
+		return nil, newOpError("threads.GetConversation", grantID, threadID, err)
+	}
+
+	return threads.NewConversation(thread, convMessages, convDrafts), nil
+}
+
+// missing returns the IDs in ids that are not in got.
+func missing(ids []string, got map[string]bool) []string {
+	var out []string
+	for _, id := range ids {
+		if !got[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// fetchEach calls get for every ID, conversationConcurrency at a time, and returns
+// the results in the order of ids. IDs that are not found are skipped; any other
+// error stops the fetch.
+func fetchEach[T any](ctx context.Context, ids []string, get func(ctx context.Context, id string) (*T, error)) ([]T, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*T, len(ids))
+	errs := make([]error, len(ids))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(conversationConcurrency, len(ids)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = get(ctx, ids[i])
+				if errs[i] != nil && !errors.Is(errs[i], ErrNotFound) {
+					cancel()
+				}
+			}
+		}()
+	}
+feed:
+	for i := range ids {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	var out []T
+	for i, r := range results {
+		if errs[i] != nil && !errors.Is(errs[i], ErrNotFound) {
+			return nil, errs[i]
+		}
+		if r != nil {
+			out = append(out, *r)
+		}
+	}
+	return out, ctx.Err()
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
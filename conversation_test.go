@@ -0,0 +1,83 @@
+package nylas
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/threads"
+)
+
+func TestThreadsService_GetConversation(t *testing.T) {
+	const base = "/v3/grants/grant-123"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case base + "/threads/t1":
+			_, _ = w.Write([]byte(`{"data": {"id": "t1", "message_ids": ["m1", "m2", "m3", "m4"], "draft_ids": ["d1"]}}`))
+		case base + "/messages":
+			if q.Get("thread_id") != "t1" || q.Get("fields") != "include_headers" {
+				t.Errorf("messages query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "m2", "thread_id": "t1", "date": 200, "headers": [{"name": "Message-ID", "value": "<m2@x>"}, {"name": "In-Reply-To", "value": "<m1@x>"}]},
+				{"id": "m1", "thread_id": "t1", "date": 100, "headers": [{"name": "Message-ID", "value": "<m1@x>"}]},
+				{"id": "other", "thread_id": "t2", "date": 150}
+			]}`))
+		case base + "/messages/m3":
+			if q.Get("fields") != "include_headers" {
+				t.Errorf("m3 query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"data": {"id": "m3", "thread_id": "t1", "date": 300, "headers": [{"name": "Message-ID", "value": "<m3@x>"}, {"name": "References", "value": "<m1@x>"}]}}`))
+		case base + "/messages/m4":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found", "type": "not_found_error"}`))
+		case base + "/drafts":
+			if q.Get("thread_id") != "t1" {
+				t.Errorf("drafts query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"data": [{"id": "d1", "thread_id": "t1", "date": 400}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	conv, err := client.Threads.GetConversation(context.Background(), "grant-123", "t1")
+	if err != nil {
+		t.Fatalf("GetConversation() error = %v", err)
+	}
+	if conv.Thread.ID != "t1" || len(conv.Messages) != 3 || len(conv.Drafts) != 1 {
+		t.Fatalf("conversation = %+v", conv)
+	}
+	var order []string
+	conv.Walk(func(n *threads.Node) {
+		order = append(order, strings.Repeat(" ", n.Depth)+n.ID())
+	})
+	if got, want := strings.Join(order, ","), "m1, m2, m3,  d1"; got != want {
+		t.Errorf("tree = %q, want %q", got, want)
+	}
+}
+
+func TestThreadsService_GetConversation_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/drafts") {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message": "boom", "type": "api_error"}`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/messages") {
+			_, _ = w.Write([]byte(`{"data": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"id": "t1"}}`))
+	})
+
+	_, err := client.Threads.GetConversation(context.Background(), "grant-123", "t1")
+	var opErr *OpError
+	if !errors.As(err, &opErr) || opErr.Op != "threads.GetConversation" {
+		t.Errorf("error = %v, want threads.GetConversation OpError", err)
+	}
+}
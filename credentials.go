@@ -15,7 +15,7 @@ func (s *CredentialsService) List(ctx context.Context, provider connectors.Provi
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("credentials.List: %w", err)
+		return nil, newOpError("credentials.List", "", "", err)
 	}
 
 	if opts != nil {
@@ -27,7 +27,7 @@ func (s *CredentialsService) List(ctx context.Context, provider connectors.Provi
 	var data []credentials.Credential
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("credentials.List: %w", err)
+		return nil, newOpError("credentials.List", "", "", err)
 	}
 
 	return &ListResponse[credentials.Credential]{
@@ -43,13 +43,13 @@ func (s *CredentialsService) Get(ctx context.Context, provider connectors.Provid
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("credentials.Get(%s): %w", credentialID, err)
+		return nil, newOpError("credentials.Get", "", credentialID, err)
 	}
 
 	var cred credentials.Credential
 	_, err = s.client.Do(req, &cred)
 	if err != nil {
-		return nil, fmt.Errorf("credentials.Get(%s): %w", credentialID, err)
+		return nil, newOpError("credentials.Get", "", credentialID, err)
 	}
 
 	return &cred, nil
@@ -61,13 +61,13 @@ func (s *CredentialsService) Create(ctx context.Context, provider connectors.Pro
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, create)
 	if err != nil {
-		return nil, fmt.Errorf("credentials.Create: %w", err)
+		return nil, newOpError("credentials.Create", "", "", err)
 	}
 
 	var cred credentials.Credential
 	_, err = s.client.Do(req, &cred)
 	if err != nil {
-		return nil, fmt.Errorf("credentials.Create: %w", err)
+		return nil, newOpError("credentials.Create", "", "", err)
 	}
 
 	return &cred, nil
@@ -79,13 +79,13 @@ func (s *CredentialsService) Update(ctx context.Context, provider connectors.Pro
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("credentials.Update(%s): %w", credentialID, err)
+		return nil, newOpError("credentials.Update", "", credentialID, err)
 	}
 
 	var cred credentials.Credential
 	_, err = s.client.Do(req, &cred)
 	if err != nil {
-		return nil, fmt.Errorf("credentials.Update(%s): %w", credentialID, err)
+		return nil, newOpError("credentials.Update", "", credentialID, err)
 	}
 
 	return &cred, nil
@@ -97,12 +97,12 @@ func (s *CredentialsService) Delete(ctx context.Context, provider connectors.Pro
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("credentials.Delete(%s): %w", credentialID, err)
+		return newOpError("credentials.Delete", "", credentialID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("credentials.Delete(%s): %w", credentialID, err)
+		return newOpError("credentials.Delete", "", credentialID, err)
 	}
 
 	return nil
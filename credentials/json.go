@@ -0,0 +1,17 @@
+package credentials
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes c, keeping fields this package does not declare in Extra.
+func (c *Credential) UnmarshalJSON(data []byte) error {
+	type alias Credential
+	extra, err := common.UnmarshalExtra(data, (*alias)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes c together with the fields kept in Extra.
+func (c Credential) MarshalJSON() ([]byte, error) {
+	type alias Credential
+	return common.MarshalExtra(alias(c), c.Extra)
+}
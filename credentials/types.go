@@ -1,7 +1,10 @@
 // Package credentials provides types for the Nylas Credentials API.
 package credentials
 
-import "github.com/mqasimca/nylas-go/connectors"
+import (
+	"encoding/json"
+	"github.com/mqasimca/nylas-go/connectors"
+)
 
 // CredentialType represents the type of credential.
 type CredentialType string
@@ -20,6 +23,9 @@ type Credential struct {
 	HashedData     string         `json:"hashed_data,omitempty"`
 	CreatedAt      int64          `json:"created_at,omitempty"`
 	UpdatedAt      int64          `json:"updated_at,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // CreateRequest contains the data to create a credential.
@@ -0,0 +1,27 @@
+package credentials
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+func (r *CreateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Name == "" {
+		v.Add("name", "is required")
+	}
+	if r.CredentialType == "" {
+		v.Add("credential_type", "is required")
+	}
+	if len(r.CredentialData) == 0 {
+		v.Add("credential_data", "is required")
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Name != nil && *r.Name == "" {
+		v.Add("name", "must not be empty")
+	}
+	return v.Err()
+}
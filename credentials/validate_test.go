@@ -0,0 +1,36 @@
+package credentials
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantPath string
+	}{
+		{"create valid", CreateMicrosoftRequest("ms", "id", "secret").Validate(), "-"},
+		{"create missing name", (&CreateRequest{CredentialType: CredentialTypeAdminConsent, CredentialData: map[string]interface{}{"a": 1}}).Validate(), "name"},
+		{"create missing data", (&CreateRequest{Name: "ms", CredentialType: CredentialTypeAdminConsent}).Validate(), "credential_data"},
+		{"update empty name", (&UpdateRequest{Name: new(string)}).Validate(), "name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPath == "-" {
+				if tt.err != nil {
+					t.Errorf("Validate() error = %v, want nil", tt.err)
+				}
+				return
+			}
+			var verr *common.ValidationError
+			if !errors.As(tt.err, &verr) || verr.Fields[0].Path != tt.wantPath {
+				t.Errorf("Validate() error = %v, want problem at %q", tt.err, tt.wantPath)
+			}
+		})
+	}
+}
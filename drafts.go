@@ -12,9 +12,13 @@ import (
 func (s *DraftsService) List(ctx context.Context, grantID string, opts *drafts.ListOptions) (*ListResponse[drafts.Draft], error) {
 	path := fmt.Sprintf("/v3/grants/%s/drafts", grantID)
 
+	if err := s.client.validate(opts); err != nil {
+		return nil, newOpError("drafts.List", grantID, "", err)
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("drafts.List: %w", err)
+		return nil, newOpError("drafts.List", grantID, "", err)
 	}
 
 	if opts != nil {
@@ -26,7 +30,7 @@ func (s *DraftsService) List(ctx context.Context, grantID string, opts *drafts.L
 	var data []drafts.Draft
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("drafts.List: %w", err)
+		return nil, newOpError("drafts.List", grantID, "", err)
 	}
 
 	return &ListResponse[drafts.Draft]{
@@ -42,49 +46,73 @@ func (s *DraftsService) Get(ctx context.Context, grantID, draftID string) (*draf
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("drafts.Get(%s): %w", draftID, err)
+		return nil, newOpError("drafts.Get", grantID, draftID, err)
 	}
 
 	var draft drafts.Draft
 	_, err = s.client.Do(req, &draft)
 	if err != nil {
-		return nil, fmt.Errorf("drafts.Get(%s): %w", draftID, err)
+		return nil, newOpError("drafts.Get", grantID, draftID, err)
 	}
 
 	return &draft, nil
 }
 
 // Create creates a new draft.
+// Large attachments, and attachments with a Reader, are streamed as multipart/form-data.
+// If the client has a SendPolicy, it is enforced before the request is made.
 func (s *DraftsService) Create(ctx context.Context, grantID string, create *drafts.CreateRequest) (*drafts.Draft, error) {
 	path := fmt.Sprintf("/v3/grants/%s/drafts", grantID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, create)
+	create, err := s.client.applyDraftPolicy(ctx, grantID, create)
 	if err != nil {
-		return nil, fmt.Errorf("drafts.Create: %w", err)
+		return nil, newOpError("drafts.Create", grantID, "", err)
+	}
+
+	var attachments []drafts.AttachmentRequest
+	if create != nil {
+		attachments = create.Attachments
+	}
+
+	req, err := s.client.newAttachmentRequest(ctx, http.MethodPost, path, create, attachments)
+	if err != nil {
+		return nil, newOpError("drafts.Create", grantID, "", err)
 	}
 
 	var draft drafts.Draft
 	_, err = s.client.Do(req, &draft)
 	if err != nil {
-		return nil, fmt.Errorf("drafts.Create: %w", err)
+		return nil, newOpError("drafts.Create", grantID, "", err)
 	}
 
 	return &draft, nil
 }
 
 // Update updates a draft.
+// Large attachments, and attachments with a Reader, are streamed as multipart/form-data.
+// If the client has a SendPolicy, it is enforced before the request is made.
 func (s *DraftsService) Update(ctx context.Context, grantID, draftID string, update *drafts.UpdateRequest) (*drafts.Draft, error) {
 	path := fmt.Sprintf("/v3/grants/%s/drafts/%s", grantID, draftID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
+	update, err := s.client.applyDraftUpdatePolicy(ctx, grantID, update)
+	if err != nil {
+		return nil, newOpError("drafts.Update", grantID, draftID, err)
+	}
+
+	var attachments []drafts.AttachmentRequest
+	if update != nil {
+		attachments = update.Attachments
+	}
+
+	req, err := s.client.newAttachmentRequest(ctx, http.MethodPut, path, update, attachments)
 	if err != nil {
-		return nil, fmt.Errorf("drafts.Update(%s): %w", draftID, err)
+		return nil, newOpError("drafts.Update", grantID, draftID, err)
 	}
 
 	var draft drafts.Draft
 	_, err = s.client.Do(req, &draft)
 	if err != nil {
-		return nil, fmt.Errorf("drafts.Update(%s): %w", draftID, err)
+		return nil, newOpError("drafts.Update", grantID, draftID, err)
 	}
 
 	return &draft, nil
@@ -96,30 +124,44 @@ func (s *DraftsService) Delete(ctx context.Context, grantID, draftID string) err
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("drafts.Delete(%s): %w", draftID, err)
+		return newOpError("drafts.Delete", grantID, draftID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("drafts.Delete(%s): %w", draftID, err)
+		return newOpError("drafts.Delete", grantID, draftID, err)
 	}
 
 	return nil
 }
 
 // Send sends a draft as an email message.
+// If the client has a SendPolicy, the draft is fetched and checked before it is sent.
 func (s *DraftsService) Send(ctx context.Context, grantID, draftID string) (*drafts.Draft, error) {
 	path := fmt.Sprintf("/v3/grants/%s/drafts/%s", grantID, draftID)
 
+	if s.client.sendPolicy != nil {
+		existing, err := s.Get(ctx, grantID, draftID)
+		if err != nil {
+			return nil, err
+		}
+		if existing.GrantID == "" {
+			existing.GrantID = grantID
+		}
+		if err := s.client.checkDraftSend(ctx, existing); err != nil {
+			return nil, newOpError("drafts.Send", grantID, draftID, err)
+		}
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("drafts.Send(%s): %w", draftID, err)
+		return nil, newOpError("drafts.Send", grantID, draftID, err)
 	}
 
 	var draft drafts.Draft
 	_, err = s.client.Do(req, &draft)
 	if err != nil {
-		return nil, fmt.Errorf("drafts.Send(%s): %w", draftID, err)
+		return nil, newOpError("drafts.Send", grantID, draftID, err)
 	}
 
 	return &draft, nil
@@ -0,0 +1,22 @@
+package drafts
+
+import (
+	"context"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// EmbedOptions is an alias for common.EmbedOptions.
+type EmbedOptions = common.EmbedOptions
+
+// EmbedImages moves the data: images of r.Body, and local and remote images if
+// enabled in opts, into inline attachments referenced by cid: URLs. Identical images
+// are attached once. On error r is unchanged. See common.EmbedImages.
+func (r *CreateRequest) EmbedImages(ctx context.Context, opts *EmbedOptions) error {
+	body, attachments, err := common.EmbedImages(ctx, r.Body, r.Attachments, opts)
+	if err != nil {
+		return err
+	}
+	r.Body, r.Attachments = body, attachments
+	return nil
+}
@@ -0,0 +1,17 @@
+package drafts
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCreateRequest_EmbedImages(t *testing.T) {
+	req := &CreateRequest{Body: `<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw="><img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=">`}
+	if err := req.EmbedImages(context.Background(), nil); err != nil {
+		t.Fatalf("EmbedImages() error = %v", err)
+	}
+	if len(req.Attachments) != 1 || strings.Count(req.Body, "cid:"+req.Attachments[0].ContentID) != 2 {
+		t.Errorf("request = %+v", req)
+	}
+}
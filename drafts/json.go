@@ -0,0 +1,17 @@
+package drafts
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes d, keeping fields this package does not declare in Extra.
+func (d *Draft) UnmarshalJSON(data []byte) error {
+	type alias Draft
+	extra, err := common.UnmarshalExtra(data, (*alias)(d))
+	d.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes d together with the fields kept in Extra.
+func (d Draft) MarshalJSON() ([]byte, error) {
+	type alias Draft
+	return common.MarshalExtra(alias(d), d.Extra)
+}
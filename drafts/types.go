@@ -1,6 +1,7 @@
 package drafts
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/mqasimca/nylas-go/common"
@@ -15,6 +16,9 @@ type Attachment = common.Attachment
 // AttachmentRequest is an alias for common.AttachmentRequest.
 type AttachmentRequest = common.AttachmentRequest
 
+// Header is an alias for common.Header.
+type Header = common.Header
+
 // TrackingOptions is an alias for common.TrackingOptions.
 type TrackingOptions = common.TrackingOptions
 
@@ -26,6 +30,8 @@ type Draft struct {
 	GrantID string `json:"grant_id"`
 	// Object is the object type, always "draft".
 	Object string `json:"object,omitempty"`
+	// Metadata contains custom key-value pairs; see common.Metadata for typed access.
+	Metadata map[string]string `json:"metadata,omitempty"`
 	// ThreadID is the ID of the thread this draft belongs to (for replies).
 	ThreadID string `json:"thread_id,omitempty"`
 	// Subject is the subject line of the draft.
@@ -56,6 +62,9 @@ type Draft struct {
 	Attachments []Attachment `json:"attachments,omitempty"`
 	// CreatedAt is the Unix timestamp when the draft was created.
 	CreatedAt int64 `json:"created_at,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ListOptions specifies options for listing drafts.
@@ -83,6 +92,9 @@ type ListOptions struct {
 	ThreadID *string `json:"thread_id,omitempty"`
 	// HasAttachment filters drafts with or without attachments.
 	HasAttachment *bool `json:"has_attachment,omitempty"`
+	// MetadataPair filters by metadata key-value pair ("key:value" format). Only the
+	// keys key1 to key5 can be filtered on; see common.MetadataPair.
+	MetadataPair *string `json:"metadata_pair,omitempty"`
 }
 
 // Values converts ListOptions to URL query parameters.
@@ -124,6 +136,9 @@ func (o *ListOptions) Values() map[string]any {
 	if o.HasAttachment != nil {
 		v["has_attachment"] = *o.HasAttachment
 	}
+	if o.MetadataPair != nil {
+		v["metadata_pair"] = *o.MetadataPair
+	}
 	return v
 }
 
@@ -149,6 +164,10 @@ type CreateRequest struct {
 	TrackingOptions *TrackingOptions `json:"tracking_options,omitempty"`
 	// Attachments is the list of files to attach.
 	Attachments []AttachmentRequest `json:"attachments,omitempty"`
+	// CustomHeaders adds custom headers to the message when the draft is sent.
+	CustomHeaders []Header `json:"custom_headers,omitempty"`
+	// Metadata attaches custom key-value pairs to the draft and the message sent from it.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // UpdateRequest represents a request to update a draft.
@@ -173,6 +192,10 @@ type UpdateRequest struct {
 	Starred *bool `json:"starred,omitempty"`
 	// Attachments is the list of files to attach.
 	Attachments []AttachmentRequest `json:"attachments,omitempty"`
+	// CustomHeaders adds custom headers to the message when the draft is sent.
+	CustomHeaders []Header `json:"custom_headers,omitempty"`
+	// Metadata replaces the draft's custom key-value pairs.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // DateTime returns the draft date as time.Time.
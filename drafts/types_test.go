@@ -79,6 +79,11 @@ func TestListOptions_Values(t *testing.T) {
 			opts: &ListOptions{HasAttachment: ptrBool(true)},
 			want: 1,
 		},
+		{
+			name: "with metadata_pair",
+			opts: &ListOptions{MetadataPair: ptrString("key1:crm-42")},
+			want: 1,
+		},
 		{
 			name: "with all options",
 			opts: &ListOptions{
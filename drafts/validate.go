@@ -0,0 +1,39 @@
+package drafts
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+// Recipients are optional on drafts but must be valid when present.
+func (r *CreateRequest) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckParticipants(v, "from", r.From)
+	common.CheckParticipants(v, "to", r.To)
+	common.CheckParticipants(v, "cc", r.CC)
+	common.CheckParticipants(v, "bcc", r.BCC)
+	common.CheckParticipants(v, "reply_to", r.ReplyTo)
+	common.CheckAttachments(v, "attachments", r.Attachments)
+	common.CheckHeaders(v, "custom_headers", r.CustomHeaders)
+	common.CheckMetadata(v, "metadata", r.Metadata)
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckParticipants(v, "from", r.From)
+	common.CheckParticipants(v, "to", r.To)
+	common.CheckParticipants(v, "cc", r.CC)
+	common.CheckParticipants(v, "bcc", r.BCC)
+	common.CheckParticipants(v, "reply_to", r.ReplyTo)
+	common.CheckAttachments(v, "attachments", r.Attachments)
+	common.CheckHeaders(v, "custom_headers", r.CustomHeaders)
+	common.CheckMetadata(v, "metadata", r.Metadata)
+	return v.Err()
+}
+
+// Validate checks that MetadataPair names a filterable key.
+func (o *ListOptions) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckMetadataPair(v, "metadata_pair", o.MetadataPair)
+	return v.Err()
+}
@@ -0,0 +1,36 @@
+package drafts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestDraftRequests_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantPath string
+	}{
+		{"create without recipients", (&CreateRequest{Subject: "No recipients yet"}).Validate(), "-"},
+		{"create email format", (&CreateRequest{To: []Participant{{Email: "bad"}}}).Validate(), "to[0].email"},
+		{"update attachment", (&UpdateRequest{Attachments: []AttachmentRequest{{Content: "aGk="}}}).Validate(), "attachments[0].filename"},
+		{"list metadata_pair", (&ListOptions{MetadataPair: ptrString("key1")}).Validate(), "metadata_pair"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPath == "-" {
+				if tt.err != nil {
+					t.Errorf("Validate() error = %v, want nil", tt.err)
+				}
+				return
+			}
+			var verr *common.ValidationError
+			if !errors.As(tt.err, &verr) || verr.Fields[0].Path != tt.wantPath {
+				t.Errorf("Validate() error = %v, want problem at %q", tt.err, tt.wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package nylas
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/auth"
+	"github.com/mqasimca/nylas-go/calendars"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+func newDryRunTestClient(t *testing.T, opts ...Option) (*Client, *int) {
+	t.Helper()
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodGet {
+			t.Errorf("dry-run request reached server: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"data": {"id": "msg-1", "subject": "from server"}, "request_id": "req-1"}`))
+	}))
+	t.Cleanup(srv.Close)
+	opts = append([]Option{WithAPIKey("test-key"), WithBaseURL(srv.URL), WithMaxRetries(0)}, opts...)
+	client, err := NewClient(opts...)
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	return client, &calls
+}
+
+func TestDryRun_Send(t *testing.T) {
+	client, calls := newDryRunTestClient(t, WithDryRun())
+
+	msg, err := client.Messages.Send(context.Background(), "grant-123", &messages.SendRequest{
+		To:      []messages.Participant{{Email: "to@example.com"}},
+		Subject: "Quarterly update",
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if *calls != 0 {
+		t.Errorf("server calls = %d, want 0", *calls)
+	}
+	if msg.Subject != "Quarterly update" || len(msg.To) != 1 {
+		t.Errorf("Send() synthetic message = %+v, want fields from request", msg)
+	}
+	if msg.ID != "dry-run-1" {
+		t.Errorf("Send() ID = %q, want dry-run-1", msg.ID)
+	}
+
+	ops := client.DryRunOperations()
+	if len(ops) != 1 {
+		t.Fatalf("DryRunOperations() len = %d, want 1", len(ops))
+	}
+	if ops[0].Method != http.MethodPost || ops[0].Path != "/v3/grants/grant-123/messages/send" {
+		t.Errorf("operation = %s %s", ops[0].Method, ops[0].Path)
+	}
+	var body messages.SendRequest
+	if err := json.Unmarshal(ops[0].Body, &body); err != nil || body.Subject != "Quarterly update" {
+		t.Errorf("recorded body = %s, err = %v", ops[0].Body, err)
+	}
+}
+
+func TestDryRun_UpdateDeleteAndGet(t *testing.T) {
+	client, calls := newDryRunTestClient(t, WithDryRun())
+	ctx := context.Background()
+
+	msg, err := client.Messages.Update(ctx, "grant-123", "msg-9", &messages.UpdateRequest{Unread: Ptr(false)})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if msg.ID != "msg-9" {
+		t.Errorf("Update() ID = %q, want msg-9", msg.ID)
+	}
+
+	if err := client.Messages.Delete(ctx, "grant-123", "msg-9"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	got, err := client.Messages.Get(ctx, "grant-123", "msg-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Subject != "from server" || *calls != 1 {
+		t.Errorf("Get() should reach server: subject = %q, calls = %d", got.Subject, *calls)
+	}
+
+	ops := client.DryRunOperations()
+	if len(ops) != 2 || ops[0].Method != http.MethodPut || ops[1].Method != http.MethodDelete {
+		t.Fatalf("DryRunOperations() = %+v", ops)
+	}
+
+	client.ClearDryRunOperations()
+	if len(client.DryRunOperations()) != 0 {
+		t.Error("ClearDryRunOperations() did not clear")
+	}
+}
+
+func TestDryRun_Context(t *testing.T) {
+	client, calls := newDryRunTestClient(t)
+
+	ctx := ContextWithDryRun(context.Background())
+	if err := client.Messages.Delete(ctx, "grant-123", "msg-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if *calls != 0 {
+		t.Errorf("server calls = %d, want 0", *calls)
+	}
+	if len(client.DryRunOperations()) != 1 {
+		t.Errorf("DryRunOperations() len = %d, want 1", len(client.DryRunOperations()))
+	}
+}
+
+func TestDryRun_DoRaw(t *testing.T) {
+	client, _ := newDryRunTestClient(t, WithDryRun())
+
+	if err := client.Auth.Revoke(context.Background(), "token-1"); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	// Exchanging a code uses it up and creates a grant, so it is intercepted too.
+	if _, err := client.Auth.ExchangeCodeForToken(context.Background(), &auth.CodeExchangeRequest{
+		ClientID:    "client-1",
+		Code:        "code-1",
+		RedirectURI: "https://app.example.com/cb",
+	}); err != nil {
+		t.Fatalf("ExchangeCodeForToken() error = %v", err)
+	}
+	ops := client.DryRunOperations()
+	if len(ops) != 2 || ops[0].Path != "/v3/connect/revoke" || ops[1].Path != "/v3/connect/token" {
+		t.Errorf("DryRunOperations() = %+v, want the revoke and the token exchange", ops)
+	}
+}
+
+func TestDryRun_ReadOnlyPost(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"data": [{"email": "a@example.com", "object": "free_busy"}], "request_id": "req-1"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(srv.URL), WithMaxRetries(0), WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Calendars.FreeBusy(context.Background(), "grant-123", &calendars.FreeBusyRequest{
+		StartTime: 1700000000,
+		EndTime:   1700003600,
+		Emails:    []string{"a@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("FreeBusy() error = %v", err)
+	}
+	if len(resp) != 1 || resp[0].Email != "a@example.com" {
+		t.Errorf("FreeBusy() = %+v", resp)
+	}
+	if len(paths) != 1 || paths[0] != "POST /v3/grants/grant-123/calendars/free-busy" {
+		t.Errorf("server requests = %v", paths)
+	}
+	if ops := client.DryRunOperations(); len(ops) != 0 {
+		t.Errorf("DryRunOperations() = %+v, want none", ops)
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{http.MethodPost, "/v3/calendars/availability", true},
+		{http.MethodPost, "/v3/grants/g1/calendars/free-busy", true},
+		{http.MethodPost, "/prefix/v3/grants/g1/messages/m1/smart-compose", true},
+		{http.MethodPut, "/v3/grants/g1/messages/clean", true},
+		{http.MethodPost, "/v3/connect/token", false},
+		{http.MethodPost, "/v3/grants/g1/messages/send", false},
+		{http.MethodPost, "/v3/grants/g1/calendars", false},
+		{http.MethodPut, "/v3/grants/g1/messages/m1", false},
+		{http.MethodDelete, "/v3/grants/g1/calendars/free-busy", false},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, "https://api.example.com"+tt.path, nil)
+		if got := isReadOnly(req); got != tt.want {
+			t.Errorf("isReadOnly(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
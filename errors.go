@@ -3,6 +3,9 @@ package nylas
 import (
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/mqasimca/nylas-go/common"
 )
 
 // Sentinel errors for common API error conditions.
@@ -18,8 +21,25 @@ var (
 	ErrRateLimited   = errors.New("nylas: rate limited")
 	ErrBadRequest    = errors.New("nylas: bad request")
 	ErrServerError   = errors.New("nylas: server error")
+	ErrValidation    = common.ErrValidation
+	ErrSizeMismatch  = errors.New("nylas: downloaded size does not match attachment size")
+
+	ErrAttachmentTooLarge = common.ErrAttachmentTooLarge
+	ErrAttachmentConsumed = common.ErrAttachmentConsumed
+	ErrMetadataNotFound   = common.ErrMetadataNotFound
 )
 
+// ValidationError lists every problem found while validating a request.
+// It is returned before any network call; see WithoutValidation.
+type ValidationError = common.ValidationError
+
+// FieldError describes a single invalid field in a request.
+type FieldError = common.FieldError
+
+// AttachmentTooLargeError is returned by the attachment constructors in package common
+// when the data exceeds the size limit.
+type AttachmentTooLargeError = common.AttachmentTooLargeError
+
 // APIError represents an error response from the Nylas API.
 type APIError struct {
 	StatusCode int    `json:"-"`
@@ -53,3 +73,66 @@ func (e *APIError) Is(target error) bool {
 	}
 	return false
 }
+
+// OpError describes a failed SDK operation. It wraps the underlying *APIError,
+// *PolicyError or transport error, so errors.Is and errors.As continue to work:
+//
+//	var opErr *nylas.OpError
+//	if errors.As(err, &opErr) {
+//	    log.Printf("op=%s resource=%s id=%s", opErr.Op, opErr.Resource, opErr.ResourceID)
+//	}
+type OpError struct {
+	// Op is the operation that failed, e.g. "messages.Get".
+	Op string
+	// Resource is the type of resource operated on, e.g. "messages" or "bookings".
+	Resource string
+	// GrantID is the grant the operation ran as, if any.
+	GrantID string
+	// ResourceID is the ID of the resource operated on, if any.
+	ResourceID string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *OpError) Error() string {
+	if e.ResourceID != "" {
+		return fmt.Sprintf("%s(%s): %v", e.Op, e.ResourceID, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+// opResources maps operations whose resource differs from their service name.
+var opResources = map[string]string{
+	"messages.ListScheduled":      "schedules",
+	"messages.GetScheduled":       "schedules",
+	"messages.StopScheduled":      "schedules",
+	"redirecturis":                "redirect-uris",
+	"smartcompose.ComposeReply":   "messages",
+	"scheduler.CreateSession":     "sessions",
+	"scheduler.ListBookings":      "bookings",
+	"scheduler.GetBooking":        "bookings",
+	"scheduler.CreateBooking":     "bookings",
+	"scheduler.ConfirmBooking":    "bookings",
+	"scheduler.RescheduleBooking": "bookings",
+	"scheduler.CancelBooking":     "bookings",
+	"scheduler":                   "configurations",
+}
+
+// newOpError wraps err with the operation that produced it.
+func newOpError(op, grantID, resourceID string, err error) error {
+	service, _, _ := strings.Cut(op, ".")
+	resource, ok := opResources[op]
+	if !ok {
+		resource, ok = opResources[service]
+	}
+	if !ok {
+		resource = service
+	}
+	return &OpError{Op: op, Resource: resource, GrantID: grantID, ResourceID: resourceID, Err: err}
+}
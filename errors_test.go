@@ -73,3 +73,37 @@ func TestSentinelErrors(t *testing.T) {
 		t.Error("ErrRateLimited message incorrect")
 	}
 }
+
+func TestOpError(t *testing.T) {
+	apiErr := &APIError{StatusCode: 404, Message: "not found"}
+	tests := []struct {
+		name         string
+		err          error
+		wantString   string
+		wantResource string
+	}{
+		{"with ID", newOpError("messages.Get", "grant-1", "msg-1", apiErr), "messages.Get(msg-1): nylas: not found (status=404)", "messages"},
+		{"without ID", newOpError("messages.List", "grant-1", "", apiErr), "messages.List: nylas: not found (status=404)", "messages"},
+		{"op override", newOpError("scheduler.GetBooking", "", "b-1", apiErr), "scheduler.GetBooking(b-1): nylas: not found (status=404)", "bookings"},
+		{"service override", newOpError("scheduler.GetConfiguration", "grant-1", "c-1", apiErr), "scheduler.GetConfiguration(c-1): nylas: not found (status=404)", "configurations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.wantString {
+				t.Errorf("Error() = %q, want %q", got, tt.wantString)
+			}
+			var opErr *OpError
+			if !errors.As(tt.err, &opErr) || opErr.Resource != tt.wantResource {
+				t.Errorf("Resource = %q, want %q", opErr.Resource, tt.wantResource)
+			}
+			if !errors.Is(tt.err, ErrNotFound) {
+				t.Error("errors.Is(err, ErrNotFound) = false")
+			}
+			var gotAPI *APIError
+			if !errors.As(tt.err, &gotAPI) || gotAPI != apiErr {
+				t.Error("errors.As(err, *APIError) failed")
+			}
+		})
+	}
+}
@@ -25,9 +25,13 @@ import (
 func (s *EventsService) List(ctx context.Context, grantID string, opts *events.ListOptions) (*ListResponse[events.Event], error) {
 	path := fmt.Sprintf("/v3/grants/%s/events", grantID)
 
+	if err := s.client.validate(opts); err != nil {
+		return nil, newOpError("events.List", grantID, "", err)
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("events.List: %w", err)
+		return nil, newOpError("events.List", grantID, "", err)
 	}
 
 	if opts != nil {
@@ -39,7 +43,7 @@ func (s *EventsService) List(ctx context.Context, grantID string, opts *events.L
 	var data []events.Event
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("events.List: %w", err)
+		return nil, newOpError("events.List", grantID, "", err)
 	}
 
 	return &ListResponse[events.Event]{
@@ -55,7 +59,7 @@ func (s *EventsService) Get(ctx context.Context, grantID, eventID string, calend
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("events.Get(%s): %w", eventID, err)
+		return nil, newOpError("events.Get", grantID, eventID, err)
 	}
 
 	if calendarID != "" {
@@ -67,7 +71,7 @@ func (s *EventsService) Get(ctx context.Context, grantID, eventID string, calend
 	var event events.Event
 	_, err = s.client.Do(req, &event)
 	if err != nil {
-		return nil, fmt.Errorf("events.Get(%s): %w", eventID, err)
+		return nil, newOpError("events.Get", grantID, eventID, err)
 	}
 
 	return &event, nil
@@ -116,12 +120,20 @@ func (s *EventsService) Get(ctx context.Context, grantID, eventID string, calend
 //	        RRule: []string{"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
 //	    },
 //	})
+//
+// If the client has a SendPolicy and the event has participants, the policy is
+// enforced before the request is made.
 func (s *EventsService) Create(ctx context.Context, grantID, calendarID string, create *events.CreateRequest) (*events.Event, error) {
 	path := fmt.Sprintf("/v3/grants/%s/events", grantID)
 
+	create, err := s.client.applyEventPolicy(ctx, grantID, create)
+	if err != nil {
+		return nil, newOpError("events.Create", grantID, "", err)
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, create)
 	if err != nil {
-		return nil, fmt.Errorf("events.Create: %w", err)
+		return nil, newOpError("events.Create", grantID, "", err)
 	}
 
 	q := req.URL.Query()
@@ -131,7 +143,7 @@ func (s *EventsService) Create(ctx context.Context, grantID, calendarID string,
 	var event events.Event
 	_, err = s.client.Do(req, &event)
 	if err != nil {
-		return nil, fmt.Errorf("events.Create: %w", err)
+		return nil, newOpError("events.Create", grantID, "", err)
 	}
 
 	return &event, nil
@@ -163,7 +175,7 @@ func (s *EventsService) Update(ctx context.Context, grantID, eventID, calendarID
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("events.Update(%s): %w", eventID, err)
+		return nil, newOpError("events.Update", grantID, eventID, err)
 	}
 
 	q := req.URL.Query()
@@ -173,7 +185,7 @@ func (s *EventsService) Update(ctx context.Context, grantID, eventID, calendarID
 	var event events.Event
 	_, err = s.client.Do(req, &event)
 	if err != nil {
-		return nil, fmt.Errorf("events.Update(%s): %w", eventID, err)
+		return nil, newOpError("events.Update", grantID, eventID, err)
 	}
 
 	return &event, nil
@@ -185,7 +197,7 @@ func (s *EventsService) Delete(ctx context.Context, grantID, eventID, calendarID
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("events.Delete(%s): %w", eventID, err)
+		return newOpError("events.Delete", grantID, eventID, err)
 	}
 
 	q := req.URL.Query()
@@ -194,7 +206,7 @@ func (s *EventsService) Delete(ctx context.Context, grantID, eventID, calendarID
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("events.Delete(%s): %w", eventID, err)
+		return newOpError("events.Delete", grantID, eventID, err)
 	}
 
 	return nil
@@ -238,7 +250,7 @@ func (s *EventsService) SendRSVP(ctx context.Context, grantID, eventID, calendar
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, rsvp)
 	if err != nil {
-		return fmt.Errorf("events.SendRSVP(%s): %w", eventID, err)
+		return newOpError("events.SendRSVP", grantID, eventID, err)
 	}
 
 	q := req.URL.Query()
@@ -247,7 +259,7 @@ func (s *EventsService) SendRSVP(ctx context.Context, grantID, eventID, calendar
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("events.SendRSVP(%s): %w", eventID, err)
+		return newOpError("events.SendRSVP", grantID, eventID, err)
 	}
 
 	return nil
@@ -259,9 +271,13 @@ func (s *EventsService) SendRSVP(ctx context.Context, grantID, eventID, calendar
 func (s *EventsService) Import(ctx context.Context, grantID string, opts *events.ImportOptions) (*ListResponse[events.Event], error) {
 	path := fmt.Sprintf("/v3/grants/%s/events/import", grantID)
 
+	if err := s.client.validate(opts); err != nil {
+		return nil, newOpError("events.Import", grantID, "", err)
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("events.Import: %w", err)
+		return nil, newOpError("events.Import", grantID, "", err)
 	}
 
 	if opts != nil {
@@ -273,7 +289,7 @@ func (s *EventsService) Import(ctx context.Context, grantID string, opts *events
 	var data []events.Event
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("events.Import: %w", err)
+		return nil, newOpError("events.Import", grantID, "", err)
 	}
 
 	return &ListResponse[events.Event]{
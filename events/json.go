@@ -0,0 +1,73 @@
+package events
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes e, keeping fields this package does not declare in Extra.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type alias Event
+	extra, err := common.UnmarshalExtra(data, (*alias)(e))
+	e.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes e together with the fields kept in Extra.
+func (e Event) MarshalJSON() ([]byte, error) {
+	type alias Event
+	return common.MarshalExtra(alias(e), e.Extra)
+}
+
+// UnmarshalJSON decodes w, keeping fields this package does not declare in Extra.
+func (w *When) UnmarshalJSON(data []byte) error {
+	type alias When
+	extra, err := common.UnmarshalExtra(data, (*alias)(w))
+	w.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes w together with the fields kept in Extra.
+func (w When) MarshalJSON() ([]byte, error) {
+	type alias When
+	return common.MarshalExtra(alias(w), w.Extra)
+}
+
+// UnmarshalJSON decodes p, keeping fields this package does not declare in Extra.
+func (p *Participant) UnmarshalJSON(data []byte) error {
+	type alias Participant
+	extra, err := common.UnmarshalExtra(data, (*alias)(p))
+	p.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes p together with the fields kept in Extra.
+func (p Participant) MarshalJSON() ([]byte, error) {
+	type alias Participant
+	return common.MarshalExtra(alias(p), p.Extra)
+}
+
+// UnmarshalJSON decodes c, keeping fields this package does not declare in Extra.
+func (c *Conferencing) UnmarshalJSON(data []byte) error {
+	type alias Conferencing
+	extra, err := common.UnmarshalExtra(data, (*alias)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes c together with the fields kept in Extra.
+func (c Conferencing) MarshalJSON() ([]byte, error) {
+	type alias Conferencing
+	return common.MarshalExtra(alias(c), c.Extra)
+}
+
+// UnmarshalJSON decodes r, keeping fields this package does not declare in Extra.
+func (r *UpdateRequest) UnmarshalJSON(data []byte) error {
+	type alias UpdateRequest
+	extra, err := common.UnmarshalExtra(data, (*alias)(r))
+	r.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes r together with the fields kept in Extra.
+func (r UpdateRequest) MarshalJSON() ([]byte, error) {
+	type alias UpdateRequest
+	return common.MarshalExtra(alias(r), r.Extra)
+}
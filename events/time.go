@@ -0,0 +1,104 @@
+package events
+
+import (
+	"time"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// NewTimespan returns a When for an event from start to end. The IANA timezones
+// are taken from the times' locations unless they are time.Local.
+//
+// Example:
+//
+//	loc, _ := time.LoadLocation("America/New_York")
+//	start := time.Date(2025, 3, 10, 9, 0, 0, 0, loc)
+//	req := &events.CreateRequest{Title: "Standup", When: events.NewTimespan(start, start.Add(15*time.Minute))}
+func NewTimespan(start, end time.Time) When {
+	return When{
+		Object:        "timespan",
+		StartTime:     common.UnixPtr(start),
+		EndTime:       common.UnixPtr(end),
+		StartTimezone: timezone(start),
+		EndTimezone:   timezone(end),
+	}
+}
+
+// NewDatespan returns a When for an all-day event spanning the dates of start through end.
+func NewDatespan(start, end time.Time) When {
+	return When{
+		Object:    "datespan",
+		StartDate: start.Format(time.DateOnly),
+		EndDate:   end.Format(time.DateOnly),
+	}
+}
+
+// NewDate returns a When for an all-day event on the date of d.
+func NewDate(d time.Time) When {
+	return When{Object: "date", Date: d.Format(time.DateOnly)}
+}
+
+// NewTime returns a When for an event at a single point in time.
+func NewTime(t time.Time) When {
+	return When{Object: "time", Time: common.UnixPtr(t), Timezone: timezone(t)}
+}
+
+// NewReminder returns a reminder sent d before the event, truncated to whole minutes.
+func NewReminder(d time.Duration, method string) ReminderOverride {
+	return ReminderOverride{ReminderMinutes: common.Minutes(d), ReminderMethod: method}
+}
+
+// SetStart filters events starting on or after t.
+func (o *ListOptions) SetStart(t time.Time) *ListOptions {
+	o.Start = common.UnixPtr(t)
+	return o
+}
+
+// SetEnd filters events starting before t.
+func (o *ListOptions) SetEnd(t time.Time) *ListOptions {
+	o.End = common.UnixPtr(t)
+	return o
+}
+
+// SetRange filters events starting on or after start and before end.
+func (o *ListOptions) SetRange(start, end time.Time) *ListOptions {
+	return o.SetStart(start).SetEnd(end)
+}
+
+// SetUpdatedAfter filters events updated after t.
+func (o *ListOptions) SetUpdatedAfter(t time.Time) *ListOptions {
+	o.UpdatedAfter = common.UnixPtr(t)
+	return o
+}
+
+// SetUpdatedBefore filters events updated before t.
+func (o *ListOptions) SetUpdatedBefore(t time.Time) *ListOptions {
+	o.UpdatedBefore = common.UnixPtr(t)
+	return o
+}
+
+// SetStart filters imported events starting on or after t.
+func (o *ImportOptions) SetStart(t time.Time) *ImportOptions {
+	o.Start = common.UnixPtr(t)
+	return o
+}
+
+// SetEnd filters imported events starting before t.
+func (o *ImportOptions) SetEnd(t time.Time) *ImportOptions {
+	o.End = common.UnixPtr(t)
+	return o
+}
+
+// SetRange filters imported events starting on or after start and before end.
+func (o *ImportOptions) SetRange(start, end time.Time) *ImportOptions {
+	return o.SetStart(start).SetEnd(end)
+}
+
+// timezone returns the IANA name of t's location, or "" for time.Local,
+// which has no portable name.
+func timezone(t time.Time) string {
+	if t.Location() == time.Local {
+		return ""
+	}
+	return t.Location().String()
+}
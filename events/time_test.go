@@ -0,0 +1,65 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWhenConstructors(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, loc)
+	end := start.Add(30 * time.Minute)
+
+	span := NewTimespan(start, end)
+	if *span.StartTime != start.Unix() || *span.EndTime != end.Unix() || span.StartTimezone != "America/New_York" || span.Object != "timespan" {
+		t.Errorf("NewTimespan() = %+v", span)
+	}
+	if err := span.Validate(); err != nil {
+		t.Errorf("NewTimespan() Validate() error = %v", err)
+	}
+
+	if local := NewTimespan(start.In(time.Local), end.In(time.Local)); local.StartTimezone != "" {
+		t.Errorf("NewTimespan() with time.Local timezone = %q, want empty", local.StartTimezone)
+	}
+
+	days := NewDatespan(start, start.AddDate(0, 0, 2))
+	if days.StartDate != "2025-03-10" || days.EndDate != "2025-03-12" || days.Validate() != nil {
+		t.Errorf("NewDatespan() = %+v", days)
+	}
+	if d := NewDate(start); d.Date != "2025-03-10" || d.Validate() != nil {
+		t.Errorf("NewDate() = %+v", d)
+	}
+	if pt := NewTime(start); *pt.Time != start.Unix() || pt.Timezone != "America/New_York" || pt.Validate() != nil {
+		t.Errorf("NewTime() = %+v", pt)
+	}
+	if r := NewReminder(90*time.Minute, "email"); r.ReminderMinutes != 90 || r.ReminderMethod != "email" {
+		t.Errorf("NewReminder() = %+v", r)
+	}
+}
+
+func TestEventOptions_TimeSetters(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	end := start.Add(7 * 24 * time.Hour)
+
+	opts := (&ListOptions{}).SetRange(start, end).SetUpdatedAfter(start).SetUpdatedBefore(end)
+	if *opts.Start != start.Unix() || *opts.End != end.Unix() || *opts.UpdatedAfter != start.Unix() || *opts.UpdatedBefore != end.Unix() {
+		t.Errorf("ListOptions = %+v", opts)
+	}
+	if err := opts.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := (&ListOptions{}).SetRange(end, start).Validate(); err == nil {
+		t.Error("Validate() reversed range error = nil")
+	}
+
+	imp := (&ImportOptions{CalendarID: "primary"}).SetRange(start, end)
+	if *imp.Start != start.Unix() || *imp.End != end.Unix() || imp.Validate() != nil {
+		t.Errorf("ImportOptions = %+v", imp)
+	}
+	if err := (&ImportOptions{}).Validate(); err == nil {
+		t.Error("Validate() without calendar_id error = nil")
+	}
+}
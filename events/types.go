@@ -1,6 +1,11 @@
 package events
 
-import "time"
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"time"
+)
 
 // Event represents a calendar event in the Nylas API.
 type Event struct {
@@ -66,6 +71,9 @@ type Event struct {
 	Resources []Resource `json:"resources,omitempty"`
 	// ColorID is the provider-specific color identifier.
 	ColorID string `json:"color_id,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // When represents the time information for an event.
@@ -92,6 +100,9 @@ type When struct {
 	Time *int64 `json:"time,omitempty"`
 	// Timezone is the IANA timezone for Date or Time fields.
 	Timezone string `json:"timezone,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Participant represents an event participant/attendee.
@@ -106,6 +117,9 @@ type Participant struct {
 	Comment string `json:"comment,omitempty"`
 	// PhoneNumber is the participant's phone number (Microsoft only).
 	PhoneNumber string `json:"phone_number,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Organizer represents the event organizer or creator.
@@ -124,6 +138,9 @@ type Conferencing struct {
 	Details *ConferencingDetails `json:"details,omitempty"`
 	// Autocreate configures automatic conference creation.
 	Autocreate *AutocreateConfig `json:"autocreate,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ConferencingDetails contains the video meeting access information.
@@ -357,6 +374,51 @@ type UpdateRequest struct {
 	Resources []Resource `json:"resources,omitempty"`
 	// ColorID sets a provider-specific color.
 	ColorID *string `json:"color_id,omitempty"`
+	// Extra holds fields this package does not declare, such as those kept from
+	// an Event by NewUpdateRequest. They are sent along with the update.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// NewUpdateRequest returns an UpdateRequest that writes back the editable fields
+// of e, including the fields kept in its Extra maps, for a read-modify-write
+// update. Change the fields to update before sending it.
+//
+// Example:
+//
+//	event, err := client.Events.Get(ctx, grantID, eventID, calendarID)
+//	if err != nil {
+//	    return err
+//	}
+//	update := events.NewUpdateRequest(event)
+//	update.Title = nylas.Ptr("Rescheduled: " + event.Title)
+//	event, err = client.Events.Update(ctx, grantID, eventID, calendarID, update)
+func NewUpdateRequest(e *Event) *UpdateRequest {
+	c := *e
+	r := &UpdateRequest{
+		Title:            &c.Title,
+		Description:      &c.Description,
+		Location:         &c.Location,
+		When:             &c.When,
+		Participants:     slices.Clone(c.Participants),
+		Busy:             &c.Busy,
+		Visibility:       &c.Visibility,
+		Conferencing:     c.Conferencing,
+		Reminders:        c.Reminders,
+		Metadata:         maps.Clone(c.Metadata),
+		Capacity:         c.Capacity,
+		HideParticipants: &c.HideParticipants,
+		Resources:        slices.Clone(c.Resources),
+		ColorID:          &c.ColorID,
+		Extra:            maps.Clone(c.Extra),
+	}
+	if c.Recurrence != nil {
+		for _, rule := range []string{c.Recurrence.RRule, c.Recurrence.Exdate} {
+			if rule != "" {
+				r.Recurrence = append(r.Recurrence, rule)
+			}
+		}
+	}
+	return r
 }
 
 // RSVPRequest represents a request to send an RSVP response to an event invitation.
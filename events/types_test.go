@@ -1,6 +1,9 @@
 package events
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+)
 
 func TestListOptions_Values(t *testing.T) {
 	tests := []struct {
@@ -269,6 +272,58 @@ func TestEvent_UpdatedDateTime(t *testing.T) {
 	}
 }
 
+func TestNewUpdateRequest_KeepsUnknownFields(t *testing.T) {
+	data := `{
+		"id": "event-1",
+		"title": "Sync",
+		"when": {"start_time": 100, "end_time": 200, "object": "timespan", "start_locale": "en-US"},
+		"participants": [{"email": "a@example.com", "status": "yes", "response_time": 42}],
+		"conferencing": {"provider": "Zoom Meeting", "conference_tier": "pro"},
+		"recurrence": {"rrule": "RRULE:FREQ=WEEKLY"},
+		"transparency": "opaque"
+	}`
+	var event Event
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	update := NewUpdateRequest(&event)
+	update.Title = ptr("Sync (moved)")
+	if event.Title != "Sync" {
+		t.Errorf("event Title = %q, changed through the update", event.Title)
+	}
+
+	b, err := json.Marshal(update)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got struct {
+		Title        string            `json:"title"`
+		Transparency string            `json:"transparency"`
+		Recurrence   []string          `json:"recurrence"`
+		When         map[string]any    `json:"when"`
+		Participants []map[string]any  `json:"participants"`
+		Conferencing map[string]string `json:"conferencing"`
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", b, err)
+	}
+	if got.Title != "Sync (moved)" || got.Transparency != "opaque" || len(got.Recurrence) != 1 ||
+		got.When["start_locale"] != "en-US" || got.Participants[0]["response_time"] != float64(42) ||
+		got.Conferencing["conference_tier"] != "pro" {
+		t.Errorf("Marshal() = %s, unknown fields lost", b)
+	}
+
+	var decoded UpdateRequest
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	again, err := json.Marshal(decoded)
+	if err != nil || string(again) != string(b) {
+		t.Errorf("round trip = %s, %v, want %s", again, err, b)
+	}
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
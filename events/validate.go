@@ -0,0 +1,161 @@
+package events
+
+import (
+	"strings"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// Validate checks that the When uses exactly one of its four forms:
+// a timespan (StartTime and EndTime), a datespan (StartDate and EndDate),
+// a single Date, or a single Time. If Object is set it must match the form used.
+func (w *When) Validate() error {
+	v := &common.ValidationError{}
+
+	var forms []string
+	if w.StartTime != nil || w.EndTime != nil {
+		forms = append(forms, "timespan")
+	}
+	if w.StartDate != "" || w.EndDate != "" {
+		forms = append(forms, "datespan")
+	}
+	if w.Date != "" {
+		forms = append(forms, "date")
+	}
+	if w.Time != nil {
+		forms = append(forms, "time")
+	}
+
+	switch len(forms) {
+	case 0:
+		v.Add("", "one of start_time/end_time, start_date/end_date, date or time is required")
+		return v.Err()
+	case 1:
+	default:
+		v.Add("", "mixes %s fields; use only one form", strings.Join(forms, " and "))
+		return v.Err()
+	}
+
+	form := forms[0]
+	if w.Object != "" && w.Object != form {
+		v.Add("object", "%q does not match the %s fields that are set", w.Object, form)
+	}
+
+	switch form {
+	case "timespan":
+		var start, end int64
+		if w.StartTime != nil {
+			start = *w.StartTime
+		}
+		if w.EndTime != nil {
+			end = *w.EndTime
+		}
+		common.CheckTimeRange(v, "start_time", start, "end_time", end)
+	case "datespan":
+		checkDate(v, "start_date", w.StartDate)
+		checkDate(v, "end_date", w.EndDate)
+		if common.ValidDate(w.StartDate) && common.ValidDate(w.EndDate) && w.EndDate < w.StartDate {
+			v.Add("end_date", "must not be before start_date")
+		}
+	case "date":
+		checkDate(v, "date", w.Date)
+	case "time":
+		common.CheckUnixTime(v, "time", *w.Time)
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *CreateRequest) Validate() error {
+	v := &common.ValidationError{}
+	v.Merge("when", r.When.Validate())
+	checkParticipants(v, r.Participants)
+	if r.Visibility != "" {
+		common.CheckOneOf(v, "visibility", r.Visibility, "public", "private", "default")
+	}
+	if r.Capacity != nil && *r.Capacity < 0 {
+		v.Add("capacity", "must not be negative")
+	}
+	checkReminders(v, r.Reminders)
+	checkResources(v, r.Resources)
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.When != nil {
+		v.Merge("when", r.When.Validate())
+	}
+	checkParticipants(v, r.Participants)
+	if r.Visibility != nil {
+		common.CheckOneOf(v, "visibility", *r.Visibility, "public", "private", "default")
+	}
+	if r.Capacity != nil && *r.Capacity < 0 {
+		v.Add("capacity", "must not be negative")
+	}
+	checkReminders(v, r.Reminders)
+	checkResources(v, r.Resources)
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *RSVPRequest) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckOneOf(v, "status", r.Status, "yes", "no", "maybe")
+	return v.Err()
+}
+
+// Validate checks that the time filters are Unix timestamps in seconds.
+func (o *ListOptions) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckOptionalTimeRange(v, "start", o.Start, "end", o.End)
+	common.CheckOptionalTimeRange(v, "updated_after", o.UpdatedAfter, "updated_before", o.UpdatedBefore)
+	return v.Err()
+}
+
+// Validate checks that CalendarID is set and the time filters are Unix timestamps in seconds.
+func (o *ImportOptions) Validate() error {
+	v := &common.ValidationError{}
+	if o.CalendarID == "" {
+		v.Add("calendar_id", "is required")
+	}
+	common.CheckOptionalTimeRange(v, "start", o.Start, "end", o.End)
+	return v.Err()
+}
+
+func checkDate(v *common.ValidationError, path, s string) {
+	switch {
+	case s == "":
+		v.Add(path, "is required")
+	case !common.ValidDate(s):
+		v.Add(path, "%q is not a YYYY-MM-DD date", s)
+	}
+}
+
+func checkParticipants(v *common.ValidationError, list []Participant) {
+	for i, p := range list {
+		path := common.IndexPath("participants", i)
+		common.CheckEmail(v, common.JoinPath(path, "email"), p.Email)
+		if p.Status != "" {
+			common.CheckOneOf(v, common.JoinPath(path, "status"), p.Status, "yes", "no", "maybe", "noreply")
+		}
+	}
+}
+
+func checkReminders(v *common.ValidationError, r *Reminders) {
+	if r == nil {
+		return
+	}
+	for i, o := range r.Overrides {
+		if o.ReminderMinutes < 0 {
+			v.Add(common.JoinPath(common.IndexPath("reminders.overrides", i), "reminder_minutes"), "must not be negative")
+		}
+	}
+}
+
+func checkResources(v *common.ValidationError, list []Resource) {
+	for i, res := range list {
+		common.CheckEmail(v, common.JoinPath(common.IndexPath("resources", i), "email"), res.Email)
+	}
+}
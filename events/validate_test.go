@@ -0,0 +1,95 @@
+package events
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func TestWhen_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		when When
+		want []string
+	}{
+		{"timespan", When{StartTime: int64Ptr(100), EndTime: int64Ptr(200)}, nil},
+		{"datespan", When{StartDate: "2024-01-01", EndDate: "2024-01-03"}, nil},
+		{"date", When{Object: "date", Date: "2024-01-01"}, nil},
+		{"time", When{Time: int64Ptr(100)}, nil},
+		{"empty", When{}, []string{""}},
+		{"mixed start_time and date", When{StartTime: int64Ptr(100), Date: "2024-01-01"}, []string{""}},
+		{"end before start", When{StartTime: int64Ptr(200), EndTime: int64Ptr(100)}, []string{"end_time"}},
+		{"missing end", When{StartTime: int64Ptr(100)}, []string{"end_time"}},
+		{"bad dates", When{StartDate: "2024-01-05", EndDate: "01/01/2024"}, []string{"end_date"}},
+		{"end date before start", When{StartDate: "2024-01-05", EndDate: "2024-01-01"}, []string{"end_date"}},
+		{"object mismatch", When{Object: "datespan", Date: "2024-01-01"}, []string{"object"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldPaths(t, tt.when.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() paths = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateRequest_Validate(t *testing.T) {
+	req := &CreateRequest{
+		When:         When{StartTime: int64Ptr(100), Date: "2024-01-01"},
+		Participants: []Participant{{Email: "ok@example.com"}, {Email: "bad", Status: "sure"}},
+		Visibility:   "secret",
+		Reminders:    &Reminders{Overrides: []ReminderOverride{{ReminderMinutes: -5}}},
+		Resources:    []Resource{{Name: "Room"}},
+	}
+	want := []string{"when", "participants[1].email", "participants[1].status", "visibility", "reminders.overrides[0].reminder_minutes", "resources[0].email"}
+	if got := fieldPaths(t, req.Validate()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() paths = %q, want %q", got, want)
+	}
+
+	valid := &CreateRequest{Title: "Sync", When: When{StartTime: int64Ptr(100), EndTime: int64Ptr(200)}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestUpdateRequest_Validate(t *testing.T) {
+	if err := (&UpdateRequest{}).Validate(); err != nil {
+		t.Errorf("empty update Validate() error = %v", err)
+	}
+	bad := "hidden"
+	req := &UpdateRequest{When: &When{EndTime: int64Ptr(100)}, Visibility: &bad}
+	want := []string{"when.start_time", "visibility"}
+	if got := fieldPaths(t, req.Validate()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() paths = %q, want %q", got, want)
+	}
+}
+
+func TestRSVPRequest_Validate(t *testing.T) {
+	if err := (&RSVPRequest{Status: "yes"}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := (&RSVPRequest{Status: "accept"}).Validate(); !errors.Is(err, common.ErrValidation) {
+		t.Errorf("Validate() error = %v, want ErrValidation", err)
+	}
+}
+
+func fieldPaths(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verr *common.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *common.ValidationError", err)
+	}
+	paths := make([]string, len(verr.Fields))
+	for i, f := range verr.Fields {
+		paths[i] = f.Path
+	}
+	return paths
+}
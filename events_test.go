@@ -3,6 +3,7 @@ package nylas
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -417,7 +418,18 @@ func TestEventsService_UpdateErrors(t *testing.T) {
 		Title: Ptr("Updated"),
 	})
 	if err == nil {
-		t.Error("Update() expected error for missing event")
+		t.Fatal("Update() expected error for missing event")
+	}
+
+	var opErr *OpError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("Update() error = %T, want *OpError", err)
+	}
+	if opErr.Op != "events.Update" || opErr.Resource != "events" || opErr.GrantID != "grant-123" || opErr.ResourceID != "event-missing" {
+		t.Errorf("OpError = %+v", opErr)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is(err, ErrNotFound) = false")
 	}
 }
 
@@ -0,0 +1,22 @@
+// Package export archives the messages of a grant as mbox, Maildir or a zip of .eml
+// files.
+//
+// Run lists the messages of a grant, or of a folder or query, fetches each one as
+// raw MIME and writes it under its folder path, built from the grant's folders.
+// A JSON Manifest records where every message went, its checksum and attachments,
+// and any message that could not be exported. Exports are journaled, so an
+// interrupted export resumes where it stopped, and they wait out the rate limit
+// instead of failing.
+//
+// Example:
+//
+//	m, err := export.Run(ctx, client, grantID, "/backups/alice", &export.Options{
+//	    Format: export.Maildir,
+//	    OnMessage: func(e export.Entry) {
+//	        log.Printf("%s -> %s", e.Subject, e.Path)
+//	    },
+//	})
+//	if err != nil {
+//	    log.Fatal(err) // run again to resume
+//	}
+package export
@@ -0,0 +1,366 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	nylas "github.com/mqasimca/nylas-go"
+	"github.com/mqasimca/nylas-go/folders"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// Format is the archive format of an export.
+type Format string
+
+const (
+	// Mbox writes one mboxrd file per folder, named after the folder path with a
+	// ".mbox" extension.
+	Mbox Format = "mbox"
+	// Maildir writes a Maildir++ tree: the inbox at the root and every other folder
+	// in a ".Parent.Child" subdirectory.
+	Maildir Format = "maildir"
+	// EML writes a zip file with one .eml file per message, in a directory per folder.
+	EML Format = "eml"
+)
+
+// DefaultRateLimitRetries is how many times a request that hit the rate limit is
+// retried when Options.RateLimitRetries is zero.
+const DefaultRateLimitRetries = 10
+
+// maxRateLimitPause caps the pause after hitting the rate limit.
+const maxRateLimitPause = time.Minute
+
+// rateLimitBackoff is the first pause after hitting the rate limit when the API does
+// not say when the limit resets. It doubles with each retry.
+var rateLimitBackoff = time.Second
+
+// pageSize is the number of messages listed per page when the query sets no limit.
+const pageSize = 200
+
+// Options configures Run.
+type Options struct {
+	// Format is the archive format. Defaults to Mbox.
+	Format Format
+	// Query selects the messages to export, e.g. a folder with In or a search with
+	// SearchQueryNative. Nil exports every message of the grant. PageToken is
+	// ignored and the query is not modified.
+	Query *messages.ListOptions
+	// RateLimitRetries is how many times a request that hit the rate limit is
+	// retried before the export stops. Zero uses DefaultRateLimitRetries; a negative
+	// value disables retries.
+	RateLimitRetries int
+	// OnMessage, if set, is called after each message is written.
+	OnMessage func(Entry)
+}
+
+// Run exports the messages of a grant to dst and returns the manifest.
+//
+// For Mbox and Maildir dst is a directory; for EML it is the path of the zip file,
+// and the messages are staged in dst + ".partial" until the export is complete.
+// The manifest is written to dst as ManifestName, or into the zip.
+//
+// Each message is fetched as raw MIME. If the provider cannot return it, the
+// message is rebuilt from its fields and attachments, and Entry.Source says so.
+// Messages that cannot be fetched at all are listed in Manifest.Failed and the
+// export carries on. A request still rate limited after RateLimitRetries stops the
+// export with ErrRateLimited instead, so that no message is recorded as failed
+// only because the limit was hit.
+//
+// Progress is journaled in the export directory. If Run returns an error, calling
+// it again with the same grant, dst and format resumes where it stopped. Before each
+// request Run waits for the rate limit to reset if no requests remain, and it pauses
+// and retries requests that hit the limit anyway.
+//
+// Example:
+//
+//	m, err := export.Run(ctx, client, grantID, "backup.zip", &export.Options{
+//	    Format: export.EML,
+//	    Query:  &messages.ListOptions{In: nylas.Ptr(folderID)},
+//	})
+//	log.Printf("exported %d messages, %d failed", len(m.Messages), len(m.Failed))
+func Run(ctx context.Context, client *nylas.Client, grantID, dst string, opts *Options) (*Manifest, error) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	switch o.Format {
+	case "":
+		o.Format = Mbox
+	case Mbox, Maildir, EML:
+	default:
+		return nil, fmt.Errorf("export: unknown format %q", o.Format)
+	}
+	if o.RateLimitRetries == 0 {
+		o.RateLimitRetries = DefaultRateLimitRetries
+	}
+
+	dir := workDir(o.Format, dst)
+	j, err := openJournal(dir, grantID, o.Format)
+	if err != nil {
+		return nil, err
+	}
+	e := &exporter{
+		client:  client,
+		grantID: grantID,
+		opts:    o,
+		journal: j,
+		writer:  newWriter(o.Format, dst, j.manifest.Messages),
+	}
+	if err := e.run(ctx); err != nil {
+		e.writer.close()
+		j.close()
+		return nil, err
+	}
+
+	m := j.manifest
+	m.CompletedAt = time.Now().UTC()
+	if err := j.close(); err != nil {
+		return nil, err
+	}
+	if err := e.writer.finish(&m); err != nil {
+		return nil, err
+	}
+	if err := removeJournal(dir); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// workDir returns the directory that holds the journal and the files of an export
+// in progress.
+func workDir(format Format, dst string) string {
+	if format == EML {
+		return dst + ".partial"
+	}
+	return dst
+}
+
+type exporter struct {
+	client  *nylas.Client
+	grantID string
+	opts    Options
+	journal *journal
+	writer  writer
+	// folders maps folder IDs to folder paths.
+	folders map[string]string
+}
+
+func (e *exporter) run(ctx context.Context) error {
+	if err := e.loadFolders(ctx); err != nil {
+		return err
+	}
+	e.journal.manifest.Folders = e.folders
+
+	var q messages.ListOptions
+	if e.opts.Query != nil {
+		q = *e.opts.Query
+	}
+	q.PageToken = ""
+	if q.Limit == nil {
+		limit := pageSize
+		q.Limit = &limit
+	}
+	if q.Fields == nil {
+		q.SetIncludeHeaders()
+	}
+
+	// Pages are listed one at a time rather than with ListAll so that a page that hit
+	// the rate limit can be retried.
+	for {
+		var page *nylas.ListResponse[messages.Message]
+		err := e.call(ctx, func() (err error) {
+			page, err = e.client.Messages.List(ctx, e.grantID, &q)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for i := range page.Data {
+			if e.journal.done[page.Data[i].ID] {
+				continue
+			}
+			if err := e.export(ctx, &page.Data[i]); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		q.PageToken = page.NextCursor
+	}
+}
+
+// loadFolders maps every folder of the grant to its path, the names from the
+// top-level folder down joined with "/".
+func (e *exporter) loadFolders(ctx context.Context) error {
+	byID := make(map[string]folders.Folder)
+	var opts folders.ListOptions
+	for {
+		var page *nylas.ListResponse[folders.Folder]
+		err := e.call(ctx, func() (err error) {
+			page, err = e.client.Folders.List(ctx, e.grantID, &opts)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, f := range page.Data {
+			byID[f.ID] = f
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		opts.PageToken = page.NextCursor
+	}
+
+	e.folders = make(map[string]string, len(byID))
+	for id, f := range byID {
+		var names []string
+		seen := make(map[string]bool)
+		for ok := true; ok && !seen[f.ID]; f, ok = byID[f.ParentID] {
+			seen[f.ID] = true
+			names = append(names, strings.ReplaceAll(f.Name, "/", "_"))
+		}
+		for i, k := 0, len(names)-1; i < k; i, k = i+1, k-1 {
+			names[i], names[k] = names[k], names[i]
+		}
+		e.folders[id] = strings.Join(names, "/")
+	}
+	return nil
+}
+
+// export writes one message. Errors fetching the message are recorded as failures;
+// only errors writing the export or a done context are returned.
+func (e *exporter) export(ctx context.Context, msg *messages.Message) error {
+	entry := Entry{
+		ID:        msg.ID,
+		ThreadID:  msg.ThreadID,
+		MessageID: msg.MessageID(),
+		Subject:   msg.Subject,
+		Date:      time.Unix(msg.Date, 0).UTC(),
+	}
+	if len(msg.From) > 0 {
+		entry.From = msg.From[0].Email
+	}
+	for _, id := range msg.Folders {
+		if p, ok := e.folders[id]; ok {
+			entry.Folders = append(entry.Folders, p)
+		}
+	}
+	for _, a := range msg.Attachments {
+		entry.Attachments = append(entry.Attachments, Attachment{
+			ID:          a.ID,
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+			Inline:      a.IsInline,
+		})
+	}
+
+	raw, source, err := e.source(ctx, msg)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, nylas.ErrRateLimited) {
+			return fmt.Errorf("export: fetch message %s: %w", msg.ID, err)
+		}
+		e.journal.addFailure(Failure{ID: msg.ID, Error: err.Error()})
+		return nil
+	}
+	sum := sha256.Sum256(raw)
+	entry.Size = int64(len(raw))
+	entry.SHA256 = hex.EncodeToString(sum[:])
+	entry.Source = source
+
+	folder := ""
+	if len(entry.Folders) > 0 {
+		folder = entry.Folders[0]
+	}
+	if err := e.writer.write(&entry, folder, raw, msg); err != nil {
+		return fmt.Errorf("export: write message %s: %w", msg.ID, err)
+	}
+	if err := e.journal.addEntry(entry); err != nil {
+		return err
+	}
+	if e.opts.OnMessage != nil {
+		e.opts.OnMessage(entry)
+	}
+	return nil
+}
+
+// source returns the RFC 5322 source of msg: the raw MIME if the provider returns
+// it, or a message rebuilt from msg otherwise.
+func (e *exporter) source(ctx context.Context, msg *messages.Message) ([]byte, string, error) {
+	var mm *messages.MIMEMessage
+	err := e.call(ctx, func() (err error) {
+		mm, err = e.client.Messages.GetRawMIME(ctx, e.grantID, msg.ID)
+		return err
+	})
+	if err == nil && len(mm.Raw) > 0 {
+		return mm.Raw, SourceRawMIME, nil
+	}
+	if err != nil && (ctx.Err() != nil || errors.Is(err, nylas.ErrNotFound) || errors.Is(err, nylas.ErrRateLimited)) {
+		return nil, "", err
+	}
+
+	raw, err := rebuildMIME(ctx, msg, func(ctx context.Context, a messages.Attachment, buf *bytes.Buffer) error {
+		return e.call(ctx, func() error {
+			buf.Reset()
+			_, err := e.client.Attachments.DownloadTo(ctx, e.grantID, a.ID, msg.ID, buf)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return raw, SourceRebuilt, nil
+}
+
+// call runs fn, first waiting for the rate limit to reset if no requests remain, and
+// retries it when it hits the rate limit.
+func (e *exporter) call(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		if r := e.client.RateLimits(); r.Limit > 0 && r.Remaining == 0 {
+			if err := sleep(ctx, min(time.Until(r.Reset), maxRateLimitPause)); err != nil {
+				return err
+			}
+		}
+		err := fn()
+		if err == nil || !errors.Is(err, nylas.ErrRateLimited) || attempt >= e.opts.RateLimitRetries {
+			return err
+		}
+		if err := sleep(ctx, rateLimitPause(e.client.RateLimits(), attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// rateLimitPause returns how long to wait after hitting the rate limit: until the
+// limit resets if the API said when, or an exponential backoff otherwise.
+func rateLimitPause(r nylas.Rate, attempt int) time.Duration {
+	if d := time.Until(r.Reset); d > 0 {
+		return min(d, maxRateLimitPause)
+	}
+	return min(rateLimitBackoff<<min(attempt, 16), maxRateLimitPause)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,442 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	nylas "github.com/mqasimca/nylas-go"
+)
+
+const testGrant = "grant-1"
+
+// fakeAPI serves the folders, messages and attachments of one grant.
+type fakeAPI struct {
+	mu sync.Mutex
+	// messages are listed two per page.
+	messages []map[string]any
+	// raw holds the raw MIME of each message; messages without one get no raw_mime.
+	raw map[string]string
+	// status makes a path fail with that status once.
+	status map[string]int
+	// requests counts the requests per path.
+	requests map[string]int
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{
+		messages: []map[string]any{
+			{"id": "m1", "thread_id": "t1", "subject": "Hello", "from": []any{map[string]any{"email": "alice@example.com"}}, "date": 1700000000, "folders": []any{"inbox"}},
+			{"id": "m2", "thread_id": "t2", "subject": "Plans", "from": []any{map[string]any{"email": "bob@example.com"}}, "date": 1700000100, "folders": []any{"acme", "inbox"}, "unread": true, "starred": true},
+			{"id": "m3", "thread_id": "t3", "subject": "Notes", "from": []any{map[string]any{"name": "Carol", "email": "carol@example.com"}}, "to": []any{map[string]any{"email": "alice@example.com"}}, "date": 1700000200, "body": "<p>See notes</p>", "attachments": []any{map[string]any{"id": "a1", "filename": "notes.txt", "content_type": "text/plain", "size": 5}}},
+		},
+		raw: map[string]string{
+			"m1": "From: alice@example.com\r\nSubject: Hello\r\n\r\nHi\r\nFrom the team\r\n",
+			"m2": "From: bob@example.com\r\nSubject: Plans\r\n\r\n>From here on\r\n",
+		},
+		status:   map[string]int{},
+		requests: map[string]int{},
+	}
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests[r.URL.Path]++
+	if status, ok := f.status[r.URL.Path]; ok {
+		delete(f.status, r.URL.Path)
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]any{"message": "failed", "type": "api_error"})
+		return
+	}
+
+	prefix := "/v3/grants/" + testGrant
+	switch path := strings.TrimPrefix(r.URL.Path, prefix); {
+	case path == "/folders":
+		writeData(w, []any{
+			map[string]any{"id": "inbox", "name": "INBOX"},
+			map[string]any{"id": "projects", "name": "Projects"},
+			map[string]any{"id": "acme", "name": "Acme", "parent_id": "projects"},
+		}, "")
+	case path == "/messages":
+		start := 0
+		if tok := r.URL.Query().Get("page_token"); tok == "page2" {
+			start = 2
+		}
+		end, next := min(start+2, len(f.messages)), ""
+		if end < len(f.messages) {
+			next = "page2"
+		}
+		writeData(w, f.messages[start:end], next)
+	case strings.HasPrefix(path, "/messages/"):
+		id := strings.TrimPrefix(path, "/messages/")
+		data := map[string]any{"id": id}
+		if raw, ok := f.raw[id]; ok {
+			data["raw_mime"] = base64.URLEncoding.EncodeToString([]byte(raw))
+		}
+		writeData(w, data, "")
+	case path == "/attachments/a1":
+		writeData(w, map[string]any{"id": "a1", "filename": "notes.txt", "content_type": "text/plain", "size": 5}, "")
+	case path == "/attachments/a1/download":
+		io.WriteString(w, "hello")
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeData(w http.ResponseWriter, data any, next string) {
+	body := map[string]any{"request_id": "req", "data": data}
+	if next != "" {
+		body["next_cursor"] = next
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func newTestClient(t *testing.T, api *fakeAPI) *nylas.Client {
+	t.Helper()
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	client, err := nylas.NewClient(nylas.WithAPIKey("test-key"), nylas.WithBaseURL(srv.URL), nylas.WithMaxRetries(0))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	return client
+}
+
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	return string(data)
+}
+
+func TestRun_Formats(t *testing.T) {
+	tests := []struct {
+		format Format
+		dst    string
+		check  func(t *testing.T, dst string, m *Manifest)
+	}{
+		{
+			format: Mbox,
+			dst:    "mbox",
+			check: func(t *testing.T, dst string, m *Manifest) {
+				inbox := readFile(t, filepath.Join(dst, "INBOX.mbox"))
+				want := "From alice@example.com Tue Nov 14 22:13:20 2023\nFrom: alice@example.com\nSubject: Hello\n\nHi\n>From the team\n\n"
+				if inbox != want {
+					t.Errorf("INBOX.mbox = %q, want %q", inbox, want)
+				}
+				acme := readFile(t, filepath.Join(dst, "Projects", "Acme.mbox"))
+				if !strings.Contains(acme, "\n>>From here on\n") {
+					t.Errorf("Acme.mbox = %q, want >From escaped", acme)
+				}
+				e := m.Messages[1]
+				if got := acme[e.Offset : e.Offset+e.Length]; !strings.HasPrefix(got, "From bob@example.com ") {
+					t.Errorf("entry %s locates %q", e.ID, got)
+				}
+			},
+		},
+		{
+			format: Maildir,
+			dst:    "maildir",
+			check: func(t *testing.T, dst string, m *Manifest) {
+				for _, e := range m.Messages {
+					if _, err := os.Stat(filepath.Join(dst, filepath.FromSlash(e.Path))); err != nil {
+						t.Errorf("message %s: %v", e.ID, err)
+					}
+				}
+				if dir, _ := filepath.Split(m.Messages[0].Path); dir != "cur/" {
+					t.Errorf("inbox message path = %q, want under cur/", m.Messages[0].Path)
+				}
+				if p := m.Messages[1].Path; !strings.HasPrefix(p, ".Projects.Acme/cur/") || !strings.HasSuffix(p, ":2,F") {
+					t.Errorf("starred unread message path = %q", p)
+				}
+				if !strings.HasSuffix(m.Messages[0].Path, ":2,S") {
+					t.Errorf("read message path = %q, want S flag", m.Messages[0].Path)
+				}
+			},
+		},
+		{
+			format: EML,
+			dst:    "export.zip",
+			check: func(t *testing.T, dst string, m *Manifest) {
+				zr, err := zip.OpenReader(dst)
+				if err != nil {
+					t.Fatalf("OpenReader error: %v", err)
+				}
+				defer zr.Close()
+				var names []string
+				for _, f := range zr.File {
+					names = append(names, f.Name)
+				}
+				want := "INBOX/m1.eml Projects/Acme/m2.eml Unfiled/m3.eml manifest.json"
+				if got := strings.Join(names, " "); got != want {
+					t.Errorf("zip files = %s, want %s", got, want)
+				}
+				if _, err := os.Stat(dst + ".partial"); !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("staging directory left behind: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			client := newTestClient(t, newFakeAPI())
+			dst := filepath.Join(t.TempDir(), tt.dst)
+			var seen []string
+			m, err := Run(context.Background(), client, testGrant, dst, &Options{
+				Format:    tt.format,
+				OnMessage: func(e Entry) { seen = append(seen, e.ID) },
+			})
+			if err != nil {
+				t.Fatalf("Run error: %v", err)
+			}
+			if got := strings.Join(seen, ","); got != "m1,m2,m3" {
+				t.Errorf("OnMessage saw %s, want m1,m2,m3", got)
+			}
+			if got := m.Messages[1].Folders; len(got) != 2 || got[0] != "Projects/Acme" || got[1] != "INBOX" {
+				t.Errorf("m2 folders = %v", got)
+			}
+			if m.Messages[0].Source != SourceRawMIME || m.Messages[0].Size != int64(len(newFakeAPI().raw["m1"])) {
+				t.Errorf("m1 entry = %+v", m.Messages[0])
+			}
+			if tt.format != EML {
+				var onDisk Manifest
+				if err := json.Unmarshal([]byte(readFile(t, filepath.Join(dst, ManifestName))), &onDisk); err != nil {
+					t.Fatalf("manifest: %v", err)
+				}
+				if len(onDisk.Messages) != 3 || onDisk.Folders["acme"] != "Projects/Acme" {
+					t.Errorf("manifest = %+v", onDisk)
+				}
+				if _, err := os.Stat(filepath.Join(dst, journalName)); !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("journal left behind: %v", err)
+				}
+			}
+			tt.check(t, dst, m)
+		})
+	}
+}
+
+func TestRun_Rebuilt(t *testing.T) {
+	client := newTestClient(t, newFakeAPI())
+	dst := filepath.Join(t.TempDir(), "export.zip")
+	m, err := Run(context.Background(), client, testGrant, dst, &Options{Format: EML})
+	if err != nil {
+		t.Fatalf("Run error: %v", err)
+	}
+
+	e := m.Messages[2]
+	if e.Source != SourceRebuilt || len(e.Attachments) != 1 || e.Attachments[0].Filename != "notes.txt" {
+		t.Fatalf("m3 entry = %+v", e)
+	}
+	zr, err := zip.OpenReader(dst)
+	if err != nil {
+		t.Fatalf("OpenReader error: %v", err)
+	}
+	defer zr.Close()
+	f, err := zr.Open("Unfiled/m3.eml")
+	if err != nil {
+		t.Fatalf("Open error: %v", err)
+	}
+	raw, _ := io.ReadAll(f)
+	for _, want := range []string{
+		"From: \"Carol\" <carol@example.com>\r\n",
+		"Subject: Notes\r\n",
+		"X-Nylas-Message-Id: m3\r\n",
+		"Content-Type: text/html; charset=utf-8",
+		"Content-Disposition: attachment; filename=notes.txt",
+		base64.StdEncoding.EncodeToString([]byte("hello")),
+	} {
+		if !bytes.Contains(raw, []byte(want)) {
+			t.Errorf("rebuilt message lacks %q:\n%s", want, raw)
+		}
+	}
+}
+
+func TestRun_Resume(t *testing.T) {
+	api := newFakeAPI()
+	client := newTestClient(t, api)
+	dst := filepath.Join(t.TempDir(), "mbox")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := Run(ctx, client, testGrant, dst, &Options{OnMessage: func(Entry) { cancel() }})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run error = %v, want context.Canceled", err)
+	}
+
+	// Simulate a crash partway through writing the next message.
+	inbox := filepath.Join(dst, "INBOX.mbox")
+	before := readFile(t, inbox)
+	f, _ := os.OpenFile(inbox, os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString("From half a message")
+	f.Close()
+	f, _ = os.OpenFile(filepath.Join(dst, journalName), os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString(`{"entry":{"id":"m2"`)
+	f.Close()
+
+	var seen []string
+	m, err := Run(context.Background(), client, testGrant, dst, &Options{OnMessage: func(e Entry) { seen = append(seen, e.ID) }})
+	if err != nil {
+		t.Fatalf("resumed Run error: %v", err)
+	}
+	if got := strings.Join(seen, ","); got != "m2,m3" {
+		t.Errorf("resumed run exported %s, want m2,m3", got)
+	}
+	if len(m.Messages) != 3 {
+		t.Errorf("manifest has %d messages, want 3", len(m.Messages))
+	}
+	if got := readFile(t, inbox); got != before {
+		t.Errorf("INBOX.mbox = %q, want %q", got, before)
+	}
+	if n := api.requests["/v3/grants/"+testGrant+"/messages/m1"]; n != 1 {
+		t.Errorf("m1 fetched %d times, want 1", n)
+	}
+}
+
+func TestRun_RateLimitedAndFailed(t *testing.T) {
+	defer func(d time.Duration) { rateLimitBackoff = d }(rateLimitBackoff)
+	rateLimitBackoff = time.Millisecond
+
+	api := newFakeAPI()
+	api.status["/v3/grants/"+testGrant+"/messages"] = http.StatusTooManyRequests
+	api.status["/v3/grants/"+testGrant+"/messages/m1"] = http.StatusTooManyRequests
+	api.status["/v3/grants/"+testGrant+"/messages/m2"] = http.StatusNotFound
+	client := newTestClient(t, api)
+
+	m, err := Run(context.Background(), client, testGrant, t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("Run error: %v", err)
+	}
+	if len(m.Messages) != 2 || m.Messages[0].ID != "m1" || m.Messages[1].ID != "m3" {
+		t.Errorf("messages = %+v, want m1 and m3", m.Messages)
+	}
+	if len(m.Failed) != 1 || m.Failed[0].ID != "m2" {
+		t.Errorf("failed = %+v, want m2", m.Failed)
+	}
+	if n := api.requests["/v3/grants/"+testGrant+"/messages/m1"]; n != 2 {
+		t.Errorf("m1 fetched %d times, want 2", n)
+	}
+
+	api.status["/v3/grants/"+testGrant+"/messages"] = http.StatusTooManyRequests
+	_, err = Run(context.Background(), client, testGrant, t.TempDir(), &Options{RateLimitRetries: -1})
+	if !errors.Is(err, nylas.ErrRateLimited) {
+		t.Errorf("Run without retries error = %v, want ErrRateLimited", err)
+	}
+
+	// A message still rate limited stops the export rather than failing, and is
+	// exported when the run is resumed.
+	dst := t.TempDir()
+	api.status["/v3/grants/"+testGrant+"/messages/m1"] = http.StatusTooManyRequests
+	_, err = Run(context.Background(), client, testGrant, dst, &Options{RateLimitRetries: -1})
+	if !errors.Is(err, nylas.ErrRateLimited) {
+		t.Errorf("Run with a rate-limited message error = %v, want ErrRateLimited", err)
+	}
+	m, err = Run(context.Background(), client, testGrant, dst, &Options{RateLimitRetries: -1})
+	if err != nil {
+		t.Fatalf("resumed Run error: %v", err)
+	}
+	if len(m.Messages) != 3 || len(m.Failed) != 0 {
+		t.Errorf("resumed: messages = %d, failed = %+v, want 3 and none", len(m.Messages), m.Failed)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	client := newTestClient(t, newFakeAPI())
+	dst := t.TempDir()
+
+	if _, err := Run(context.Background(), client, testGrant, dst, &Options{Format: "pst"}); err == nil {
+		t.Error("unknown format: want error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, _ = Run(ctx, client, testGrant, dst, &Options{OnMessage: func(Entry) { cancel() }})
+	if _, err := Run(context.Background(), client, "grant-2", dst, nil); err == nil || !strings.Contains(err.Error(), testGrant) {
+		t.Errorf("other grant error = %v, want mismatch", err)
+	}
+	if _, err := Run(context.Background(), client, testGrant, dst, &Options{Format: Maildir}); err == nil {
+		t.Error("other format: want error")
+	}
+}
+
+func TestWriteMboxrd(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"crlf", "A: b\r\n\r\nbody\r\n", "A: b\n\nbody\n"},
+		{"from lines", "From x\n>From y\n>>From z\nFromage\n", ">From x\n>>From y\n>>>From z\nFromage\n"},
+		{"no final newline", "body", "body\n"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeMboxrd(&buf, []byte(tt.raw))
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writeMboxrd(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafeComponent(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"reserved", `a/b:c*d`, "a_b_c_d"},
+		{"trimmed", " .name. ", "name"},
+		{"empty", "..", "_"},
+		{"ascii cut", strings.Repeat("a", 130), strings.Repeat("a", 120)},
+		{"rune boundary", strings.Repeat("a", 119) + "é", strings.Repeat("a", 119)},
+		{"multibyte", strings.Repeat("日", 50), strings.Repeat("日", 40)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := safeComponent(tt.in)
+			if got != tt.want || !utf8.ValidString(got) {
+				t.Errorf("safeComponent(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFolderPaths(t *testing.T) {
+	tests := []struct {
+		folder      string
+		wantPath    string
+		wantMaildir string
+	}{
+		{"", "Unfiled", ".Unfiled"},
+		{"INBOX", "INBOX", "."},
+		{"Projects/Acme", "Projects/Acme", ".Projects.Acme"},
+		{"v1.2/a:b", "v1.2/a_b", ".v1_2.a_b"},
+		{"..", "_", "._"},
+	}
+	for _, tt := range tests {
+		if got := folderPath(tt.folder); got != tt.wantPath {
+			t.Errorf("folderPath(%q) = %q, want %q", tt.folder, got, tt.wantPath)
+		}
+		if got := maildirFolder(tt.folder); got != tt.wantMaildir {
+			t.Errorf("maildirFolder(%q) = %q, want %q", tt.folder, got, tt.wantMaildir)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ManifestName is the name of the manifest file in an export.
+const ManifestName = "manifest.json"
+
+// journalName is the file an export in progress appends its entries to, so that an
+// interrupted export can resume.
+const journalName = ".export-journal.jsonl"
+
+// Manifest describes a finished export.
+type Manifest struct {
+	// GrantID is the grant that was exported.
+	GrantID string `json:"grant_id"`
+	// Format is the format of the export.
+	Format Format `json:"format"`
+	// StartedAt is when the export started, before any resumes.
+	StartedAt time.Time `json:"started_at"`
+	// CompletedAt is when the export finished.
+	CompletedAt time.Time `json:"completed_at"`
+	// Folders maps folder IDs to the folder paths used in the export.
+	Folders map[string]string `json:"folders,omitempty"`
+	// Messages lists the exported messages in export order.
+	Messages []Entry `json:"messages"`
+	// Failed lists the messages that could not be exported.
+	Failed []Failure `json:"failed,omitempty"`
+}
+
+// Entry describes one exported message.
+type Entry struct {
+	// ID is the Nylas message ID.
+	ID string `json:"id"`
+	// ThreadID is the Nylas thread ID.
+	ThreadID string `json:"thread_id,omitempty"`
+	// MessageID is the Message-ID header, if known.
+	MessageID string `json:"message_id,omitempty"`
+	// Subject is the subject line.
+	Subject string `json:"subject,omitempty"`
+	// From is the sender's address.
+	From string `json:"from,omitempty"`
+	// Date is when the message was sent.
+	Date time.Time `json:"date"`
+	// Folders are the paths of every folder the message is in. The message is
+	// stored under the first one.
+	Folders []string `json:"folders,omitempty"`
+	// Path is the file the message was written to, relative to the export root and
+	// with forward slashes. For mbox exports several messages share a file.
+	Path string `json:"path"`
+	// Offset and Length locate the message within an mbox file.
+	Offset int64 `json:"offset,omitempty"`
+	Length int64 `json:"length,omitempty"`
+	// Size is the size of the RFC 5322 message in bytes.
+	Size int64 `json:"size"`
+	// SHA256 is the hex SHA-256 of the RFC 5322 message, before any mbox escaping.
+	SHA256 string `json:"sha256"`
+	// Source is SourceRawMIME if the message is the provider's original source, or
+	// SourceRebuilt if it was rebuilt from the message fields and attachments.
+	Source string `json:"source"`
+	// Attachments lists the message's attachments.
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Sources of an exported message.
+const (
+	SourceRawMIME = "raw_mime"
+	SourceRebuilt = "rebuilt"
+)
+
+// Attachment describes an attachment of an exported message.
+type Attachment struct {
+	ID          string `json:"id"`
+	Filename    string `json:"filename,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int    `json:"size,omitempty"`
+	Inline      bool   `json:"inline,omitempty"`
+}
+
+// Failure records a message that could not be exported.
+type Failure struct {
+	// ID is the Nylas message ID.
+	ID string `json:"id"`
+	// Error describes what went wrong.
+	Error string `json:"error"`
+}
+
+// journalLine is one line of the journal: the header first, then an entry per
+// message written.
+type journalLine struct {
+	GrantID   string    `json:"grant_id,omitempty"`
+	Format    Format    `json:"format,omitempty"`
+	StartedAt time.Time `json:"started_at,omitempty"`
+	Entry     *Entry    `json:"entry,omitempty"`
+}
+
+// journal records progress in dir so that an interrupted export can resume.
+type journal struct {
+	f *os.File
+	// manifest holds the header and the entries exported so far.
+	manifest Manifest
+	// done holds the IDs of the messages already exported.
+	done map[string]bool
+}
+
+// openJournal opens the journal in dir, reading the progress of an earlier run of
+// the same export. A journal of another grant or format is an error.
+func openJournal(dir, grantID string, format Format) (*journal, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, journalName)
+	j := &journal{
+		manifest: Manifest{GrantID: grantID, Format: format, StartedAt: time.Now().UTC()},
+		done:     make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	valid := 0
+	for i, line := range bytes.SplitAfter(data, []byte("\n")) {
+		var l journalLine
+		// A line cut short by a crash ends the journal.
+		if !bytes.HasSuffix(line, []byte("\n")) || json.Unmarshal(line, &l) != nil {
+			break
+		}
+		valid += len(line)
+		switch {
+		case i == 0:
+			if l.GrantID != grantID || l.Format != format {
+				return nil, fmt.Errorf("export: %s holds an export of grant %s as %s", dir, l.GrantID, l.Format)
+			}
+			j.manifest.StartedAt = l.StartedAt
+		case l.Entry != nil:
+			j.manifest.Messages = append(j.manifest.Messages, *l.Entry)
+			j.done[l.Entry.ID] = true
+		}
+	}
+
+	if j.f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0o644); err != nil {
+		return nil, err
+	}
+	if err := j.f.Truncate(int64(valid)); err != nil {
+		j.f.Close()
+		return nil, err
+	}
+	if _, err := j.f.Seek(int64(valid), 0); err != nil {
+		j.f.Close()
+		return nil, err
+	}
+	if valid == 0 {
+		err = j.append(journalLine{GrantID: grantID, Format: format, StartedAt: j.manifest.StartedAt})
+	}
+	return j, err
+}
+
+func (j *journal) append(l journalLine) error {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	if _, err := j.f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return j.f.Sync()
+}
+
+func (j *journal) addEntry(e Entry) error {
+	j.manifest.Messages = append(j.manifest.Messages, e)
+	j.done[e.ID] = true
+	return j.append(journalLine{Entry: &e})
+}
+
+// addFailure records a failure in the manifest. Failures are not journaled, so a
+// resumed export tries those messages again.
+func (j *journal) addFailure(f Failure) {
+	j.manifest.Failed = append(j.manifest.Failed, f)
+}
+
+func (j *journal) close() error {
+	return j.f.Close()
+}
+
+// removeJournal deletes the journal in dir once the export is complete.
+func removeJournal(dir string) error {
+	err := os.Remove(filepath.Join(dir, journalName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// writeFileAtomic writes path through a temporary file in the same directory, so
+// the file is either complete or absent.
+func writeFileAtomic(path string, write func(w *bufio.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	bw := bufio.NewWriter(tmp)
+	if err := write(bw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func encodeManifest(w *bufio.Writer, m *Manifest) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// rebuildMIME builds an RFC 5322 message from msg's fields, for messages whose
+// original source is not available. download writes an attachment's data to a
+// buffer.
+func rebuildMIME(ctx context.Context, msg *messages.Message, download func(ctx context.Context, a messages.Attachment, buf *bytes.Buffer) error) ([]byte, error) {
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", addressList(msg.From))
+	writeHeader(&buf, "To", addressList(msg.To))
+	writeHeader(&buf, "Cc", addressList(msg.CC))
+	writeHeader(&buf, "Reply-To", addressList(msg.ReplyTo))
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	writeHeader(&buf, "Date", time.Unix(msg.Date, 0).UTC().Format(time.RFC1123Z))
+	if id := msg.MessageID(); id != "" {
+		writeHeader(&buf, "Message-ID", "<"+id+">")
+	}
+	writeHeader(&buf, "X-Nylas-Message-Id", msg.ID)
+	writeHeader(&buf, "MIME-Version", "1.0")
+
+	mw := multipart.NewWriter(&buf)
+	writeHeader(&buf, "Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mw.Boundary()}))
+	buf.WriteString("\r\n")
+
+	bodyType := "text/plain"
+	if strings.Contains(msg.Body, "<") {
+		bodyType = "text/html"
+	}
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(bodyType, map[string]string{"charset": "utf-8"})},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(msg.Body)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, a := range msg.Attachments {
+		var data bytes.Buffer
+		if err := download(ctx, a, &data); err != nil {
+			return nil, fmt.Errorf("export: attachment %s: %w", a.ID, err)
+		}
+		if err := writeAttachment(mw, a, data.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeAttachment(mw *multipart.Writer, a messages.Attachment, data []byte) error {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	disposition := "attachment"
+	if a.IsInline {
+		disposition = "inline"
+	}
+	h := textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"base64"},
+	}
+	if a.Filename != "" {
+		h.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename}))
+	} else {
+		h.Set("Content-Disposition", disposition)
+	}
+	if a.ContentID != "" {
+		h.Set("Content-ID", "<"+strings.Trim(a.ContentID, "<>")+">")
+	}
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+
+	// Base64 lines are at most 76 characters.
+	enc := base64.StdEncoding.EncodeToString(data)
+	for len(enc) > 76 {
+		if _, err := fmt.Fprintf(part, "%s\r\n", enc[:76]); err != nil {
+			return err
+		}
+		enc = enc[76:]
+	}
+	_, err = fmt.Fprintf(part, "%s\r\n", enc)
+	return err
+}
+
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(buf, "%s: %s\r\n", name, value)
+}
+
+func addressList(ps []messages.Participant) string {
+	list := make([]string, 0, len(ps))
+	for _, p := range ps {
+		a := mail.Address{Name: p.Name, Address: p.Email}
+		list = append(list, a.String())
+	}
+	return strings.Join(list, ", ")
+}
@@ -0,0 +1,368 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// unfiled is the folder path of messages that are in no known folder.
+const unfiled = "Unfiled"
+
+// writer stores exported messages in one of the formats.
+type writer interface {
+	// dir returns the directory that holds the journal.
+	dir() string
+	// write stores raw, the RFC 5322 source of msg, under folder and sets e.Path.
+	write(e *Entry, folder string, raw []byte, msg *messages.Message) error
+	// finish completes the export with manifest m.
+	finish(m *Manifest) error
+	// close releases open files without completing the export.
+	close() error
+}
+
+// newWriter returns the writer of format. done lists the messages an earlier run
+// of the export already wrote.
+func newWriter(format Format, dst string, done []Entry) writer {
+	switch format {
+	case Maildir:
+		return &maildirWriter{root: dst}
+	case EML:
+		return &emlWriter{dst: dst, root: workDir(EML, dst)}
+	}
+	return newMboxWriter(dst, done)
+}
+
+// mboxWriter writes one mboxrd file per folder.
+type mboxWriter struct {
+	root  string
+	files map[string]*os.File
+	// ends holds the end of the last complete message of each file, from the
+	// journal of an earlier run.
+	ends map[string]int64
+}
+
+func newMboxWriter(root string, done []Entry) *mboxWriter {
+	w := &mboxWriter{root: root, files: make(map[string]*os.File), ends: make(map[string]int64)}
+	for _, e := range done {
+		w.ends[e.Path] = max(w.ends[e.Path], e.Offset+e.Length)
+	}
+	return w
+}
+
+func (w *mboxWriter) dir() string { return w.root }
+
+// file opens the mbox file rel, cutting off anything after the last message an
+// earlier run completed.
+func (w *mboxWriter) file(rel string) (*os.File, error) {
+	if f, ok := w.files[rel]; ok {
+		return f, nil
+	}
+	name := filepath.Join(w.root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Truncate(w.ends[rel]); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(w.ends[rel], io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	w.files[rel] = f
+	return f, nil
+}
+
+func (w *mboxWriter) write(e *Entry, folder string, raw []byte, msg *messages.Message) error {
+	rel := folderPath(folder) + ".mbox"
+	f, err := w.file(rel)
+	if err != nil {
+		return err
+	}
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	sender := e.From
+	if sender == "" || strings.ContainsAny(sender, " \t") {
+		sender = "MAILER-DAEMON"
+	}
+	fmt.Fprintf(&buf, "From %s %s\n", sender, e.Date.UTC().Format(time.ANSIC))
+	writeMboxrd(&buf, raw)
+	buf.WriteByte('\n')
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	e.Path, e.Offset, e.Length = rel, offset, int64(buf.Len())
+	return nil
+}
+
+// writeMboxrd writes raw with LF line endings and a ">" added to every line that
+// starts with "From " after any number of ">", so the message can be recovered exactly.
+func writeMboxrd(buf *bytes.Buffer, raw []byte) {
+	raw = bytes.ReplaceAll(raw, []byte("\r\n"), []byte("\n"))
+	for len(raw) > 0 {
+		line := raw
+		if i := bytes.IndexByte(raw, '\n'); i >= 0 {
+			line = raw[:i+1]
+		}
+		raw = raw[len(line):]
+		if bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From ")) {
+			buf.WriteByte('>')
+		}
+		buf.Write(line)
+	}
+	if b := buf.Bytes(); len(b) > 0 && b[len(b)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+}
+
+func (w *mboxWriter) finish(m *Manifest) error {
+	// Opening a file cuts off what a crashed run left after its last message, so open
+	// the files this run did not write to as well.
+	for rel := range w.ends {
+		if _, err := w.file(rel); err != nil {
+			w.close()
+			return err
+		}
+	}
+	if err := w.close(); err != nil {
+		return err
+	}
+	return writeManifestFile(w.root, m)
+}
+
+func (w *mboxWriter) close() error {
+	var first error
+	for rel, f := range w.files {
+		if err := f.Close(); err != nil && first == nil {
+			first = err
+		}
+		delete(w.files, rel)
+	}
+	return first
+}
+
+// maildirWriter writes a Maildir++ tree: the inbox at the root and every other
+// folder in a ".Parent.Child" subdirectory.
+type maildirWriter struct {
+	root string
+}
+
+func (w *maildirWriter) dir() string { return w.root }
+
+func (w *maildirWriter) write(e *Entry, folder string, raw []byte, msg *messages.Message) error {
+	rel := maildirFolder(folder)
+	dir := filepath.Join(w.root, filepath.FromSlash(rel))
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return err
+		}
+	}
+
+	// The name depends only on the message, so a resumed export overwrites
+	// instead of duplicating.
+	sum := sha256.Sum256([]byte(msg.ID))
+	name := fmt.Sprintf("%d.%s.nylas", e.Date.Unix(), hex.EncodeToString(sum[:8]))
+	flags := ""
+	if msg.Starred {
+		flags += "F"
+	}
+	if !msg.Unread {
+		flags += "S"
+	}
+
+	tmp := filepath.Join(dir, "tmp", name)
+	if err := writeFileSync(tmp, raw); err != nil {
+		return err
+	}
+	// A run that stopped before journaling the message may have left it with other flags.
+	stale, _ := filepath.Glob(filepath.Join(dir, "cur", name+":2,*"))
+	for _, f := range stale {
+		if err := os.Remove(f); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, "cur", name+":2,"+flags)); err != nil {
+		return err
+	}
+	e.Path = path.Join(rel, "cur", name+":2,"+flags)
+	return nil
+}
+
+func (w *maildirWriter) finish(m *Manifest) error { return writeManifestFile(w.root, m) }
+
+func (w *maildirWriter) close() error { return nil }
+
+// maildirFolder returns the Maildir++ directory of a folder path.
+func maildirFolder(folder string) string {
+	if folder == "" {
+		folder = unfiled
+	}
+	if strings.EqualFold(folder, "inbox") {
+		return "."
+	}
+	parts := strings.Split(folderPath(folder), "/")
+	for i, p := range parts {
+		parts[i] = strings.ReplaceAll(p, ".", "_")
+	}
+	return "." + strings.Join(parts, ".")
+}
+
+// emlWriter writes .eml files to a staging directory next to dst and zips them into
+// dst when the export is complete.
+type emlWriter struct {
+	dst  string
+	root string
+}
+
+func (w *emlWriter) dir() string { return w.root }
+
+func (w *emlWriter) write(e *Entry, folder string, raw []byte, msg *messages.Message) error {
+	rel := path.Join(folderPath(folder), safeComponent(msg.ID)+".eml")
+	name := filepath.Join(w.root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	if err := writeFileSync(name, raw); err != nil {
+		return err
+	}
+	e.Path = rel
+	return nil
+}
+
+func (w *emlWriter) finish(m *Manifest) error {
+	if err := os.MkdirAll(filepath.Dir(w.dst), 0o755); err != nil {
+		return err
+	}
+	err := writeFileAtomic(w.dst, func(bw *bufio.Writer) error {
+		zw := zip.NewWriter(bw)
+		entries := append([]Entry(nil), m.Messages...)
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+		for _, e := range entries {
+			if err := addZipFile(zw, e.Path, filepath.Join(w.root, filepath.FromSlash(e.Path)), e.Date); err != nil {
+				return err
+			}
+		}
+		mw, err := zw.Create(ManifestName)
+		if err != nil {
+			return err
+		}
+		mbw := bufio.NewWriter(mw)
+		if err := encodeManifest(mbw, m); err != nil {
+			return err
+		}
+		if err := mbw.Flush(); err != nil {
+			return err
+		}
+		return zw.Close()
+	})
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(w.root)
+}
+
+func (w *emlWriter) close() error { return nil }
+
+func addZipFile(zw *zip.Writer, name, file string, modified time.Time) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	hdr := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified}
+	w, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func writeManifestFile(root string, m *Manifest) error {
+	return writeFileAtomic(filepath.Join(root, ManifestName), func(w *bufio.Writer) error {
+		return encodeManifest(w, m)
+	})
+}
+
+func writeFileSync(name string, data []byte) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// folderPath returns folder with every component made safe for a file name, or
+// unfiled for "".
+func folderPath(folder string) string {
+	if folder == "" {
+		return unfiled
+	}
+	parts := strings.Split(folder, "/")
+	for i, p := range parts {
+		parts[i] = safeComponent(p)
+	}
+	return strings.Join(parts, "/")
+}
+
+// maxComponentLen is the maximum length in bytes of a file or folder name
+// written by an export.
+const maxComponentLen = 120
+
+// safeComponent returns s with characters that are not allowed in file names on
+// common systems replaced by "_", cut to maxComponentLen bytes.
+func safeComponent(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, s)
+	s = strings.Trim(s, " .")
+	if len(s) > maxComponentLen {
+		// Cut at a rune boundary so the name stays valid UTF-8.
+		i := maxComponentLen
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		s = strings.TrimRight(s[:i], " .")
+	}
+	if s == "" {
+		return "_"
+	}
+	return s
+}
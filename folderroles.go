@@ -0,0 +1,205 @@
+package nylas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/mqasimca/nylas-go/folders"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// ErrFolderRoleNotFound is returned when a grant has no folder with the requested
+// role.
+var ErrFolderRoleNotFound = errors.New("nylas: no folder with that role")
+
+// Roles returns the system folders of a grant by role, as resolved by
+// folders.ResolveRoles. Roles without a folder are left out.
+//
+// The map is cached per grant, so only the first call lists folders. Creating,
+// updating or deleting a folder through this client drops the grant's cache; call
+// ForgetRoles after folders change elsewhere.
+func (s *FoldersService) Roles(ctx context.Context, grantID string) (map[folders.Role]folders.Folder, error) {
+	roles, err := s.roles(ctx, grantID)
+	if err != nil {
+		return nil, newOpError("folders.Roles", grantID, "", err)
+	}
+	out := make(map[folders.Role]folders.Folder, len(roles))
+	for role, f := range roles {
+		out[role] = f
+	}
+	return out, nil
+}
+
+// FindByRole returns the folder of a grant with the given role, such as
+// folders.RoleTrash, whatever the provider calls it. It fails with
+// ErrFolderRoleNotFound if the grant has no such folder. Results are cached as
+// described for Roles.
+//
+// Example:
+//
+//	sent, err := client.Folders.FindByRole(ctx, grantID, folders.RoleSent)
+//	if err != nil {
+//	    return err
+//	}
+//	opts := &messages.ListOptions{In: &sent.ID}
+func (s *FoldersService) FindByRole(ctx context.Context, grantID string, role folders.Role) (*folders.Folder, error) {
+	roles, err := s.roles(ctx, grantID)
+	if err != nil {
+		return nil, newOpError("folders.FindByRole", grantID, "", err)
+	}
+	f, ok := roles[role]
+	if !ok {
+		return nil, newOpError("folders.FindByRole", grantID, "", fmt.Errorf("%w: %s", ErrFolderRoleNotFound, role))
+	}
+	return &f, nil
+}
+
+// ForgetRoles drops the cached folder roles of a grant, so the next lookup lists
+// its folders again.
+func (s *FoldersService) ForgetRoles(grantID string) {
+	s.client.folderRolesMu.Lock()
+	defer s.client.folderRolesMu.Unlock()
+	delete(s.client.folderRoles, grantID)
+}
+
+// roles returns the cached role map of a grant, listing its folders on a miss.
+// The map is shared and must not be modified.
+func (s *FoldersService) roles(ctx context.Context, grantID string) (map[folders.Role]folders.Folder, error) {
+	s.client.folderRolesMu.Lock()
+	roles, ok := s.client.folderRoles[grantID]
+	s.client.folderRolesMu.Unlock()
+	if ok {
+		return roles, nil
+	}
+
+	fs, err := s.listAll(ctx, grantID)
+	if err != nil {
+		return nil, err
+	}
+	roles = folders.ResolveRoles(fs)
+
+	s.client.folderRolesMu.Lock()
+	defer s.client.folderRolesMu.Unlock()
+	if s.client.folderRoles == nil {
+		s.client.folderRoles = make(map[string]map[folders.Role]folders.Folder)
+	}
+	s.client.folderRoles[grantID] = roles
+	return roles, nil
+}
+
+// MoveToRole moves a message to the grant's folder with the given role, such as
+// folders.RoleSpam. It fails with ErrFolderRoleNotFound if there is none.
+//
+// On Gmail the message keeps its other labels, such as UNREAD, STARRED and user
+// labels; only the INBOX, TRASH and SPAM labels are replaced by the target. On
+// other providers the message is moved out of its current folder.
+func (s *MessagesService) MoveToRole(ctx context.Context, grantID, messageID string, role folders.Role) (*messages.Message, error) {
+	return s.moveToRole(ctx, "messages.MoveToRole", grantID, messageID, role)
+}
+
+// Trash moves a message to the grant's trash folder, which is "Deleted Items" on
+// Microsoft, the TRASH label on Gmail and the \Trash folder on IMAP. Gmail labels
+// are kept as described for MoveToRole.
+func (s *MessagesService) Trash(ctx context.Context, grantID, messageID string) (*messages.Message, error) {
+	return s.moveToRole(ctx, "messages.Trash", grantID, messageID, folders.RoleTrash)
+}
+
+// Archive moves a message out of the inbox. If the grant has an archive folder the
+// message is moved there, keeping its other Gmail labels as described for
+// MoveToRole. Otherwise, as on Gmail where archiving removes the INBOX
+// label, the message keeps its other folders and only leaves the inbox. A message
+// already out of the inbox is returned unchanged.
+func (s *MessagesService) Archive(ctx context.Context, grantID, messageID string) (*messages.Message, error) {
+	const op = "messages.Archive"
+	roles, err := s.client.Folders.roles(ctx, grantID)
+	if err != nil {
+		return nil, newOpError(op, grantID, messageID, err)
+	}
+	if _, ok := roles[folders.RoleArchive]; ok {
+		return s.moveToRole(ctx, op, grantID, messageID, folders.RoleArchive)
+	}
+	inbox, ok := roles[folders.RoleInbox]
+	if !ok {
+		return nil, newOpError(op, grantID, messageID, fmt.Errorf("%w: %s", ErrFolderRoleNotFound, folders.RoleArchive))
+	}
+
+	msg, err := s.Get(ctx, grantID, messageID)
+	if err != nil {
+		return nil, newOpError(op, grantID, messageID, err)
+	}
+	rest := make([]string, 0, len(msg.Folders))
+	for _, id := range msg.Folders {
+		if id != inbox.ID {
+			rest = append(rest, id)
+		}
+	}
+	if len(rest) == len(msg.Folders) {
+		return msg, nil
+	}
+	return s.setFolders(ctx, op, grantID, messageID, rest)
+}
+
+func (s *MessagesService) moveToRole(ctx context.Context, op, grantID, messageID string, role folders.Role) (*messages.Message, error) {
+	roles, err := s.client.Folders.roles(ctx, grantID)
+	if err != nil {
+		return nil, newOpError(op, grantID, messageID, err)
+	}
+	f, ok := roles[role]
+	if !ok {
+		return nil, newOpError(op, grantID, messageID, fmt.Errorf("%w: %s", ErrFolderRoleNotFound, role))
+	}
+	if !folders.UsesLabels(roles) {
+		return s.setFolders(ctx, op, grantID, messageID, []string{f.ID})
+	}
+
+	msg, err := s.Get(ctx, grantID, messageID)
+	if err != nil {
+		return nil, newOpError(op, grantID, messageID, err)
+	}
+	labels := make([]string, 0, len(msg.Folders)+1)
+	for _, id := range msg.Folders {
+		if !isLocationLabel(roles, id) && id != f.ID {
+			labels = append(labels, id)
+		}
+	}
+	labels = append(labels, f.ID)
+	if slices.Equal(labels, msg.Folders) {
+		return msg, nil
+	}
+	return s.setFolders(ctx, op, grantID, messageID, labels)
+}
+
+// isLocationLabel reports whether id is the grant's inbox, trash or spam label.
+// A Gmail message is in at most one of them; its other labels are flags and user
+// labels that moving the message keeps.
+func isLocationLabel(roles map[folders.Role]folders.Folder, id string) bool {
+	for _, role := range []folders.Role{folders.RoleInbox, folders.RoleTrash, folders.RoleSpam} {
+		if f, ok := roles[role]; ok && f.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// setFolders replaces the folders of a message. Unlike an UpdateRequest, it sends
+// an empty list too, which leaves a Gmail message with no labels.
+func (s *MessagesService) setFolders(ctx context.Context, op, grantID, messageID string, folderIDs []string) (*messages.Message, error) {
+	path := fmt.Sprintf("/v3/grants/%s/messages/%s", grantID, messageID)
+	body := struct {
+		Folders []string `json:"folders"`
+	}{folderIDs}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, body)
+	if err != nil {
+		return nil, newOpError(op, grantID, messageID, err)
+	}
+
+	var msg messages.Message
+	if _, err := s.client.Do(req, &msg); err != nil {
+		return nil, newOpError(op, grantID, messageID, err)
+	}
+	return &msg, nil
+}
@@ -0,0 +1,211 @@
+package nylas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/folders"
+)
+
+// folderRolesServer serves a folder list and records the folders each message
+// update sets.
+func folderRolesServer(t *testing.T, folderList string, lists *int32, updates map[string][]string) *Client {
+	t.Helper()
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/folders"):
+			atomic.AddInt32(lists, 1)
+			_, _ = w.Write([]byte(`{"data": ` + folderList + `}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/folders"):
+			_, _ = w.Write([]byte(`{"data": {"id": "new"}}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/messages/msg-1"):
+			_, _ = w.Write([]byte(`{"data": {"id": "msg-1", "folders": ["INBOX", "UNREAD", "CATEGORY_PERSONAL"]}}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/messages/msg-2"):
+			_, _ = w.Write([]byte(`{"data": {"id": "msg-2", "folders": ["INBOX"]}}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/messages/msg-3"):
+			_, _ = w.Write([]byte(`{"data": {"id": "msg-3", "folders": ["TRASH", "STARRED", "Label_7"]}}`))
+		case r.Method == http.MethodPut:
+			var body map[string][]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			updates[id] = body["folders"]
+			_, _ = w.Write([]byte(`{"data": {"id": "` + id + `"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+const (
+	imapFolders = `[
+		{"id": "f-inbox", "name": "INBOX", "attributes": ["\\Inbox"]},
+		{"id": "f-trash", "name": "Papierkorb", "attributes": ["\\Trash"]},
+		{"id": "f-archive", "name": "Archiv", "attributes": ["\\Archive"]},
+		{"id": "f-sent", "name": "Gesendet", "attributes": ["\\Sent"]}
+	]`
+	gmailFolders = `[
+		{"id": "INBOX", "name": "INBOX", "system_folder": true},
+		{"id": "TRASH", "name": "TRASH", "system_folder": true},
+		{"id": "UNREAD", "name": "UNREAD", "system_folder": true}
+	]`
+)
+
+func TestFoldersService_FindByRole(t *testing.T) {
+	var lists int32
+	client := folderRolesServer(t, imapFolders, &lists, map[string][]string{})
+	ctx := context.Background()
+
+	trash, err := client.Folders.FindByRole(ctx, "grant-123", folders.RoleTrash)
+	if err != nil || trash.ID != "f-trash" {
+		t.Fatalf("FindByRole(trash) = %+v, %v", trash, err)
+	}
+	sent, err := client.Folders.FindByRole(ctx, "grant-123", folders.RoleSent)
+	if err != nil || sent.ID != "f-sent" {
+		t.Fatalf("FindByRole(sent) = %+v, %v", sent, err)
+	}
+	if lists != 1 {
+		t.Errorf("folder lists = %d, want 1 (cached)", lists)
+	}
+
+	_, err = client.Folders.FindByRole(ctx, "grant-123", folders.RoleSpam)
+	var opErr *OpError
+	if !errors.Is(err, ErrFolderRoleNotFound) || !errors.As(err, &opErr) || opErr.Op != "folders.FindByRole" {
+		t.Errorf("FindByRole(spam) error = %v, want ErrFolderRoleNotFound", err)
+	}
+
+	if _, err := client.Folders.Create(ctx, "grant-123", &folders.CreateRequest{Name: "Spam"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Folders.Roles(ctx, "grant-123"); err != nil || lists != 2 {
+		t.Errorf("Roles() after Create: lists = %d, error = %v", lists, err)
+	}
+	if _, err := client.Folders.Roles(ctx, "grant-456"); err != nil || lists != 3 {
+		t.Errorf("Roles() for another grant: lists = %d, error = %v", lists, err)
+	}
+}
+
+func TestMessagesService_TrashArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		folders string
+		call    func(c *Client) error
+		want    map[string][]string
+		wantErr error
+	}{
+		{
+			name:    "trash imap",
+			folders: imapFolders,
+			call: func(c *Client) error {
+				_, err := c.Messages.Trash(context.Background(), "grant-123", "msg-1")
+				return err
+			},
+			want: map[string][]string{"msg-1": {"f-trash"}},
+		},
+		{
+			name:    "trash gmail",
+			folders: gmailFolders,
+			call: func(c *Client) error {
+				_, err := c.Messages.Trash(context.Background(), "grant-123", "msg-1")
+				return err
+			},
+			want: map[string][]string{"msg-1": {"UNREAD", "CATEGORY_PERSONAL", "TRASH"}},
+		},
+		{
+			name:    "restore gmail to inbox",
+			folders: gmailFolders,
+			call: func(c *Client) error {
+				_, err := c.Messages.MoveToRole(context.Background(), "grant-123", "msg-3", folders.RoleInbox)
+				return err
+			},
+			want: map[string][]string{"msg-3": {"STARRED", "Label_7", "INBOX"}},
+		},
+		{
+			name:    "gmail already in inbox",
+			folders: gmailFolders,
+			call: func(c *Client) error {
+				_, err := c.Messages.MoveToRole(context.Background(), "grant-123", "msg-2", folders.RoleInbox)
+				return err
+			},
+			want: map[string][]string{},
+		},
+		{
+			name:    "archive to folder",
+			folders: imapFolders,
+			call: func(c *Client) error {
+				_, err := c.Messages.Archive(context.Background(), "grant-123", "msg-1")
+				return err
+			},
+			want: map[string][]string{"msg-1": {"f-archive"}},
+		},
+		{
+			name:    "archive removes inbox label",
+			folders: gmailFolders,
+			call: func(c *Client) error {
+				_, err := c.Messages.Archive(context.Background(), "grant-123", "msg-1")
+				return err
+			},
+			want: map[string][]string{"msg-1": {"UNREAD", "CATEGORY_PERSONAL"}},
+		},
+		{
+			name: "archive to gmail label",
+			folders: `[
+				{"id": "INBOX", "name": "INBOX", "system_folder": true},
+				{"id": "TRASH", "name": "TRASH", "system_folder": true},
+				{"id": "Label_9", "name": "Archive"}
+			]`,
+			call: func(c *Client) error {
+				_, err := c.Messages.Archive(context.Background(), "grant-123", "msg-1")
+				return err
+			},
+			want: map[string][]string{"msg-1": {"UNREAD", "CATEGORY_PERSONAL", "Label_9"}},
+		},
+		{
+			name:    "archive last label",
+			folders: gmailFolders,
+			call: func(c *Client) error {
+				_, err := c.Messages.Archive(context.Background(), "grant-123", "msg-2")
+				return err
+			},
+			want: map[string][]string{"msg-2": {}},
+		},
+		{
+			name:    "no spam folder",
+			folders: gmailFolders,
+			call: func(c *Client) error {
+				_, err := c.Messages.MoveToRole(context.Background(), "grant-123", "msg-1", folders.RoleSpam)
+				return err
+			},
+			want:    map[string][]string{},
+			wantErr: ErrFolderRoleNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lists int32
+			updates := map[string][]string{}
+			client := folderRolesServer(t, tt.folders, &lists, updates)
+			err := tt.call(client)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if len(updates) != len(tt.want) {
+				t.Fatalf("updates = %v, want %v", updates, tt.want)
+			}
+			for id, want := range tt.want {
+				got, ok := updates[id]
+				if !ok || got == nil || strings.Join(got, ",") != strings.Join(want, ",") {
+					t.Errorf("folders of %s = %#v, want %#v", id, got, want)
+				}
+			}
+		})
+	}
+}
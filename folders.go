@@ -14,7 +14,7 @@ func (s *FoldersService) List(ctx context.Context, grantID string, opts *folders
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("folders.List: %w", err)
+		return nil, newOpError("folders.List", grantID, "", err)
 	}
 
 	if opts != nil {
@@ -26,7 +26,7 @@ func (s *FoldersService) List(ctx context.Context, grantID string, opts *folders
 	var data []folders.Folder
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("folders.List: %w", err)
+		return nil, newOpError("folders.List", grantID, "", err)
 	}
 
 	return &ListResponse[folders.Folder]{
@@ -42,13 +42,13 @@ func (s *FoldersService) Get(ctx context.Context, grantID, folderID string) (*fo
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("folders.Get(%s): %w", folderID, err)
+		return nil, newOpError("folders.Get", grantID, folderID, err)
 	}
 
 	var folder folders.Folder
 	_, err = s.client.Do(req, &folder)
 	if err != nil {
-		return nil, fmt.Errorf("folders.Get(%s): %w", folderID, err)
+		return nil, newOpError("folders.Get", grantID, folderID, err)
 	}
 
 	return &folder, nil
@@ -60,15 +60,16 @@ func (s *FoldersService) Create(ctx context.Context, grantID string, create *fol
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, create)
 	if err != nil {
-		return nil, fmt.Errorf("folders.Create: %w", err)
+		return nil, newOpError("folders.Create", grantID, "", err)
 	}
 
 	var folder folders.Folder
 	_, err = s.client.Do(req, &folder)
 	if err != nil {
-		return nil, fmt.Errorf("folders.Create: %w", err)
+		return nil, newOpError("folders.Create", grantID, "", err)
 	}
 
+	s.ForgetRoles(grantID)
 	return &folder, nil
 }
 
@@ -78,15 +79,16 @@ func (s *FoldersService) Update(ctx context.Context, grantID, folderID string, u
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("folders.Update(%s): %w", folderID, err)
+		return nil, newOpError("folders.Update", grantID, folderID, err)
 	}
 
 	var folder folders.Folder
 	_, err = s.client.Do(req, &folder)
 	if err != nil {
-		return nil, fmt.Errorf("folders.Update(%s): %w", folderID, err)
+		return nil, newOpError("folders.Update", grantID, folderID, err)
 	}
 
+	s.ForgetRoles(grantID)
 	return &folder, nil
 }
 
@@ -96,14 +98,15 @@ func (s *FoldersService) Delete(ctx context.Context, grantID, folderID string) e
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("folders.Delete(%s): %w", folderID, err)
+		return newOpError("folders.Delete", grantID, folderID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("folders.Delete(%s): %w", folderID, err)
+		return newOpError("folders.Delete", grantID, folderID, err)
 	}
 
+	s.ForgetRoles(grantID)
 	return nil
 }
 
@@ -2,4 +2,8 @@
 //
 // Use the FoldersService methods on the main nylas.Client to manage email folders
 // and labels across different email providers.
+//
+// Folder.Role and ResolveRoles identify system folders such as the trash or sent
+// folder, whatever the provider calls them. NewTree arranges folders into a
+// hierarchy addressed by "/"-separated paths, regardless of the provider's delimiter.
 package folders
@@ -0,0 +1,17 @@
+package folders
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes f, keeping fields this package does not declare in Extra.
+func (f *Folder) UnmarshalJSON(data []byte) error {
+	type alias Folder
+	extra, err := common.UnmarshalExtra(data, (*alias)(f))
+	f.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes f together with the fields kept in Extra.
+func (f Folder) MarshalJSON() ([]byte, error) {
+	type alias Folder
+	return common.MarshalExtra(alias(f), f.Extra)
+}
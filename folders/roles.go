@@ -0,0 +1,184 @@
+package folders
+
+import "strings"
+
+// Role is the purpose of a system folder, independent of what the provider calls it.
+type Role string
+
+// Folder roles.
+const (
+	RoleInbox   Role = "inbox"
+	RoleSent    Role = "sent"
+	RoleDrafts  Role = "drafts"
+	RoleTrash   Role = "trash"
+	RoleSpam    Role = "spam"
+	RoleArchive Role = "archive"
+)
+
+// Roles lists every folder role.
+var Roles = []Role{RoleInbox, RoleSent, RoleDrafts, RoleTrash, RoleSpam, RoleArchive}
+
+// How strongly a folder matches a role; stronger matches win.
+const (
+	matchName      = 1 // a well-known folder name
+	matchGmail     = 2 // a Gmail system label ID
+	matchAll       = 3 // the IMAP \All attribute, a fallback for archive
+	matchAttribute = 4 // an IMAP special-use attribute (RFC 6154)
+)
+
+// attributeRoles maps IMAP special-use attributes, lower-cased, to roles.
+var attributeRoles = map[string]Role{
+	`\inbox`:   RoleInbox,
+	`\sent`:    RoleSent,
+	`\drafts`:  RoleDrafts,
+	`\trash`:   RoleTrash,
+	`\junk`:    RoleSpam,
+	`\archive`: RoleArchive,
+}
+
+// gmailRoles maps Gmail system label IDs to roles.
+var gmailRoles = map[string]Role{
+	"INBOX": RoleInbox,
+	"SENT":  RoleSent,
+	"DRAFT": RoleDrafts,
+	"TRASH": RoleTrash,
+	"SPAM":  RoleSpam,
+}
+
+// nameRoles maps well-known folder names, lower-cased, to roles. They cover
+// Microsoft's well-known folders and IMAP servers without special-use attributes.
+var nameRoles = map[string]Role{
+	"inbox":            RoleInbox,
+	"sent":             RoleSent,
+	"sent items":       RoleSent,
+	"sent mail":        RoleSent,
+	"sent messages":    RoleSent,
+	"drafts":           RoleDrafts,
+	"draft":            RoleDrafts,
+	"trash":            RoleTrash,
+	"bin":              RoleTrash,
+	"deleted":          RoleTrash,
+	"deleted items":    RoleTrash,
+	"deleted messages": RoleTrash,
+	"spam":             RoleSpam,
+	"junk":             RoleSpam,
+	"junk email":       RoleSpam,
+	"junk e-mail":      RoleSpam,
+	"bulk mail":        RoleSpam,
+	"archive":          RoleArchive,
+	"archives":         RoleArchive,
+}
+
+// Role returns the role of the folder, or "" if it is not a system folder this
+// package recognises.
+//
+// IMAP special-use attributes are checked first, then Gmail system label IDs, then
+// well-known names such as Microsoft's "Sent Items" and "Deleted Items".
+func (f *Folder) Role() Role {
+	role, _ := f.roleMatch()
+	return role
+}
+
+// roleMatch returns the role of the folder and how strongly it matches.
+func (f *Folder) roleMatch() (Role, int) {
+	for _, attr := range f.Attributes {
+		if role, ok := attributeRoles[strings.ToLower(attr)]; ok {
+			return role, matchAttribute
+		}
+	}
+	for _, attr := range f.Attributes {
+		if strings.EqualFold(attr, `\All`) {
+			return RoleArchive, matchAll
+		}
+	}
+	if role, ok := gmailRoles[f.ID]; ok {
+		return role, matchGmail
+	}
+	if role, ok := nameRoles[strings.ToLower(baseName(f.Name))]; ok {
+		return role, matchName
+	}
+	return "", 0
+}
+
+// baseName strips the prefixes IMAP servers put on system folders, as in
+// "[Gmail]/Sent Mail" or "INBOX.Trash".
+func baseName(name string) string {
+	name = strings.TrimSpace(name)
+	if rest, ok := strings.CutPrefix(name, "[Gmail]/"); ok {
+		return rest
+	}
+	if rest, ok := strings.CutPrefix(name, "[Google Mail]/"); ok {
+		return rest
+	}
+	if len(name) > 6 && strings.EqualFold(name[:5], "INBOX") && (name[5] == '.' || name[5] == '/') {
+		return name[6:]
+	}
+	return name
+}
+
+// ResolveRoles maps each role to the folder that best fills it. A role no folder
+// matches is left out.
+//
+// When several folders match a role, an IMAP special-use attribute beats a Gmail
+// label ID, which beats a name. Among equal matches a system folder beats a user
+// folder, a top-level folder beats a nested one, and otherwise the first wins.
+//
+// Example:
+//
+//	roles := folders.ResolveRoles(all)
+//	if trash, ok := roles[folders.RoleTrash]; ok {
+//	    fmt.Println("trash is", trash.ID)
+//	}
+func ResolveRoles(fs []Folder) map[Role]Folder {
+	type candidate struct {
+		folder Folder
+		rank   [3]int
+	}
+	best := make(map[Role]candidate)
+	for _, f := range fs {
+		role, strength := f.roleMatch()
+		if role == "" {
+			continue
+		}
+		c := candidate{folder: f, rank: [3]int{strength, boolRank(f.SystemFolder), boolRank(f.ParentID == "")}}
+		if cur, ok := best[role]; !ok || outranks(c.rank, cur.rank) {
+			best[role] = c
+		}
+	}
+	roles := make(map[Role]Folder, len(best))
+	for role, c := range best {
+		roles[role] = c.folder
+	}
+	return roles
+}
+
+// UsesLabels reports whether the folders in roles, as returned by ResolveRoles,
+// are Gmail labels, where a message can be in several at once. The inbox alone
+// does not count, since IMAP servers also call it INBOX.
+func UsesLabels(roles map[Role]Folder) bool {
+	for role, f := range roles {
+		if role == RoleInbox {
+			continue
+		}
+		if _, strength := f.roleMatch(); strength == matchGmail {
+			return true
+		}
+	}
+	return false
+}
+
+func outranks(a, b [3]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] > b[i]
+		}
+	}
+	return false
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package folders
+
+import "testing"
+
+func TestFolder_Role(t *testing.T) {
+	tests := []struct {
+		name   string
+		folder Folder
+		want   Role
+	}{
+		{name: "imap attribute", folder: Folder{ID: "f1", Name: "Papierkorb", Attributes: []string{`\HasNoChildren`, `\Trash`}}, want: RoleTrash},
+		{name: "imap junk", folder: Folder{ID: "f2", Name: "Bulk", Attributes: []string{`\junk`}}, want: RoleSpam},
+		{name: "imap all mail", folder: Folder{ID: "f3", Name: "[Gmail]/All Mail", Attributes: []string{`\All`}}, want: RoleArchive},
+		{name: "gmail label", folder: Folder{ID: "SPAM", Name: "SPAM", SystemFolder: true}, want: RoleSpam},
+		{name: "gmail draft label", folder: Folder{ID: "DRAFT", Name: "DRAFT"}, want: RoleDrafts},
+		{name: "microsoft name", folder: Folder{ID: "AAMk1", Name: "Deleted Items"}, want: RoleTrash},
+		{name: "microsoft junk", folder: Folder{ID: "AAMk2", Name: "Junk Email"}, want: RoleSpam},
+		{name: "imap prefixed name", folder: Folder{ID: "f4", Name: "INBOX.Sent"}, want: RoleSent},
+		{name: "gmail imap name", folder: Folder{ID: "f5", Name: "[Gmail]/Sent Mail"}, want: RoleSent},
+		{name: "user folder", folder: Folder{ID: "f6", Name: "Receipts"}},
+		{name: "inbox child", folder: Folder{ID: "f7", Name: "INBOX.Receipts"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.folder.Role(); got != tt.want {
+				t.Errorf("Role() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRoles(t *testing.T) {
+	fs := []Folder{
+		{ID: "user-trash", Name: "Trash", ParentID: "projects"},
+		{ID: "trash", Name: "Deleted", Attributes: []string{`\Trash`}},
+		{ID: "all", Name: "[Gmail]/All Mail", Attributes: []string{`\All`}},
+		{ID: "archive", Name: "Archive", Attributes: []string{`\Archive`}},
+		{ID: "sent-user", Name: "Sent"},
+		{ID: "sent-system", Name: "Sent Items", SystemFolder: true},
+		{ID: "INBOX", Name: "INBOX"},
+		{ID: "receipts", Name: "Receipts"},
+	}
+	want := map[Role]string{
+		RoleTrash:   "trash",
+		RoleArchive: "archive",
+		RoleSent:    "sent-system",
+		RoleInbox:   "INBOX",
+	}
+
+	roles := ResolveRoles(fs)
+	if len(roles) != len(want) {
+		t.Errorf("ResolveRoles() = %v", roles)
+	}
+	for role, id := range want {
+		if roles[role].ID != id {
+			t.Errorf("roles[%s] = %q, want %q", role, roles[role].ID, id)
+		}
+	}
+}
+
+func TestUsesLabels(t *testing.T) {
+	imap := ResolveRoles([]Folder{
+		{ID: "INBOX", Name: "INBOX"},
+		{ID: "Trash", Name: "Trash", Attributes: []string{`\Trash`}},
+	})
+	if UsesLabels(imap) {
+		t.Error("UsesLabels(imap) = true")
+	}
+	gmail := ResolveRoles([]Folder{
+		{ID: "INBOX", Name: "INBOX", SystemFolder: true},
+		{ID: "TRASH", Name: "TRASH", SystemFolder: true},
+	})
+	if !UsesLabels(gmail) {
+		t.Error("UsesLabels(gmail) = false")
+	}
+}
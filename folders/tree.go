@@ -0,0 +1,288 @@
+package folders
+
+import (
+	"sort"
+	"strings"
+)
+
+// Tree is the folder hierarchy of a grant.
+type Tree struct {
+	// Roots are the top-level folders, sorted by name.
+	Roots []*Node
+	// Delimiter is the separator the provider nests folder names with: "/" on
+	// Gmail, "/" or "." on IMAP, whichever most nested names use. It is "" when
+	// folders are nested by parent ID without full names, as on Microsoft, or
+	// when no folder is nested.
+	Delimiter string
+
+	byPath     map[string]*Node
+	byFoldPath map[string]*Node
+	byID       map[string]*Node
+}
+
+// Node is a folder in a Tree.
+type Node struct {
+	// Folder is the folder. It is nil for a level that only exists in the names
+	// of its descendants, as for "Clients" when a Gmail label "Clients/Acme" has
+	// no "Clients" label of its own.
+	Folder *Folder
+	// Name is the folder's own name, without the names of its ancestors.
+	Name string
+	// Path is the names from the top-level folder down, joined with "/".
+	Path string
+	// Parent is the enclosing folder, or nil for a top-level folder.
+	Parent *Node
+	// Children are the folders directly inside this one, sorted by name.
+	Children []*Node
+	// Depth is 0 for top-level folders, 1 for their children, and so on.
+	Depth int
+}
+
+// NewTree arranges folders into a hierarchy.
+//
+// A folder is nested under the folder named by its ParentID. If no folder has a
+// ParentID, a name such as Gmail's "Clients/Acme" is nested under the folder named
+// by the part before the last "/", and a "/" name whose parent does not exist gets
+// a node without a Folder for each missing level. IMAP folders, which are the ones
+// with attributes, may use "." instead; the delimiter more names nest by wins, so a
+// single name such as "Mr. Smith" does not decide it. Nested names are shortened
+// to their last part, so every path uses "/" whatever the provider's delimiter.
+func NewTree(fs []Folder) *Tree {
+	t := &Tree{
+		byPath:     make(map[string]*Node),
+		byFoldPath: make(map[string]*Node),
+		byID:       make(map[string]*Node),
+	}
+	nodes := make([]*Node, len(fs))
+	byName := make(map[string]*Node)
+	imap := false
+	for i := range fs {
+		f := fs[i]
+		nodes[i] = &Node{Folder: &f, Name: f.Name}
+		if _, dup := t.byID[f.ID]; !dup {
+			t.byID[f.ID] = nodes[i]
+		}
+		if _, dup := byName[f.Name]; !dup {
+			byName[f.Name] = nodes[i]
+		}
+		imap = imap || len(f.Attributes) > 0
+	}
+	delims := []string{"/"}
+	if imap {
+		delims = append(delims, ".")
+	}
+	byParentID := false
+	for _, f := range fs {
+		if p, ok := t.byID[f.ParentID]; ok && f.ParentID != "" && p.Folder.ID != f.ID {
+			byParentID = true
+			break
+		}
+	}
+
+	// levelFor returns the folder named name or, if there is none, a node without
+	// a Folder, creating it and its own missing ancestors.
+	virtual := make(map[string]*Node)
+	var levelFor func(name string) *Node
+	levelFor = func(name string) *Node {
+		if n, ok := byName[name]; ok {
+			return n
+		}
+		if n, ok := virtual[name]; ok {
+			return n
+		}
+		n := &Node{Name: name}
+		virtual[name] = n
+		if i := strings.LastIndex(name, "/"); i > 0 && i < len(name)-1 {
+			n.Name = name[i+1:]
+			setParent(n, levelFor(name[:i]))
+		}
+		return n
+	}
+
+	votes := make(map[string]int)
+	if byParentID {
+		for _, n := range nodes {
+			f := n.Folder
+			if p, ok := t.byID[f.ParentID]; ok && f.ParentID != "" && !isAncestor(n, p) {
+				if rest, delim, ok := cutParentName(f.Name, p.Folder.Name, delims); ok {
+					n.Name = rest
+					votes[delim]++
+				}
+				setParent(n, p)
+			}
+		}
+	} else {
+		delim := nameDelimiter(fs, byName, delims)
+		for _, n := range nodes {
+			f := n.Folder
+			if p, rest := parentByName(f.Name, delim, byName); p != nil && !isAncestor(n, p) {
+				n.Name = rest
+				votes[delim]++
+				setParent(n, p)
+				continue
+			}
+			if i := strings.LastIndex(f.Name, "/"); delim == "/" && i > 0 && i < len(f.Name)-1 {
+				if p := levelFor(f.Name[:i]); !isAncestor(n, p) {
+					n.Name = f.Name[i+1:]
+					votes["/"]++
+					setParent(n, p)
+				}
+			}
+		}
+	}
+	t.Delimiter = mostVoted(votes, delims)
+
+	for _, n := range nodes {
+		if n.Parent == nil {
+			t.Roots = append(t.Roots, n)
+		}
+	}
+	for _, n := range virtual {
+		if n.Parent == nil {
+			t.Roots = append(t.Roots, n)
+		}
+	}
+
+	var index func(ns []*Node, parent *Node)
+	index = func(ns []*Node, parent *Node) {
+		sortByName(ns)
+		for _, n := range ns {
+			n.Path = n.Name
+			if parent != nil {
+				n.Path = parent.Path + "/" + n.Name
+				n.Depth = parent.Depth + 1
+			}
+			if _, dup := t.byPath[n.Path]; !dup {
+				t.byPath[n.Path] = n
+			}
+			if _, dup := t.byFoldPath[strings.ToLower(n.Path)]; !dup {
+				t.byFoldPath[strings.ToLower(n.Path)] = n
+			}
+			index(n.Children, n)
+		}
+	}
+	index(t.Roots, nil)
+	return t
+}
+
+// FindByPath returns the folder at a path such as "Clients/Acme/2025". Segments
+// are separated by "/" whatever the provider's delimiter, and leading, trailing
+// and repeated slashes are ignored. An exact match is preferred to one that
+// differs only in case.
+func (t *Tree) FindByPath(path string) (*Node, bool) {
+	path = strings.Join(SplitPath(path), "/")
+	if n, ok := t.byPath[path]; ok {
+		return n, true
+	}
+	n, ok := t.byFoldPath[strings.ToLower(path)]
+	return n, ok
+}
+
+// FindByID returns the node of the folder with the given ID.
+func (t *Tree) FindByID(id string) (*Node, bool) {
+	n, ok := t.byID[id]
+	return n, ok
+}
+
+// Walk calls fn for every node in depth-first order, each folder before its
+// children.
+func (t *Tree) Walk(fn func(n *Node)) {
+	var walk func(ns []*Node)
+	walk = func(ns []*Node) {
+		for _, n := range ns {
+			fn(n)
+			walk(n.Children)
+		}
+	}
+	walk(t.Roots)
+}
+
+// SplitPath splits a folder path into its segments, dropping empty ones.
+func SplitPath(path string) []string {
+	var segs []string
+	for _, s := range strings.Split(path, "/") {
+		if s = strings.TrimSpace(s); s != "" {
+			segs = append(segs, s)
+		}
+	}
+	return segs
+}
+
+// cutParentName returns name without the parent's name and the delimiter after
+// it, for providers that give nested folders their full name.
+func cutParentName(name, parent string, delims []string) (rest, delim string, ok bool) {
+	for _, d := range delims {
+		if rest, ok := strings.CutPrefix(name, parent+d); ok && rest != "" {
+			return rest, d, true
+		}
+	}
+	return name, "", false
+}
+
+// parentByName returns the folder whose name is the longest prefix of name that
+// ends before delim, and the rest of name after it.
+func parentByName(name, delim string, byName map[string]*Node) (*Node, string) {
+	for i := strings.LastIndex(name, delim); i > 0; i = strings.LastIndex(name[:i], delim) {
+		if i == len(name)-len(delim) {
+			continue
+		}
+		if p, ok := byName[name[:i]]; ok {
+			return p, name[i+len(delim):]
+		}
+	}
+	return nil, ""
+}
+
+// nameDelimiter returns the one of delims that nests the most names under
+// another folder's name, preferring the first on a tie.
+func nameDelimiter(fs []Folder, byName map[string]*Node, delims []string) string {
+	votes := make(map[string]int)
+	for _, f := range fs {
+		for _, d := range delims {
+			if p, _ := parentByName(f.Name, d, byName); p != nil {
+				votes[d]++
+			}
+		}
+	}
+	if d := mostVoted(votes, delims); d != "" {
+		return d
+	}
+	return delims[0]
+}
+
+// mostVoted returns the one of delims with the most votes, preferring the first
+// on a tie, or "" if none has any.
+func mostVoted(votes map[string]int, delims []string) string {
+	best := ""
+	for _, d := range delims {
+		if votes[d] > votes[best] {
+			best = d
+		}
+	}
+	return best
+}
+
+func setParent(n, p *Node) {
+	n.Parent = p
+	p.Children = append(p.Children, n)
+}
+
+// isAncestor reports whether a is p or one of p's ancestors.
+func isAncestor(a, p *Node) bool {
+	for ; p != nil; p = p.Parent {
+		if p == a {
+			return true
+		}
+	}
+	return false
+}
+
+func sortByName(ns []*Node) {
+	sort.SliceStable(ns, func(i, j int) bool {
+		a, b := strings.ToLower(ns[i].Name), strings.ToLower(ns[j].Name)
+		if a != b {
+			return a < b
+		}
+		return ns[i].Name < ns[j].Name
+	})
+}
@@ -0,0 +1,164 @@
+package folders
+
+import (
+	"strings"
+	"testing"
+)
+
+// paths renders a tree as its paths in walk order, marking nodes without a folder.
+func paths(t *Tree) string {
+	var out []string
+	t.Walk(func(n *Node) {
+		p := n.Path
+		if n.Folder == nil {
+			p += "*"
+		}
+		out = append(out, p)
+	})
+	return strings.Join(out, " ")
+}
+
+func TestNewTree(t *testing.T) {
+	tests := []struct {
+		name      string
+		folders   []Folder
+		want      string
+		wantDelim string
+	}{
+		{
+			name: "microsoft parent ids",
+			folders: []Folder{
+				{ID: "2025", Name: "2025", ParentID: "acme"},
+				{ID: "clients", Name: "Clients", ParentID: "root"},
+				{ID: "acme", Name: "Acme", ParentID: "clients"},
+				{ID: "inbox", Name: "Inbox", ParentID: "root"},
+			},
+			want: "Clients Clients/Acme Clients/Acme/2025 Inbox",
+		},
+		{
+			name: "gmail labels",
+			folders: []Folder{
+				{ID: "INBOX", Name: "INBOX"},
+				{ID: "Label_2", Name: "Clients/Acme"},
+				{ID: "Label_1", Name: "Clients"},
+				{ID: "Label_3", Name: "Clients/Acme/2025"},
+			},
+			want:      "Clients Clients/Acme Clients/Acme/2025 INBOX",
+			wantDelim: "/",
+		},
+		{
+			name: "gmail missing levels",
+			folders: []Folder{
+				{ID: "Label_3", Name: "Clients/Acme/2025"},
+				{ID: "Label_4", Name: "Clients/Beta"},
+			},
+			want:      "Clients* Clients/Acme* Clients/Acme/2025 Clients/Beta",
+			wantDelim: "/",
+		},
+		{
+			name: "microsoft dot in name",
+			folders: []Folder{
+				{ID: "mr", Name: "Mr"},
+				{ID: "smith", Name: "Mr. Smith", ParentID: "mr"},
+				{ID: "jones", Name: "Mr. Jones"},
+			},
+			want: "Mr Mr/Mr. Smith Mr. Jones",
+		},
+		{
+			name: "gmail dot in name",
+			folders: []Folder{
+				{ID: "Label_1", Name: "Mr"},
+				{ID: "Label_2", Name: "Mr. Smith"},
+			},
+			want: "Mr Mr. Smith",
+		},
+		{
+			name: "imap dot delimiter",
+			folders: []Folder{
+				{ID: "1", Name: "INBOX", Attributes: []string{`\HasChildren`}},
+				{ID: "2", Name: "INBOX.Clients", Attributes: []string{`\HasChildren`}},
+				{ID: "3", Name: "INBOX.Clients.Acme", Attributes: []string{`\HasNoChildren`}},
+				{ID: "4", Name: "Mr. Smith", Attributes: []string{`\HasNoChildren`}},
+			},
+			want:      "INBOX INBOX/Clients INBOX/Clients/Acme Mr. Smith",
+			wantDelim: ".",
+		},
+		{
+			name: "imap slash outvotes dot",
+			folders: []Folder{
+				{ID: "1", Name: "Clients", Attributes: []string{`\HasChildren`}},
+				{ID: "2", Name: "Clients/Acme", Attributes: []string{`\HasNoChildren`}},
+				{ID: "3", Name: "Clients/Beta", Attributes: []string{`\HasNoChildren`}},
+				{ID: "4", Name: "Mr", Attributes: []string{`\HasNoChildren`}},
+				{ID: "5", Name: "Mr. Smith", Attributes: []string{`\HasNoChildren`}},
+			},
+			want:      "Clients Clients/Acme Clients/Beta Mr Mr. Smith",
+			wantDelim: "/",
+		},
+		{
+			name: "imap parent ids with full names",
+			folders: []Folder{
+				{ID: "1", Name: "INBOX", Attributes: []string{`\HasChildren`}},
+				{ID: "2", Name: "INBOX.Clients", ParentID: "1", Attributes: []string{`\HasNoChildren`}},
+			},
+			want:      "INBOX INBOX/Clients",
+			wantDelim: ".",
+		},
+		{
+			name: "parent id cycle",
+			folders: []Folder{
+				{ID: "a", Name: "A", ParentID: "b"},
+				{ID: "b", Name: "B", ParentID: "a"},
+			},
+			want: "B B/A",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := NewTree(tt.folders)
+			if got := paths(tree); got != tt.want {
+				t.Errorf("paths = %q, want %q", got, tt.want)
+			}
+			if tree.Delimiter != tt.wantDelim {
+				t.Errorf("Delimiter = %q, want %q", tree.Delimiter, tt.wantDelim)
+			}
+		})
+	}
+}
+
+func TestTree_Find(t *testing.T) {
+	tree := NewTree([]Folder{
+		{ID: "clients", Name: "Clients"},
+		{ID: "acme", Name: "Acme", ParentID: "clients"},
+		{ID: "acme-lower", Name: "acme", ParentID: "clients"},
+	})
+
+	tests := []struct {
+		path   string
+		wantID string
+	}{
+		{path: "Clients/Acme", wantID: "acme"},
+		{path: "/Clients//acme/", wantID: "acme-lower"},
+		{path: "clients/ACME", wantID: "acme"},
+		{path: "Clients/Beta"},
+		{path: ""},
+	}
+	for _, tt := range tests {
+		n, ok := tree.FindByPath(tt.path)
+		if tt.wantID == "" {
+			if ok {
+				t.Errorf("FindByPath(%q) = %q, want none", tt.path, n.Path)
+			}
+			continue
+		}
+		if !ok || n.Folder.ID != tt.wantID {
+			t.Errorf("FindByPath(%q) = %+v, %v, want %s", tt.path, n, ok, tt.wantID)
+		}
+	}
+
+	n, ok := tree.FindByID("acme")
+	if !ok || n.Path != "Clients/Acme" || n.Depth != 1 || n.Parent.Folder.ID != "clients" {
+		t.Errorf("FindByID(acme) = %+v, %v", n, ok)
+	}
+}
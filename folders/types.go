@@ -1,5 +1,7 @@
 package folders
 
+import "encoding/json"
+
 // Folder represents an email folder (IMAP) or label (Gmail) in the Nylas API.
 type Folder struct {
 	// ID is the unique identifier for this folder.
@@ -26,6 +28,9 @@ type Folder struct {
 	TotalCount *int `json:"total_count,omitempty"`
 	// Attributes contains IMAP folder attributes (e.g., "\\Sent", "\\Trash").
 	Attributes []string `json:"attributes,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ListOptions specifies options for listing folders.
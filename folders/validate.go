@@ -0,0 +1,35 @@
+package folders
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+func (r *CreateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Name == "" {
+		v.Add("name", "is required")
+	}
+	checkColor(v, "background_color", r.BackgroundColor)
+	checkColor(v, "text_color", r.TextColor)
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Name != nil && *r.Name == "" {
+		v.Add("name", "must not be empty")
+	}
+	if r.BackgroundColor != nil {
+		checkColor(v, "background_color", *r.BackgroundColor)
+	}
+	if r.TextColor != nil {
+		checkColor(v, "text_color", *r.TextColor)
+	}
+	return v.Err()
+}
+
+func checkColor(v *common.ValidationError, path, color string) {
+	if color != "" && !common.ValidHexColor(color) {
+		v.Add(path, "%q is not a #RRGGBB color", color)
+	}
+}
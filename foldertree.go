@@ -0,0 +1,150 @@
+package nylas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mqasimca/nylas-go/folders"
+)
+
+// Tree returns the folder hierarchy of a grant (see folders.NewTree).
+//
+// Example:
+//
+//	tree, err := client.Folders.Tree(ctx, grantID)
+//	if err != nil {
+//	    return err
+//	}
+//	tree.Walk(func(n *folders.Node) {
+//	    fmt.Printf("%s%s\n", strings.Repeat("  ", n.Depth), n.Name)
+//	})
+func (s *FoldersService) Tree(ctx context.Context, grantID string) (*folders.Tree, error) {
+	tree, err := s.tree(ctx, grantID)
+	if err != nil {
+		return nil, newOpError("folders.Tree", grantID, "", err)
+	}
+	return tree, nil
+}
+
+// FindByPath returns the folder at a path such as "Clients/Acme/2025", with
+// segments separated by "/" whatever delimiter the provider uses. It fails with
+// ErrNotFound if there is no folder at the path.
+func (s *FoldersService) FindByPath(ctx context.Context, grantID, path string) (*folders.Folder, error) {
+	tree, err := s.tree(ctx, grantID)
+	if err != nil {
+		return nil, newOpError("folders.FindByPath", grantID, "", err)
+	}
+	n, ok := tree.FindByPath(path)
+	if !ok || n.Folder == nil {
+		return nil, newOpError("folders.FindByPath", grantID, "", fmt.Errorf("%w: no folder at %q", ErrNotFound, path))
+	}
+	return n.Folder, nil
+}
+
+// EnsurePath returns the folder at a path such as "Clients/Acme/2025", creating it
+// and any missing folders above it.
+//
+// New folders are nested the way the grant's existing folders are: by ParentID on
+// Microsoft, and by a full name joined with the provider's delimiter on Gmail and
+// IMAP. If no existing folder is nested, the grant's provider decides, with "/" as
+// the delimiter.
+//
+// Example:
+//
+//	folder, err := client.Folders.EnsurePath(ctx, grantID, "Clients/Acme/2025")
+//	if err != nil {
+//	    return err
+//	}
+//	_, err = client.Messages.Update(ctx, grantID, messageID, &messages.UpdateRequest{
+//	    Folders: []string{folder.ID},
+//	})
+func (s *FoldersService) EnsurePath(ctx context.Context, grantID, path string) (*folders.Folder, error) {
+	const op = "folders.EnsurePath"
+	segs := folders.SplitPath(path)
+	if len(segs) == 0 {
+		return nil, newOpError(op, grantID, "", errors.New("nylas: empty folder path"))
+	}
+	tree, err := s.tree(ctx, grantID)
+	if err != nil {
+		return nil, newOpError(op, grantID, "", err)
+	}
+
+	var parent *folders.Folder
+	delim, resolved := treeNesting(tree)
+	for i, seg := range segs {
+		if n, ok := tree.FindByPath(strings.Join(segs[:i+1], "/")); ok {
+			if n.Folder != nil {
+				parent = n.Folder
+				continue
+			}
+			seg = n.Name
+		}
+		if !resolved {
+			if delim, err = s.nestingDelimiter(ctx, grantID); err != nil {
+				return nil, newOpError(op, grantID, "", err)
+			}
+			resolved = true
+		}
+		create := &folders.CreateRequest{Name: seg}
+		switch {
+		case parent != nil && delim == "":
+			create.ParentID = parent.ID
+		case parent != nil:
+			create.Name = parent.Name + delim + seg
+		}
+		if parent, err = s.Create(ctx, grantID, create); err != nil {
+			return nil, newOpError(op, grantID, "", err)
+		}
+	}
+	return parent, nil
+}
+
+// treeNesting returns the delimiter the folders of tree are nested with, or "" if
+// they are nested by ParentID. It returns false if no folder is nested.
+func treeNesting(tree *folders.Tree) (string, bool) {
+	if tree.Delimiter != "" {
+		return tree.Delimiter, true
+	}
+	nested := false
+	tree.Walk(func(n *folders.Node) {
+		nested = nested || n.Parent != nil
+	})
+	return "", nested
+}
+
+// nestingDelimiter returns the delimiter new folders of a grant are nested with,
+// or "" to nest them by ParentID, for grants that have no nested folders yet.
+func (s *FoldersService) nestingDelimiter(ctx context.Context, grantID string) (string, error) {
+	grant, err := s.client.Grants.Get(ctx, grantID)
+	if err != nil {
+		return "", err
+	}
+	switch grant.Provider {
+	case "microsoft", "ews":
+		return "", nil
+	}
+	return "/", nil
+}
+
+func (s *FoldersService) tree(ctx context.Context, grantID string) (*folders.Tree, error) {
+	fs, err := s.listAll(ctx, grantID)
+	if err != nil {
+		return nil, err
+	}
+	return folders.NewTree(fs), nil
+}
+
+// listAll returns every folder of a grant.
+func (s *FoldersService) listAll(ctx context.Context, grantID string) ([]folders.Folder, error) {
+	all, err := s.ListAll(ctx, grantID, nil).Collect()
+	if err != nil {
+		return nil, err
+	}
+	fs := make([]folders.Folder, len(all))
+	for i, f := range all {
+		fs[i] = *f
+	}
+	return fs, nil
+}
@@ -0,0 +1,163 @@
+package nylas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/folders"
+)
+
+func TestFoldersService_FindByPath(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "Label_1", "name": "Clients"},
+			{"id": "Label_2", "name": "Clients/Acme"},
+			{"id": "Label_3", "name": "Archive/2024/Q1"}
+		]}`))
+	})
+	ctx := context.Background()
+
+	f, err := client.Folders.FindByPath(ctx, "grant-123", "Clients/Acme")
+	if err != nil || f.ID != "Label_2" {
+		t.Errorf("FindByPath(Clients/Acme) = %+v, %v", f, err)
+	}
+	// Archive/2024 is only a level in a label name, not a folder.
+	for _, path := range []string{"Clients/Beta", "Archive/2024"} {
+		if _, err := client.Folders.FindByPath(ctx, "grant-123", path); !errors.Is(err, ErrNotFound) {
+			t.Errorf("FindByPath(%s) error = %v, want ErrNotFound", path, err)
+		}
+	}
+
+	tree, err := client.Folders.Tree(ctx, "grant-123")
+	if err != nil || len(tree.Roots) != 2 || tree.Delimiter != "/" {
+		t.Errorf("Tree() = %+v, %v", tree, err)
+	}
+}
+
+func TestFoldersService_EnsurePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		folders  string
+		path     string
+		want     []folders.CreateRequest
+		wantID   string
+	}{
+		{
+			name:    "exists",
+			folders: `[{"id": "c", "name": "Clients"}, {"id": "a", "name": "Acme", "parent_id": "c"}]`,
+			path:    "Clients/Acme",
+			wantID:  "a",
+		},
+		{
+			name:    "nested by parent id",
+			folders: `[{"id": "c", "name": "Clients"}, {"id": "a", "name": "Acme", "parent_id": "c"}]`,
+			path:    "Clients/Acme/2025/Q1",
+			want: []folders.CreateRequest{
+				{Name: "2025", ParentID: "a"},
+				{Name: "Q1", ParentID: "new-1"},
+			},
+			wantID: "new-2",
+		},
+		{
+			name:    "imap dot delimiter",
+			folders: `[{"id": "1", "name": "INBOX", "attributes": ["\\HasChildren"]}, {"id": "2", "name": "INBOX.Clients", "attributes": ["\\HasNoChildren"]}]`,
+			path:    "INBOX/Clients/Acme",
+			want:    []folders.CreateRequest{{Name: "INBOX.Clients.Acme"}},
+			wantID:  "new-1",
+		},
+		{
+			name:    "dot in name nested by parent id",
+			folders: `[{"id": "m", "name": "Mr"}, {"id": "s", "name": "Mr. Smith", "parent_id": "m"}]`,
+			path:    "Mr/Mr. Smith/2025",
+			want:    []folders.CreateRequest{{Name: "2025", ParentID: "s"}},
+			wantID:  "new-1",
+		},
+		{
+			name:    "gmail missing level",
+			folders: `[{"id": "Label_1", "name": "Clients/Acme/2025"}]`,
+			path:    "clients/acme/2026",
+			want: []folders.CreateRequest{
+				{Name: "Clients"},
+				{Name: "Clients/Acme"},
+				{Name: "Clients/Acme/2026"},
+			},
+			wantID: "new-3",
+		},
+		{
+			name:     "flat microsoft",
+			provider: "microsoft",
+			folders:  `[{"id": "inbox", "name": "Inbox"}]`,
+			path:     "Clients/Acme",
+			want: []folders.CreateRequest{
+				{Name: "Clients"},
+				{Name: "Acme", ParentID: "new-1"},
+			},
+			wantID: "new-2",
+		},
+		{
+			name:     "flat google",
+			provider: "google",
+			folders:  `[{"id": "INBOX", "name": "INBOX"}]`,
+			path:     "Clients/Acme",
+			want: []folders.CreateRequest{
+				{Name: "Clients"},
+				{Name: "Clients/Acme"},
+			},
+			wantID: "new-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []folders.CreateRequest
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/v3/grants/grant-123":
+					_, _ = w.Write([]byte(`{"data": {"id": "grant-123", "provider": "` + tt.provider + `"}}`))
+				case r.Method == http.MethodGet:
+					_, _ = w.Write([]byte(`{"data": ` + tt.folders + `}`))
+				case r.Method == http.MethodPost:
+					var req folders.CreateRequest
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						t.Errorf("decode body: %v", err)
+					}
+					created = append(created, req)
+					id := "new-" + string(rune('0'+len(created)))
+					_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"id": id, "name": req.Name}})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			})
+
+			f, err := client.Folders.EnsurePath(context.Background(), "grant-123", tt.path)
+			if err != nil {
+				t.Fatalf("EnsurePath() error = %v", err)
+			}
+			if f.ID != tt.wantID {
+				t.Errorf("EnsurePath() = %s, want %s", f.ID, tt.wantID)
+			}
+			if len(created) != len(tt.want) {
+				t.Fatalf("created = %+v, want %+v", created, tt.want)
+			}
+			for i := range created {
+				if created[i] != tt.want[i] {
+					t.Errorf("created[%d] = %+v, want %+v", i, created[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("empty path", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		})
+		if _, err := client.Folders.EnsurePath(context.Background(), "grant-123", " / "); err == nil || !strings.Contains(err.Error(), "empty folder path") {
+			t.Errorf("error = %v", err)
+		}
+	})
+}
@@ -12,9 +12,13 @@ import (
 func (s *GrantsService) List(ctx context.Context, opts *grants.ListOptions) (*ListResponse[grants.Grant], error) {
 	path := "/v3/grants"
 
+	if err := s.client.validate(opts); err != nil {
+		return nil, newOpError("grants.List", "", "", err)
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("grants.List: %w", err)
+		return nil, newOpError("grants.List", "", "", err)
 	}
 
 	if opts != nil {
@@ -26,7 +30,7 @@ func (s *GrantsService) List(ctx context.Context, opts *grants.ListOptions) (*Li
 	var data []grants.Grant
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("grants.List: %w", err)
+		return nil, newOpError("grants.List", "", "", err)
 	}
 
 	return &ListResponse[grants.Grant]{
@@ -42,13 +46,13 @@ func (s *GrantsService) Get(ctx context.Context, grantID string) (*grants.Grant,
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("grants.Get(%s): %w", grantID, err)
+		return nil, newOpError("grants.Get", grantID, grantID, err)
 	}
 
 	var grant grants.Grant
 	_, err = s.client.Do(req, &grant)
 	if err != nil {
-		return nil, fmt.Errorf("grants.Get(%s): %w", grantID, err)
+		return nil, newOpError("grants.Get", grantID, grantID, err)
 	}
 
 	return &grant, nil
@@ -60,13 +64,13 @@ func (s *GrantsService) Update(ctx context.Context, grantID string, update *gran
 
 	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("grants.Update(%s): %w", grantID, err)
+		return nil, newOpError("grants.Update", grantID, grantID, err)
 	}
 
 	var grant grants.Grant
 	_, err = s.client.Do(req, &grant)
 	if err != nil {
-		return nil, fmt.Errorf("grants.Update(%s): %w", grantID, err)
+		return nil, newOpError("grants.Update", grantID, grantID, err)
 	}
 
 	return &grant, nil
@@ -78,12 +82,12 @@ func (s *GrantsService) Delete(ctx context.Context, grantID string) error {
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("grants.Delete(%s): %w", grantID, err)
+		return newOpError("grants.Delete", grantID, grantID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("grants.Delete(%s): %w", grantID, err)
+		return newOpError("grants.Delete", grantID, grantID, err)
 	}
 
 	return nil
@@ -0,0 +1,17 @@
+package grants
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes g, keeping fields this package does not declare in Extra.
+func (g *Grant) UnmarshalJSON(data []byte) error {
+	type alias Grant
+	extra, err := common.UnmarshalExtra(data, (*alias)(g))
+	g.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes g together with the fields kept in Extra.
+func (g Grant) MarshalJSON() ([]byte, error) {
+	type alias Grant
+	return common.MarshalExtra(alias(g), g.Extra)
+}
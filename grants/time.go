@@ -0,0 +1,19 @@
+package grants
+
+import (
+	"time"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// SetSince filters grants created after t.
+func (o *ListOptions) SetSince(t time.Time) *ListOptions {
+	o.Since = common.UnixPtr(t)
+	return o
+}
+
+// SetBefore filters grants created before t.
+func (o *ListOptions) SetBefore(t time.Time) *ListOptions {
+	o.Before = common.UnixPtr(t)
+	return o
+}
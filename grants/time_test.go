@@ -0,0 +1,17 @@
+package grants
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListOptions_TimeSetters(t *testing.T) {
+	since := time.Unix(1700000000, 0)
+	opts := (&ListOptions{}).SetSince(since).SetBefore(since.Add(time.Hour))
+	if *opts.Since != 1700000000 || *opts.Before != 1700003600 || opts.Validate() != nil {
+		t.Errorf("ListOptions = %+v", opts)
+	}
+	if err := (&ListOptions{}).SetSince(since.Add(time.Hour)).SetBefore(since).Validate(); err == nil {
+		t.Error("Validate() with reversed range error = nil")
+	}
+}
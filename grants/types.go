@@ -1,5 +1,7 @@
 package grants
 
+import "encoding/json"
+
 // Grant represents a Nylas grant (connected email/calendar account).
 // A grant is created when a user authenticates via OAuth.
 type Grant struct {
@@ -25,6 +27,9 @@ type Grant struct {
 	UpdatedAt int64 `json:"updated_at,omitempty"`
 	// Settings contains provider-specific settings.
 	Settings map[string]any `json:"settings,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ListOptions specifies options for listing grants.
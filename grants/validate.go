@@ -0,0 +1,24 @@
+package grants
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if len(r.Settings) == 0 && len(r.Scope) == 0 {
+		v.Add("", "settings or scope is required")
+	}
+	for i, s := range r.Scope {
+		if s == "" {
+			v.Add(common.IndexPath("scope", i), "must not be empty")
+		}
+	}
+	return v.Err()
+}
+
+// Validate checks that the time filters are Unix timestamps in seconds.
+func (o *ListOptions) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckOptionalTimeRange(v, "since", o.Since, "before", o.Before)
+	return v.Err()
+}
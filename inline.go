@@ -0,0 +1,273 @@
+package nylas
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mqasimca/nylas-go/attachments"
+	"github.com/mqasimca/nylas-go/bodytext"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// DefaultInlineMaxSize caps the bytes of inline images an InlineResolver downloads
+// for one message unless InlineOptions.MaxSize is set.
+const DefaultInlineMaxSize = 10 << 20
+
+// DefaultInlineMaxCached is the number of messages whose resolved images an
+// InlineResolver keeps unless InlineOptions.MaxCached is set.
+const DefaultInlineMaxCached = 1000
+
+// ErrInlineTooLarge is reported for inline images left unresolved because they would
+// take a message over InlineOptions.MaxSize.
+var ErrInlineTooLarge = errors.New("nylas: inline images exceed the size limit")
+
+// InlineOptions configures an InlineResolver. By default cid: references become
+// data: URIs.
+type InlineOptions struct {
+	// Upload, if set, stores each inline image, e.g. on a CDN, and returns the URL
+	// the body should reference instead.
+	Upload func(ctx context.Context, messageID string, a messages.Attachment, data []byte) (string, error)
+	// Dir, if set and Upload is not, saves each inline image to Dir/<grant>/<message>
+	// as "<attachment ID>-<filename>", so images that share a filename do not
+	// overwrite each other, and the body references the file path.
+	Dir string
+	// MaxSize caps the bytes of inline images downloaded for one message. Images
+	// that would exceed it are left as cid: references. Defaults to
+	// DefaultInlineMaxSize.
+	MaxSize int64
+	// MaxCached caps the number of messages whose results are cached. Once it is
+	// reached, the message resolved longest ago is dropped. Defaults to
+	// DefaultInlineMaxCached.
+	MaxCached int
+}
+
+// InlineResolver rewrites the cid: references in message bodies so inline images
+// display outside a mail client. Images are downloaded with AttachmentsService, and
+// the result is cached per grant and message, so resolving a message again
+// downloads nothing.
+// The cache holds up to InlineOptions.MaxCached messages; with data: URIs each entry
+// holds the images themselves. It is safe for concurrent use.
+//
+// Example:
+//
+//	r := client.Attachments.NewInlineResolver(nil)
+//	body, err := r.Resolve(ctx, grantID, msg)
+//	if err != nil {
+//	    log.Printf("some inline images could not be resolved: %v", err)
+//	}
+type InlineResolver struct {
+	s    *AttachmentsService
+	opts InlineOptions
+
+	mu    sync.Mutex
+	cache map[inlineKey]*inlineImages
+	order []inlineKey // keys in cache, oldest first
+}
+
+// inlineKey identifies a message in the cache. Message IDs are only unique within
+// a grant.
+type inlineKey struct {
+	grantID, messageID string
+}
+
+// inlineImages are the resolved inline images of one message.
+type inlineImages struct {
+	// urls maps content IDs to URLs.
+	urls map[string]string
+	// size is the number of bytes downloaded.
+	size int64
+}
+
+// NewInlineResolver returns an InlineResolver. A nil opts uses data: URIs.
+func (s *AttachmentsService) NewInlineResolver(opts *InlineOptions) *InlineResolver {
+	r := &InlineResolver{s: s, cache: make(map[inlineKey]*inlineImages)}
+	if opts != nil {
+		r.opts = *opts
+	}
+	if r.opts.MaxSize <= 0 {
+		r.opts.MaxSize = DefaultInlineMaxSize
+	}
+	if r.opts.MaxCached <= 0 {
+		r.opts.MaxCached = DefaultInlineMaxCached
+	}
+	return r
+}
+
+// Resolve returns msg.Body with each cid: reference replaced by the URL of the
+// matching attachment. Only attachments the body references are downloaded.
+//
+// The body is returned even if some images could not be resolved; those keep their
+// cid: references and are reported in the error, wrapping ErrInlineTooLarge for
+// images over the size limit.
+func (r *InlineResolver) Resolve(ctx context.Context, grantID string, msg *messages.Message) (string, error) {
+	urls, err := r.resolve(ctx, grantID, msg)
+	body := bodytext.ReplaceContentIDs(msg.Body, func(cid string) (string, bool) {
+		u, ok := urls[cid]
+		return u, ok
+	})
+	if err != nil {
+		return body, newOpError("attachments.ResolveInline", grantID, msg.ID, err)
+	}
+	return body, nil
+}
+
+// Forget drops the cached images of a message, so the next Resolve downloads them
+// again. Files saved to InlineOptions.Dir are not removed.
+func (r *InlineResolver) Forget(grantID, messageID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := inlineKey{grantID, messageID}
+	if _, ok := r.cache[key]; ok {
+		delete(r.cache, key)
+		r.order = slices.DeleteFunc(r.order, func(k inlineKey) bool { return k == key })
+	}
+}
+
+// store caches the images of a message, dropping the oldest entry if the cache is
+// full.
+func (r *InlineResolver) store(key inlineKey, images *inlineImages) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.cache[key]; !ok {
+		r.order = append(r.order, key)
+	}
+	r.cache[key] = images
+	for len(r.order) > r.opts.MaxCached {
+		delete(r.cache, r.order[0])
+		r.order = r.order[1:]
+	}
+}
+
+// resolve returns the URL of every content ID in msg.Body it can resolve, using
+// the cache and downloading the rest.
+func (r *InlineResolver) resolve(ctx context.Context, grantID string, msg *messages.Message) (map[string]string, error) {
+	key := inlineKey{grantID, msg.ID}
+	r.mu.Lock()
+	images := &inlineImages{urls: make(map[string]string)}
+	if cached, ok := r.cache[key]; ok {
+		images.size = cached.size
+		for cid, u := range cached.urls {
+			images.urls[cid] = u
+		}
+	}
+	r.mu.Unlock()
+	urls := images.urls
+
+	var errs []error
+	for _, cid := range bodytext.ContentIDs(msg.Body) {
+		if _, ok := urls[cid]; ok {
+			continue
+		}
+		a, ok := inlineAttachment(msg, cid)
+		if !ok {
+			continue
+		}
+		if images.size+int64(a.Size) > r.opts.MaxSize {
+			errs = append(errs, fmt.Errorf("%s: %w", cid, ErrInlineTooLarge))
+			continue
+		}
+		u, n, err := r.fetch(ctx, grantID, msg.ID, a, r.opts.MaxSize-images.size)
+		images.size += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", cid, err))
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		urls[cid] = u
+	}
+
+	r.store(key, images)
+	return urls, errors.Join(errs...)
+}
+
+// fetch downloads a and returns its URL and the number of bytes downloaded. The
+// download stops with ErrInlineTooLarge as soon as it goes over limit, whatever
+// size the attachment claims, and a partly saved file is removed.
+func (r *InlineResolver) fetch(ctx context.Context, grantID, messageID string, a messages.Attachment, limit int64) (string, int64, error) {
+	if r.opts.Upload == nil && r.opts.Dir != "" {
+		name := attachments.SafeFilename(a.ID)
+		if filename := attachments.SafeFilename(a.Filename); filename != "" {
+			name += "-" + filename
+		}
+		dir := filepath.Join(r.opts.Dir, attachments.SafeFilename(grantID), attachments.SafeFilename(messageID))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", 0, err
+		}
+		path := filepath.Join(dir, name)
+		f, err := os.Create(path)
+		if err != nil {
+			return "", 0, err
+		}
+		lw := &limitWriter{w: f, limit: limit}
+		_, err = r.s.DownloadTo(ctx, grantID, a.ID, messageID, lw)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			_ = os.Remove(path)
+			return "", lw.n, err
+		}
+		return filepath.ToSlash(path), lw.n, nil
+	}
+
+	var buf bytes.Buffer
+	lw := &limitWriter{w: &buf, limit: limit}
+	res, err := r.s.DownloadTo(ctx, grantID, a.ID, messageID, lw)
+	if err != nil {
+		return "", lw.n, err
+	}
+	if r.opts.Upload != nil {
+		u, err := r.opts.Upload(ctx, messageID, a, buf.Bytes())
+		return u, res.Size, err
+	}
+
+	contentType := res.ContentType
+	if contentType == "" {
+		contentType = a.ContentType
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(buf.Bytes())
+	}
+	contentType, _, _ = strings.Cut(contentType, ";")
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), res.Size, nil
+}
+
+// limitWriter writes to w until more than limit bytes are written in total, then
+// fails with ErrInlineTooLarge.
+type limitWriter struct {
+	w     io.Writer
+	limit int64
+	n     int64
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if l.n+int64(len(p)) > l.limit {
+		l.n += int64(len(p))
+		return 0, ErrInlineTooLarge
+	}
+	n, err := l.w.Write(p)
+	l.n += int64(n)
+	return n, err
+}
+
+// inlineAttachment returns the attachment of msg with the given content ID.
+func inlineAttachment(msg *messages.Message, cid string) (messages.Attachment, bool) {
+	for _, a := range msg.Attachments {
+		if a.ContentID != "" && strings.EqualFold(strings.Trim(a.ContentID, "<>"), cid) {
+			return a, true
+		}
+	}
+	return messages.Attachment{}, false
+}
@@ -0,0 +1,220 @@
+package nylas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// newInlineServer serves the attachments in data, keyed by attachment ID, to any
+// grant and counts the downloads of each.
+func newInlineServer(t *testing.T, data map[string]string) (*Client, map[string]int) {
+	t.Helper()
+	var mu sync.Mutex
+	downloads := make(map[string]int)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, id, _ := strings.Cut(r.URL.Path, "/attachments/")
+		id = strings.TrimSuffix(id, "/download")
+		body, ok := data[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "attachment not found", "type": "not_found"}`))
+			return
+		}
+		if !strings.HasSuffix(r.URL.Path, "/download") {
+			fmt.Fprintf(w, `{"data": {"id": %q, "filename": "%s.png", "content_type": "image/png", "size": %d}}`, id, id, len(body))
+			return
+		}
+		mu.Lock()
+		downloads[id]++
+		mu.Unlock()
+		_, _ = w.Write([]byte(body))
+	})
+	return client, downloads
+}
+
+func inlineMessage() *messages.Message {
+	return &messages.Message{
+		ID:   "msg-123",
+		Body: `<p>Hi</p><img src="cid:logo@example.com"><img src="cid:chart"><img src="cid:missing">`,
+		Attachments: []messages.Attachment{
+			{ID: "att-logo", Filename: "logo.png", ContentType: "image/png", Size: 4, ContentID: "<logo@example.com>", IsInline: true},
+			{ID: "att-chart", Filename: "chart.png", ContentType: "image/png", Size: 6, ContentID: "chart", IsInline: true},
+			{ID: "att-report", Filename: "report.pdf", ContentType: "application/pdf", Size: 100},
+		},
+	}
+}
+
+func TestInlineResolver_DataURI(t *testing.T) {
+	client, downloads := newInlineServer(t, map[string]string{"att-logo": "LOGO", "att-chart": "CHART!"})
+	r := client.Attachments.NewInlineResolver(nil)
+	msg := inlineMessage()
+
+	body, err := r.Resolve(context.Background(), "grant-123", msg)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	want := `<p>Hi</p><img src="data:image/png;base64,TE9HTw=="><img src="data:image/png;base64,Q0hBUlQh"><img src="cid:missing">`
+	if body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+
+	if _, err := r.Resolve(context.Background(), "grant-123", msg); err != nil {
+		t.Fatalf("second Resolve() error = %v", err)
+	}
+	if downloads["att-logo"] != 1 || downloads["att-chart"] != 1 || downloads["att-report"] != 0 {
+		t.Errorf("downloads = %v, want each inline image once", downloads)
+	}
+
+	r.Forget("grant-123", msg.ID)
+	if _, err := r.Resolve(context.Background(), "grant-123", msg); err != nil {
+		t.Fatalf("Resolve() after Forget error = %v", err)
+	}
+	if downloads["att-logo"] != 2 {
+		t.Errorf("logo downloaded %d times after Forget, want 2", downloads["att-logo"])
+	}
+
+	// Message IDs are only unique within a grant, so another grant is not served
+	// from the cache.
+	if _, err := r.Resolve(context.Background(), "grant-456", msg); err != nil {
+		t.Fatalf("Resolve() for another grant error = %v", err)
+	}
+	if downloads["att-logo"] != 3 {
+		t.Errorf("logo downloaded %d times after another grant, want 3", downloads["att-logo"])
+	}
+}
+
+func TestInlineResolver_Upload(t *testing.T) {
+	client, _ := newInlineServer(t, map[string]string{"att-logo": "LOGO", "att-chart": "CHART!"})
+	r := client.Attachments.NewInlineResolver(&InlineOptions{
+		Upload: func(ctx context.Context, messageID string, a messages.Attachment, data []byte) (string, error) {
+			return fmt.Sprintf("https://cdn.example.com/%s/%s?n=%d", messageID, a.Filename, len(data)), nil
+		},
+	})
+
+	body, err := r.Resolve(context.Background(), "grant-123", inlineMessage())
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	for _, want := range []string{
+		`src="https://cdn.example.com/msg-123/logo.png?n=4"`,
+		`src="https://cdn.example.com/msg-123/chart.png?n=6"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body = %s, want %s", body, want)
+		}
+	}
+}
+
+func TestInlineResolver_Dir(t *testing.T) {
+	client, _ := newInlineServer(t, map[string]string{"att-logo": "LOGO", "att-chart": "CHART!"})
+	dir := t.TempDir()
+	r := client.Attachments.NewInlineResolver(&InlineOptions{Dir: dir})
+
+	body, err := r.Resolve(context.Background(), "grant-123", inlineMessage())
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	logo := filepath.Join(dir, "grant-123", "msg-123", "att-logo-logo.png")
+	if !strings.Contains(body, `src="`+filepath.ToSlash(logo)+`"`) {
+		t.Errorf("body = %s, want a reference to %s", body, logo)
+	}
+	if data, err := os.ReadFile(logo); err != nil || string(data) != "LOGO" {
+		t.Errorf("saved logo = %q, %v", data, err)
+	}
+
+	// Images that share a filename are saved to separate files.
+	msg := inlineMessage()
+	msg.ID = "msg-456"
+	msg.Attachments[0].Filename = "image.png"
+	msg.Attachments[1].Filename = "image.png"
+	if _, err := r.Resolve(context.Background(), "grant-123", msg); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	for id, want := range map[string]string{"att-logo": "LOGO", "att-chart": "CHART!"} {
+		path := filepath.Join(dir, "grant-123", "msg-456", id+"-image.png")
+		if data, err := os.ReadFile(path); err != nil || string(data) != want {
+			t.Errorf("saved %s = %q, %v, want %q", id, data, err, want)
+		}
+	}
+}
+
+func TestInlineResolver_Errors(t *testing.T) {
+	client, downloads := newInlineServer(t, map[string]string{"att-logo": "LOGO"})
+	r := client.Attachments.NewInlineResolver(&InlineOptions{MaxSize: 8})
+	msg := inlineMessage()
+	msg.Attachments[1].Size = 4
+
+	// The chart is not on the server; a larger chart would not fit.
+	body, err := r.Resolve(context.Background(), "grant-123", msg)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Resolve() error = %v, want ErrNotFound", err)
+	}
+	if !strings.Contains(body, `src="data:image/png;base64,TE9HTw=="`) || !strings.Contains(body, `src="cid:chart"`) {
+		t.Errorf("body = %s, want the logo resolved and the chart left", body)
+	}
+
+	msg.Attachments[1].Size = 5
+	_, err = r.Resolve(context.Background(), "grant-123", msg)
+	if !errors.Is(err, ErrInlineTooLarge) {
+		t.Errorf("Resolve() error = %v, want ErrInlineTooLarge", err)
+	}
+	if downloads["att-logo"] != 1 {
+		t.Errorf("logo downloaded %d times, want 1", downloads["att-logo"])
+	}
+}
+
+func TestInlineResolver_StreamLimit(t *testing.T) {
+	// The attachment claims 4 bytes but the download is larger.
+	client, _ := newInlineServer(t, map[string]string{"att-logo": strings.Repeat("x", 64<<10)})
+	dir := t.TempDir()
+	msg := inlineMessage()
+	msg.Body = `<img src="cid:logo@example.com">`
+
+	for _, opts := range []*InlineOptions{{MaxSize: 1 << 10}, {MaxSize: 1 << 10, Dir: dir}} {
+		r := client.Attachments.NewInlineResolver(opts)
+		body, err := r.Resolve(context.Background(), "grant-123", msg)
+		if !errors.Is(err, ErrInlineTooLarge) {
+			t.Errorf("Resolve(Dir=%q) error = %v, want ErrInlineTooLarge", opts.Dir, err)
+		}
+		if body != msg.Body {
+			t.Errorf("Resolve(Dir=%q) body = %s, want it unchanged", opts.Dir, body)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "grant-123", "msg-123", "att-logo-logo.png")); !os.IsNotExist(err) {
+		t.Errorf("partial file stat error = %v, want it removed", err)
+	}
+}
+
+func TestInlineResolver_MaxCached(t *testing.T) {
+	client, downloads := newInlineServer(t, map[string]string{"att-logo": "LOGO", "att-chart": "CHART!"})
+	r := client.Attachments.NewInlineResolver(&InlineOptions{MaxCached: 2})
+	ctx := context.Background()
+
+	for _, id := range []string{"msg-1", "msg-2", "msg-3", "msg-2"} {
+		msg := inlineMessage()
+		msg.ID = id
+		if _, err := r.Resolve(ctx, "grant-123", msg); err != nil {
+			t.Fatalf("Resolve(%s) error = %v", id, err)
+		}
+	}
+	if len(r.cache) != 2 || r.cache[inlineKey{"grant-123", "msg-1"}] != nil || len(r.order) != 2 {
+		t.Errorf("cache = %v, order = %v, want msg-2 and msg-3", r.cache, r.order)
+	}
+	if downloads["att-logo"] != 3 {
+		t.Errorf("logo downloaded %d times, want 3", downloads["att-logo"])
+	}
+
+	r.Forget("grant-123", "msg-2")
+	if len(r.cache) != 1 || len(r.order) != 1 || r.order[0].messageID != "msg-3" {
+		t.Errorf("after Forget: cache = %v, order = %v", r.cache, r.order)
+	}
+}
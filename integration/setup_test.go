@@ -19,6 +19,8 @@ type Provider struct {
 
 // TestConfig holds integration test configuration.
 type TestConfig struct {
+	// Client is the client configuration loaded by nylas.ConfigFromEnv.
+	Client          *nylas.Config
 	APIKey          string
 	ClientID        string
 	Providers       []Provider
@@ -35,12 +37,17 @@ var providerEnvVars = map[string]string{
 	"EWS":       "NYLAS_EWS_GRANT_ID",
 }
 
-// LoadConfig loads configuration from environment variables.
+// LoadConfig loads client settings with nylas.ConfigFromEnv, so the environment
+// and NYLAS_CONFIG_FILE profiles work as they do for NewClientFromEnv. Grant IDs
+// and the meeting link are test fixtures and are read from their own variables.
 func LoadConfig(t *testing.T) *TestConfig {
 	t.Helper()
 
-	apiKey := os.Getenv("NYLAS_API_KEY")
-	if apiKey == "" {
+	client, err := nylas.ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if client.APIKey == "" {
 		t.Skip("NYLAS_API_KEY not set, skipping integration tests")
 	}
 
@@ -57,8 +64,9 @@ func LoadConfig(t *testing.T) *TestConfig {
 	}
 
 	return &TestConfig{
-		APIKey:          apiKey,
-		ClientID:        os.Getenv("NYLAS_CLIENT_ID"),
+		Client:          client,
+		APIKey:          client.APIKey,
+		ClientID:        client.ClientID,
 		Providers:       providers,
 		TestMeetingLink: os.Getenv("NYLAS_TEST_MEETING_LINK"),
 	}
@@ -79,8 +87,7 @@ func RunForEachProvider(t *testing.T, cfg *TestConfig, testFn func(t *testing.T,
 func NewTestClient(t *testing.T, cfg *TestConfig) *nylas.Client {
 	t.Helper()
 
-	client, err := nylas.NewClient(
-		nylas.WithAPIKey(cfg.APIKey),
+	client, err := cfg.Client.NewClient(
 		nylas.WithTimeout(60*time.Second),
 		nylas.WithMaxRetries(5),            // More retries for rate limits
 		nylas.WithRetryWait(2*time.Second), // Longer base wait for 429s
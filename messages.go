@@ -3,6 +3,7 @@ package nylas
 import (
 	"context"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 
 	"github.com/mqasimca/nylas-go/messages"
@@ -16,9 +17,13 @@ import (
 func (s *MessagesService) List(ctx context.Context, grantID string, opts *messages.ListOptions) (*ListResponse[messages.Message], error) {
 	path := fmt.Sprintf("/v3/grants/%s/messages", grantID)
 
+	if err := s.client.validate(opts); err != nil {
+		return nil, newOpError("messages.List", grantID, "", err)
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("messages.List: %w", err)
+		return nil, newOpError("messages.List", grantID, "", err)
 	}
 
 	if opts != nil {
@@ -30,7 +35,7 @@ func (s *MessagesService) List(ctx context.Context, grantID string, opts *messag
 	var data []messages.Message
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("messages.List: %w", err)
+		return nil, newOpError("messages.List", grantID, "", err)
 	}
 
 	return &ListResponse[messages.Message]{
@@ -46,17 +51,33 @@ func (s *MessagesService) List(ctx context.Context, grantID string, opts *messag
 // The messageID is the unique identifier of the message to retrieve.
 // Returns ErrNotFound if the message does not exist.
 func (s *MessagesService) Get(ctx context.Context, grantID, messageID string) (*messages.Message, error) {
+	return s.get(ctx, "messages.Get", grantID, messageID, "")
+}
+
+// GetWithHeaders returns a single message with its Headers populated, for use
+// with the typed accessors such as Message.MessageID and Message.IsAutoResponse.
+func (s *MessagesService) GetWithHeaders(ctx context.Context, grantID, messageID string) (*messages.Message, error) {
+	return s.get(ctx, "messages.GetWithHeaders", grantID, messageID, messages.FieldsIncludeHeaders)
+}
+
+func (s *MessagesService) get(ctx context.Context, op, grantID, messageID, fields string) (*messages.Message, error) {
 	path := fmt.Sprintf("/v3/grants/%s/messages/%s", grantID, messageID)
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("messages.Get(%s): %w", messageID, err)
+		return nil, newOpError(op, grantID, messageID, err)
+	}
+
+	if fields != "" {
+		q := req.URL.Query()
+		q.Set("fields", fields)
+		req.URL.RawQuery = q.Encode()
 	}
 
 	var msg messages.Message
 	_, err = s.client.Do(req, &msg)
 	if err != nil {
-		return nil, fmt.Errorf("messages.Get(%s): %w", messageID, err)
+		return nil, newOpError(op, grantID, messageID, err)
 	}
 
 	return &msg, nil
@@ -67,24 +88,141 @@ func (s *MessagesService) Get(ctx context.Context, grantID, messageID string) (*
 // The grantID is the ID of the connected account to send from.
 // At minimum, the To field and either Subject or Body must be provided.
 // To schedule a message for later delivery, set SendAt to a future Unix timestamp.
+// Large attachments, and attachments with a Reader, are streamed as multipart/form-data;
+// see WithMultipartThreshold.
 // Returns the sent message with its assigned ID.
+// If the client has a SendPolicy, it is enforced before the request is made.
 func (s *MessagesService) Send(ctx context.Context, grantID string, send *messages.SendRequest) (*messages.Message, error) {
 	path := fmt.Sprintf("/v3/grants/%s/messages/send", grantID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, send)
+	send, err := s.client.applySendPolicy(ctx, grantID, send)
+	if err != nil {
+		return nil, newOpError("messages.Send", grantID, "", err)
+	}
+
+	var attachments []messages.AttachmentRequest
+	if send != nil {
+		attachments = send.Attachments
+	}
+
+	req, err := s.client.newAttachmentRequest(ctx, http.MethodPost, path, send, attachments)
 	if err != nil {
-		return nil, fmt.Errorf("messages.Send: %w", err)
+		return nil, newOpError("messages.Send", grantID, "", err)
 	}
 
 	var msg messages.Message
 	_, err = s.client.Do(req, &msg)
 	if err != nil {
-		return nil, fmt.Errorf("messages.Send: %w", err)
+		return nil, newOpError("messages.Send", grantID, "", err)
 	}
 
 	return &msg, nil
 }
 
+// SendRawMIME sends a complete RFC 5322 message built by the caller.
+//
+// Use it when the SDK cannot express the message, e.g. S/MIME signed mail, arbitrary
+// headers or calendar parts. The message is sent as a multipart/form-data "mime" part
+// and its recipients are taken from the To, Cc and Bcc headers.
+// If the client has a SendPolicy, it is enforced before the request is made.
+//
+// Example:
+//
+//	raw := []byte("From: me@example.com\r\nTo: you@example.com\r\nSubject: Hi\r\n\r\nHello")
+//	msg, err := client.Messages.SendRawMIME(ctx, grantID, raw)
+func (s *MessagesService) SendRawMIME(ctx context.Context, grantID string, raw []byte) (*messages.Message, error) {
+	path := fmt.Sprintf("/v3/grants/%s/messages/send", grantID)
+
+	if err := s.client.validate(messages.RawMIME(raw)); err != nil {
+		return nil, newOpError("messages.SendRawMIME", grantID, "", err)
+	}
+	if err := s.client.checkRawMIMESend(ctx, grantID, raw); err != nil {
+		return nil, newOpError("messages.SendRawMIME", grantID, "", err)
+	}
+
+	req, err := s.client.newMultipartRequest(ctx, http.MethodPost, path, func(w *multipart.Writer) error {
+		return w.WriteField("mime", string(raw))
+	})
+	if err != nil {
+		return nil, newOpError("messages.SendRawMIME", grantID, "", err)
+	}
+
+	var msg messages.Message
+	_, err = s.client.Do(req, &msg)
+	if err != nil {
+		return nil, newOpError("messages.SendRawMIME", grantID, "", err)
+	}
+
+	return &msg, nil
+}
+
+// GetRawMIME returns a message's exact original source, as stored by the provider,
+// parsed into its headers and MIME parts.
+//
+// The grantID is the ID of the connected account.
+// The messageID is the unique identifier of the message to retrieve.
+// Returns ErrNotFound if the message does not exist.
+func (s *MessagesService) GetRawMIME(ctx context.Context, grantID, messageID string) (*messages.MIMEMessage, error) {
+	msg, err := s.get(ctx, "messages.GetRawMIME", grantID, messageID, messages.FieldsRawMIME)
+	if err != nil {
+		return nil, err
+	}
+
+	mime, err := msg.MIME()
+	if err != nil {
+		return nil, newOpError("messages.GetRawMIME", grantID, messageID, err)
+	}
+
+	return mime, nil
+}
+
+// NewForward is messages.NewForward that also carries the original attachments
+// forward when opts.IncludeAttachments is set. Each attachment, inline images
+// included, is added with its file name, content type and content ID, so cid:
+// references in the quoted body keep working.
+//
+// The attachments are not downloaded here: each one is a Reader that downloads it
+// with ctx while the request is sent, streaming it into the multipart body (see
+// AttachmentRequest.Reader). The request can therefore be sent once, and is not
+// retried.
+//
+// The grantID is the ID of the connected account that owns msg.
+//
+// Example:
+//
+//	req, err := client.Messages.NewForward(ctx, grantID, msg, &messages.ReplyOptions{
+//	    To:                 []messages.Participant{{Email: "colleague@example.com"}},
+//	    Body:               "<p>FYI</p>",
+//	    IncludeAttachments: true,
+//	})
+//	sent, err := client.Messages.Send(ctx, grantID, req)
+func (s *MessagesService) NewForward(ctx context.Context, grantID string, msg *messages.Message, opts *messages.ReplyOptions) (*messages.SendRequest, error) {
+	req := messages.NewForward(msg, opts)
+	if opts == nil || !opts.IncludeAttachments {
+		return req, nil
+	}
+
+	for _, a := range msg.Attachments {
+		req.Attachments = append(req.Attachments, messages.AttachmentRequest{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			ContentID:   a.ContentID,
+			IsInline:    a.IsInline,
+			Reader: &attachmentReader{
+				s:            s.client.Attachments,
+				ctx:          ctx,
+				grantID:      grantID,
+				attachmentID: a.ID,
+				messageID:    msg.ID,
+				filename:     a.Filename,
+				size:         int64(a.Size),
+			},
+		})
+	}
+
+	return req, nil
+}
+
 // Update modifies a message's metadata (read status, starred, folders).
 //
 // The grantID is the ID of the connected account.
@@ -95,13 +233,13 @@ func (s *MessagesService) Update(ctx context.Context, grantID, messageID string,
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("messages.Update(%s): %w", messageID, err)
+		return nil, newOpError("messages.Update", grantID, messageID, err)
 	}
 
 	var msg messages.Message
 	_, err = s.client.Do(req, &msg)
 	if err != nil {
-		return nil, fmt.Errorf("messages.Update(%s): %w", messageID, err)
+		return nil, newOpError("messages.Update", grantID, messageID, err)
 	}
 
 	return &msg, nil
@@ -117,12 +255,12 @@ func (s *MessagesService) Delete(ctx context.Context, grantID, messageID string)
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("messages.Delete(%s): %w", messageID, err)
+		return newOpError("messages.Delete", grantID, messageID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("messages.Delete(%s): %w", messageID, err)
+		return newOpError("messages.Delete", grantID, messageID, err)
 	}
 
 	return nil
@@ -157,13 +295,13 @@ func (s *MessagesService) ListScheduled(ctx context.Context, grantID string) (me
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("messages.ListScheduled: %w", err)
+		return nil, newOpError("messages.ListScheduled", grantID, "", err)
 	}
 
 	var result messages.ScheduledMessagesList
 	_, err = s.client.Do(req, &result)
 	if err != nil {
-		return nil, fmt.Errorf("messages.ListScheduled: %w", err)
+		return nil, newOpError("messages.ListScheduled", grantID, "", err)
 	}
 
 	return result, nil
@@ -177,13 +315,13 @@ func (s *MessagesService) GetScheduled(ctx context.Context, grantID, scheduleID
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("messages.GetScheduled(%s): %w", scheduleID, err)
+		return nil, newOpError("messages.GetScheduled", grantID, scheduleID, err)
 	}
 
 	var msg messages.ScheduledMessage
 	_, err = s.client.Do(req, &msg)
 	if err != nil {
-		return nil, fmt.Errorf("messages.GetScheduled(%s): %w", scheduleID, err)
+		return nil, newOpError("messages.GetScheduled", grantID, scheduleID, err)
 	}
 
 	return &msg, nil
@@ -197,12 +335,12 @@ func (s *MessagesService) StopScheduled(ctx context.Context, grantID, scheduleID
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("messages.StopScheduled(%s): %w", scheduleID, err)
+		return newOpError("messages.StopScheduled", grantID, scheduleID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("messages.StopScheduled(%s): %w", scheduleID, err)
+		return newOpError("messages.StopScheduled", grantID, scheduleID, err)
 	}
 
 	return nil
@@ -212,18 +350,19 @@ func (s *MessagesService) StopScheduled(ctx context.Context, grantID, scheduleID
 //
 // This removes quoted text, signatures, and other noise to get the core message content.
 // Useful for AI processing, summarization, or displaying clean conversation threads.
+// The bodytext package offers an offline, deterministic alternative.
 func (s *MessagesService) Clean(ctx context.Context, grantID string, clean *messages.CleanRequest) ([]messages.CleanResponse, error) {
 	path := fmt.Sprintf("/v3/grants/%s/messages/clean", grantID)
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, clean)
 	if err != nil {
-		return nil, fmt.Errorf("messages.Clean: %w", err)
+		return nil, newOpError("messages.Clean", grantID, "", err)
 	}
 
 	var result []messages.CleanResponse
 	_, err = s.client.Do(req, &result)
 	if err != nil {
-		return nil, fmt.Errorf("messages.Clean: %w", err)
+		return nil, newOpError("messages.Clean", grantID, "", err)
 	}
 
 	return result, nil
@@ -0,0 +1,32 @@
+package messages
+
+import (
+	"context"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// EmbedOptions is an alias for common.EmbedOptions.
+type EmbedOptions = common.EmbedOptions
+
+// EmbedImages moves the data: images of r.Body, and local and remote images if
+// enabled in opts, into inline attachments referenced by cid: URLs. Identical images
+// are attached once. On error r is unchanged. See common.EmbedImages.
+//
+// Example:
+//
+//	req := &messages.SendRequest{
+//	    To:   []messages.Participant{{Email: "team@example.com"}},
+//	    Body: `<p>This week:</p><img src="data:image/png;base64,iVBORw0KGgo...">`,
+//	}
+//	if err := req.EmbedImages(ctx, nil); err != nil {
+//	    return err
+//	}
+func (r *SendRequest) EmbedImages(ctx context.Context, opts *EmbedOptions) error {
+	body, attachments, err := common.EmbedImages(ctx, r.Body, r.Attachments, opts)
+	if err != nil {
+		return err
+	}
+	r.Body, r.Attachments = body, attachments
+	return nil
+}
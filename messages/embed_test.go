@@ -0,0 +1,28 @@
+package messages
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSendRequest_EmbedImages(t *testing.T) {
+	req := &SendRequest{
+		To:   []Participant{{Email: "team@example.com"}},
+		Body: `<p>Chart:</p><img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=">`,
+	}
+	if err := req.EmbedImages(context.Background(), nil); err != nil {
+		t.Fatalf("EmbedImages() error = %v", err)
+	}
+	if len(req.Attachments) != 1 || !req.Attachments[0].IsInline {
+		t.Fatalf("attachments = %+v", req.Attachments)
+	}
+	if want := `src="cid:` + req.Attachments[0].ContentID + `"`; !strings.Contains(req.Body, want) {
+		t.Errorf("body = %s, want %s", req.Body, want)
+	}
+
+	bad := &SendRequest{Body: `<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=">`}
+	if err := bad.EmbedImages(context.Background(), &EmbedOptions{MaxSize: 4}); err == nil || bad.Attachments != nil || !strings.Contains(bad.Body, "data:") {
+		t.Errorf("EmbedImages() over the limit: error = %v, request = %+v", err, bad)
+	}
+}
@@ -0,0 +1,262 @@
+package messages
+
+import (
+	"strings"
+)
+
+// Field selections for the fields query parameter of Messages.List and Messages.Get.
+const (
+	// FieldsIncludeHeaders returns the standard fields plus Message.Headers.
+	FieldsIncludeHeaders = "include_headers"
+	// FieldsRawMIME returns only identifiers plus Message.RawMIME.
+	FieldsRawMIME = "raw_mime"
+)
+
+// SetIncludeHeaders requests Message.Headers for every listed message.
+func (o *ListOptions) SetIncludeHeaders() *ListOptions {
+	fields := FieldsIncludeHeaders
+	o.Fields = &fields
+	return o
+}
+
+// Header returns the first value of the named header, or "" if it is absent.
+// Names are matched case-insensitively. Headers are only returned when the
+// message was fetched with FieldsIncludeHeaders.
+func (m *Message) Header(name string) string {
+	for _, h := range m.Headers {
+		if strings.EqualFold(h.Name, name) {
+			return strings.TrimSpace(h.Value)
+		}
+	}
+	return ""
+}
+
+// HeaderValues returns every value of the named header, in message order.
+func (m *Message) HeaderValues(name string) []string {
+	var values []string
+	for _, h := range m.Headers {
+		if strings.EqualFold(h.Name, name) {
+			values = append(values, strings.TrimSpace(h.Value))
+		}
+	}
+	return values
+}
+
+// MessageID returns the Message-ID header without angle brackets, or "".
+// This is the RFC 5322 identifier used for threading, not the Nylas ID.
+func (m *Message) MessageID() string {
+	ids := parseMessageIDs(m.Header("Message-ID"))
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[0]
+}
+
+// InReplyTo returns the message IDs in the In-Reply-To header, without angle brackets.
+func (m *Message) InReplyTo() []string {
+	return parseMessageIDs(m.Header("In-Reply-To"))
+}
+
+// References returns the message IDs in the References header, oldest first,
+// without angle brackets.
+func (m *Message) References() []string {
+	return parseMessageIDs(m.Header("References"))
+}
+
+// ListUnsubscribe returns the URIs in the List-Unsubscribe header (RFC 2369),
+// e.g. "mailto:..." or "https://...", without angle brackets.
+func (m *Message) ListUnsubscribe() []string {
+	var uris []string
+	for _, part := range strings.Split(m.Header("List-Unsubscribe"), ",") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "<") && strings.HasSuffix(part, ">") {
+			uris = append(uris, strings.TrimSpace(part[1:len(part)-1]))
+		}
+	}
+	return uris
+}
+
+// ListUnsubscribePost returns the List-Unsubscribe-Post header (RFC 8058), or "".
+func (m *Message) ListUnsubscribePost() string {
+	return m.Header("List-Unsubscribe-Post")
+}
+
+// OneClickUnsubscribe returns the HTTPS URI to POST to for RFC 8058 one-click
+// unsubscribe, or "" if the message does not support it.
+func (m *Message) OneClickUnsubscribe() string {
+	if !strings.EqualFold(strings.ReplaceAll(m.ListUnsubscribePost(), " ", ""), "List-Unsubscribe=One-Click") {
+		return ""
+	}
+	for _, uri := range m.ListUnsubscribe() {
+		if strings.HasPrefix(strings.ToLower(uri), "https://") {
+			return uri
+		}
+	}
+	return ""
+}
+
+// AutoSubmitted returns the Auto-Submitted header (RFC 3834) without comments or
+// parameters, lower-cased, e.g. "auto-replied". It is "" if the header is absent.
+func (m *Message) AutoSubmitted() string {
+	value, _, _ := strings.Cut(stripComments(m.Header("Auto-Submitted")), ";")
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// IsAutoResponse reports whether the message was generated automatically, such as an
+// out-of-office reply or a bounce, based on Auto-Submitted and the common
+// non-standard headers X-Autoreply, X-Autorespond and "Precedence: auto_reply".
+// Bulk mail and messages that only ask not to be auto-answered, with
+// X-Auto-Response-Suppress, are not auto-responses.
+func (m *Message) IsAutoResponse() bool {
+	if as := m.AutoSubmitted(); as != "" && as != "no" {
+		return true
+	}
+	if m.Header("X-Autoreply") != "" || m.Header("X-Autorespond") != "" {
+		return true
+	}
+	return strings.EqualFold(strings.TrimSpace(m.Header("Precedence")), "auto_reply")
+}
+
+// AuthenticationResults parses every Authentication-Results header (RFC 8601),
+// in message order. The topmost header is normally added by the receiving server.
+func (m *Message) AuthenticationResults() []AuthenticationResults {
+	var out []AuthenticationResults
+	for _, value := range m.HeaderValues("Authentication-Results") {
+		if res, ok := ParseAuthenticationResults(value); ok {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// AuthenticationResults is a parsed Authentication-Results header.
+type AuthenticationResults struct {
+	// AuthServID identifies the server that performed the checks, e.g. "mx.google.com".
+	AuthServID string
+	// Results are the individual method results, in header order.
+	Results []AuthResult
+}
+
+// AuthResult is the outcome of one authentication method.
+type AuthResult struct {
+	// Method is the lower-cased method name, e.g. "spf", "dkim", "dmarc" or "arc".
+	Method string
+	// Result is the lower-cased result, e.g. "pass", "fail", "softfail" or "none".
+	Result string
+	// Reason is the optional reason= value.
+	Reason string
+	// Properties maps "ptype.property" to its value, e.g. "header.d" to "example.com".
+	Properties map[string]string
+}
+
+// Result returns the result of the first entry for method, or "" if there is none.
+func (r AuthenticationResults) Result(method string) string {
+	for _, res := range r.Results {
+		if res.Method == strings.ToLower(method) {
+			return res.Result
+		}
+	}
+	return ""
+}
+
+// ParseAuthenticationResults parses an Authentication-Results header value.
+// Comments are ignored. It reports false if the value has no authserv-id.
+func ParseAuthenticationResults(value string) (AuthenticationResults, bool) {
+	parts := strings.Split(stripComments(value), ";")
+	fields := strings.Fields(parts[0])
+	if len(fields) == 0 {
+		return AuthenticationResults{}, false
+	}
+	out := AuthenticationResults{AuthServID: fields[0]}
+
+	for _, part := range parts[1:] {
+		tokens := strings.Fields(part)
+		if len(tokens) == 0 {
+			continue
+		}
+		method, result, ok := strings.Cut(tokens[0], "=")
+		if !ok {
+			continue
+		}
+		method, _, _ = strings.Cut(method, "/")
+		res := AuthResult{Method: strings.ToLower(method), Result: strings.ToLower(result), Properties: map[string]string{}}
+		for _, tok := range tokens[1:] {
+			key, val, ok := strings.Cut(tok, "=")
+			if !ok {
+				continue
+			}
+			val = strings.Trim(val, `"`)
+			if strings.EqualFold(key, "reason") {
+				res.Reason = val
+				continue
+			}
+			res.Properties[strings.ToLower(key)] = val
+		}
+		out.Results = append(out.Results, res)
+	}
+	return out, true
+}
+
+// SetHeader sets a custom header on the outgoing message, replacing any custom
+// header with the same name.
+func (r *SendRequest) SetHeader(name, value string) *SendRequest {
+	headers := r.CustomHeaders[:0:0]
+	for _, h := range r.CustomHeaders {
+		if !strings.EqualFold(h.Name, name) {
+			headers = append(headers, h)
+		}
+	}
+	r.CustomHeaders = append(headers, Header{Name: name, Value: value})
+	return r
+}
+
+// AddHeader adds a custom header to the outgoing message, keeping existing values.
+func (r *SendRequest) AddHeader(name, value string) *SendRequest {
+	r.CustomHeaders = append(r.CustomHeaders, Header{Name: name, Value: value})
+	return r
+}
+
+// parseMessageIDs returns the msg-ids in a Message-ID, In-Reply-To or References
+// value without angle brackets. Bare IDs without brackets are accepted.
+func parseMessageIDs(value string) []string {
+	value = stripComments(value)
+	var ids []string
+	for {
+		start := strings.IndexByte(value, '<')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(value[start:], '>')
+		if end < 0 {
+			break
+		}
+		if id := strings.TrimSpace(value[start+1 : start+end]); id != "" {
+			ids = append(ids, id)
+		}
+		value = value[start+end+1:]
+	}
+	if len(ids) == 0 {
+		ids = strings.Fields(value)
+	}
+	return ids
+}
+
+// stripComments removes RFC 5322 parenthesised comments, which may nest.
+func stripComments(s string) string {
+	if !strings.Contains(s, "(") {
+		return s
+	}
+	var b strings.Builder
+	depth := 0
+	for _, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
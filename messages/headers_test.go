@@ -0,0 +1,132 @@
+package messages
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMessage_ThreadingHeaders(t *testing.T) {
+	m := &Message{Headers: []Header{
+		{Name: "message-id", Value: " <abc@mail.example.com> "},
+		{Name: "In-Reply-To", Value: "<parent@example.com> (original)"},
+		{Name: "References", Value: "<root@example.com>\r\n <parent@example.com>"},
+		{Name: "Received", Value: "from a"},
+		{Name: "Received", Value: "from b"},
+	}}
+
+	if got := m.MessageID(); got != "abc@mail.example.com" {
+		t.Errorf("MessageID() = %q", got)
+	}
+	if got := m.InReplyTo(); !reflect.DeepEqual(got, []string{"parent@example.com"}) {
+		t.Errorf("InReplyTo() = %q", got)
+	}
+	if got := m.References(); !reflect.DeepEqual(got, []string{"root@example.com", "parent@example.com"}) {
+		t.Errorf("References() = %q", got)
+	}
+	if got := m.HeaderValues("received"); !reflect.DeepEqual(got, []string{"from a", "from b"}) {
+		t.Errorf("HeaderValues() = %q", got)
+	}
+	if got := m.Header("X-Missing"); got != "" {
+		t.Errorf("Header() = %q, want empty", got)
+	}
+}
+
+func TestMessage_ListUnsubscribe(t *testing.T) {
+	m := &Message{Headers: []Header{
+		{Name: "List-Unsubscribe", Value: "<mailto:leave@example.com?subject=unsubscribe>, <https://example.com/u/123>"},
+		{Name: "List-Unsubscribe-Post", Value: "List-Unsubscribe=One-Click"},
+	}}
+
+	want := []string{"mailto:leave@example.com?subject=unsubscribe", "https://example.com/u/123"}
+	if got := m.ListUnsubscribe(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ListUnsubscribe() = %q", got)
+	}
+	if got := m.OneClickUnsubscribe(); got != "https://example.com/u/123" {
+		t.Errorf("OneClickUnsubscribe() = %q", got)
+	}
+
+	m.Headers = m.Headers[:1]
+	if got := m.OneClickUnsubscribe(); got != "" {
+		t.Errorf("OneClickUnsubscribe() without List-Unsubscribe-Post = %q", got)
+	}
+}
+
+func TestMessage_IsAutoResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []Header
+		want    bool
+	}{
+		{"none", nil, false},
+		{"auto-submitted no", []Header{{Name: "Auto-Submitted", Value: "no"}}, false},
+		{"auto-replied", []Header{{Name: "Auto-Submitted", Value: "Auto-Replied (vacation)"}}, true},
+		{"x-autoreply", []Header{{Name: "X-Autoreply", Value: "yes"}}, true},
+		{"x-autorespond", []Header{{Name: "X-Autorespond", Value: "vacation"}}, true},
+		{"precedence auto_reply", []Header{{Name: "Precedence", Value: "Auto_Reply"}}, true},
+		{"precedence bulk", []Header{{Name: "Precedence", Value: "bulk"}}, false},
+		{"precedence junk", []Header{{Name: "Precedence", Value: "junk"}}, false},
+		{"precedence list", []Header{{Name: "Precedence", Value: "list"}}, false},
+		{"suppress all", []Header{{Name: "X-Auto-Response-Suppress", Value: "All"}}, false},
+		{"suppress oof", []Header{{Name: "X-Auto-Response-Suppress", Value: "DR, OOF, AutoReply"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Message{Headers: tt.headers}
+			if got := m.IsAutoResponse(); got != tt.want {
+				t.Errorf("IsAutoResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	m := &Message{Headers: []Header{{Name: "Auto-Submitted", Value: "Auto-Replied (vacation)"}}}
+	if got := m.AutoSubmitted(); got != "auto-replied" {
+		t.Errorf("AutoSubmitted() = %q", got)
+	}
+}
+
+func TestParseAuthenticationResults(t *testing.T) {
+	m := &Message{Headers: []Header{{Name: "Authentication-Results", Value: "mx.google.com;\r\n" +
+		" dkim=pass header.i=@example.com header.s=sel1 (comment; with semicolon);\r\n" +
+		" spf=softfail (domain of transitioning x@example.com) smtp.mailfrom=x@example.com;\r\n" +
+		" dmarc=FAIL reason=\"policy\" header.from=example.com"}}}
+
+	results := m.AuthenticationResults()
+	if len(results) != 1 {
+		t.Fatalf("AuthenticationResults() len = %d, want 1", len(results))
+	}
+	res := results[0]
+	if res.AuthServID != "mx.google.com" || len(res.Results) != 3 {
+		t.Fatalf("result = %+v", res)
+	}
+	if res.Result("DKIM") != "pass" || res.Result("spf") != "softfail" || res.Result("dmarc") != "fail" || res.Result("arc") != "" {
+		t.Errorf("results = %+v", res.Results)
+	}
+	if got := res.Results[0].Properties["header.i"]; got != "@example.com" {
+		t.Errorf("dkim header.i = %q", got)
+	}
+	if res.Results[2].Reason != "policy" {
+		t.Errorf("dmarc reason = %q", res.Results[2].Reason)
+	}
+
+	if _, ok := ParseAuthenticationResults("  (only a comment) "); ok {
+		t.Error("ParseAuthenticationResults() of empty header ok = true")
+	}
+}
+
+func TestSendRequest_SetHeader(t *testing.T) {
+	r := (&SendRequest{}).
+		AddHeader("X-Tag", "a").
+		AddHeader("X-Tag", "b").
+		SetHeader("X-Ticket", "1").
+		SetHeader("x-ticket", "2")
+
+	want := []Header{{Name: "X-Tag", Value: "a"}, {Name: "X-Tag", Value: "b"}, {Name: "x-ticket", Value: "2"}}
+	if !reflect.DeepEqual(r.CustomHeaders, want) {
+		t.Errorf("CustomHeaders = %+v", r.CustomHeaders)
+	}
+
+	opts := (&ListOptions{}).SetIncludeHeaders()
+	if opts.Values()["fields"] != FieldsIncludeHeaders {
+		t.Errorf("fields = %v", opts.Values()["fields"])
+	}
+}
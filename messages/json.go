@@ -0,0 +1,31 @@
+package messages
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes m, keeping fields this package does not declare in Extra.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type alias Message
+	extra, err := common.UnmarshalExtra(data, (*alias)(m))
+	m.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes m together with the fields kept in Extra.
+func (m Message) MarshalJSON() ([]byte, error) {
+	type alias Message
+	return common.MarshalExtra(alias(m), m.Extra)
+}
+
+// UnmarshalJSON decodes s, keeping fields this package does not declare in Extra.
+func (s *ScheduledMessage) UnmarshalJSON(data []byte) error {
+	type alias ScheduledMessage
+	extra, err := common.UnmarshalExtra(data, (*alias)(s))
+	s.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes s together with the fields kept in Extra.
+func (s ScheduledMessage) MarshalJSON() ([]byte, error) {
+	type alias ScheduledMessage
+	return common.MarshalExtra(alias(s), s.Extra)
+}
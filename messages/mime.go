@@ -0,0 +1,253 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+// maxMIMEDepth bounds how deeply nested multipart bodies are parsed.
+const maxMIMEDepth = 32
+
+// RawMIME is a complete RFC 5322 message built by the caller, as sent by
+// MessagesService.SendRawMIME.
+type RawMIME []byte
+
+// Recipients returns the addresses in the To, Cc and Bcc headers.
+func (r RawMIME) Recipients() ([]string, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	return headerAddresses(msg.Header, "To", "Cc", "Bcc")
+}
+
+// MIMEMessage is a message parsed from its raw RFC 5322 source.
+type MIMEMessage struct {
+	// Raw is the exact message source as stored by the provider.
+	Raw []byte
+	// Header is the top-level message header.
+	Header mail.Header
+	// Root is the message body as a tree of MIME parts.
+	Root *MIMEPart
+}
+
+// MIMEPart is a single entity in a MIME message.
+type MIMEPart struct {
+	// Header is the part header. For the root part it is the message header.
+	Header textproto.MIMEHeader
+	// MediaType is the lower-cased media type, e.g. "text/plain" or "multipart/mixed".
+	MediaType string
+	// Params holds the Content-Type parameters, e.g. "charset" or "boundary".
+	Params map[string]string
+	// Disposition is the lower-cased Content-Disposition, e.g. "attachment" or "inline".
+	Disposition string
+	// Filename is the decoded file name from Content-Disposition or Content-Type, if any.
+	Filename string
+	// ContentID is the Content-ID without angle brackets, if any.
+	ContentID string
+	// Body is the content with its transfer encoding removed. It is nil for multipart parts.
+	// Text is not converted from its charset.
+	Body []byte
+	// Parts are the child parts of a multipart part.
+	Parts []*MIMEPart
+}
+
+// IsMultipart reports whether p is a multipart container.
+func (p *MIMEPart) IsMultipart() bool {
+	return strings.HasPrefix(p.MediaType, "multipart/")
+}
+
+// IsAttachment reports whether p is a leaf part carrying a file rather than body text.
+func (p *MIMEPart) IsAttachment() bool {
+	if p.IsMultipart() {
+		return false
+	}
+	return p.Disposition == "attachment" || p.Filename != ""
+}
+
+// ParseMIME parses a raw RFC 5322 message into its headers and MIME part tree.
+//
+// Example:
+//
+//	msg, err := messages.ParseMIME(raw)
+//	if err != nil {
+//	    return err
+//	}
+//	log.Printf("%s: %s", msg.Header.Get("Subject"), msg.TextBody())
+func ParseMIME(raw []byte) (*MIMEMessage, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("messages: parse MIME: %w", err)
+	}
+	root, err := parsePart(textproto.MIMEHeader(msg.Header), msg.Body, 0)
+	if err != nil {
+		return nil, fmt.Errorf("messages: parse MIME: %w", err)
+	}
+	return &MIMEMessage{Raw: raw, Header: msg.Header, Root: root}, nil
+}
+
+// DecodeRawMIME decodes the base64url raw_mime value returned by the API.
+// Padded, unpadded and standard base64 are all accepted.
+func DecodeRawMIME(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	for _, enc := range []*base64.Encoding{base64.URLEncoding, base64.RawURLEncoding, base64.StdEncoding, base64.RawStdEncoding} {
+		if b, err := enc.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+	return nil, errors.New("messages: raw_mime is not valid base64")
+}
+
+// MIME decodes and parses RawMIME. The message must have been fetched with
+// the raw_mime field selected, e.g. with MessagesService.GetRawMIME.
+func (m *Message) MIME() (*MIMEMessage, error) {
+	if m.RawMIME == "" {
+		return nil, errors.New("messages: message has no raw_mime; fetch it with fields=raw_mime")
+	}
+	raw, err := DecodeRawMIME(m.RawMIME)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMIME(raw)
+}
+
+// Walk calls fn for every part in depth-first order, starting with the root.
+func (m *MIMEMessage) Walk(fn func(*MIMEPart)) {
+	var walk func(*MIMEPart)
+	walk = func(p *MIMEPart) {
+		fn(p)
+		for _, child := range p.Parts {
+			walk(child)
+		}
+	}
+	if m.Root != nil {
+		walk(m.Root)
+	}
+}
+
+// TextBody returns the first text/plain part that is not an attachment, or "".
+func (m *MIMEMessage) TextBody() string {
+	return m.firstBody("text/plain")
+}
+
+// HTMLBody returns the first text/html part that is not an attachment, or "".
+func (m *MIMEMessage) HTMLBody() string {
+	return m.firstBody("text/html")
+}
+
+// Attachments returns the parts that carry files, in message order.
+func (m *MIMEMessage) Attachments() []*MIMEPart {
+	var parts []*MIMEPart
+	m.Walk(func(p *MIMEPart) {
+		if p.IsAttachment() {
+			parts = append(parts, p)
+		}
+	})
+	return parts
+}
+
+func (m *MIMEMessage) firstBody(mediaType string) string {
+	var body []byte
+	found := false
+	m.Walk(func(p *MIMEPart) {
+		if !found && p.MediaType == mediaType && !p.IsAttachment() {
+			body, found = p.Body, true
+		}
+	})
+	return string(body)
+}
+
+func parsePart(header textproto.MIMEHeader, body io.Reader, depth int) (*MIMEPart, error) {
+	p := &MIMEPart{Header: header, MediaType: "text/plain", Params: map[string]string{}}
+
+	if ct := header.Get("Content-Type"); ct != "" {
+		if mediaType, params, err := mime.ParseMediaType(ct); err == nil {
+			p.MediaType, p.Params = mediaType, params
+		}
+	}
+	if cd := header.Get("Content-Disposition"); cd != "" {
+		disposition, params, err := mime.ParseMediaType(cd)
+		if err == nil {
+			p.Disposition = disposition
+			p.Filename = params["filename"]
+		}
+	}
+	if p.Filename == "" {
+		p.Filename = p.Params["name"]
+	}
+	p.Filename = decodeHeaderWords(p.Filename)
+	p.ContentID = strings.Trim(strings.TrimSpace(header.Get("Content-ID")), "<>")
+
+	if p.IsMultipart() && p.Params["boundary"] != "" {
+		if depth >= maxMIMEDepth {
+			return nil, fmt.Errorf("multipart nesting deeper than %d", maxMIMEDepth)
+		}
+		r := multipart.NewReader(body, p.Params["boundary"])
+		for {
+			part, err := r.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			child, err := parsePart(part.Header, part, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			p.Parts = append(p.Parts, child)
+		}
+		return p, nil
+	}
+
+	var err error
+	p.Body, err = io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	return p, err
+}
+
+// decodeTransfer removes a Content-Transfer-Encoding from r.
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+func decodeHeaderWords(s string) string {
+	dec := new(mime.WordDecoder)
+	if decoded, err := dec.DecodeHeader(s); err == nil {
+		return decoded
+	}
+	return s
+}
+
+// headerAddresses returns the addresses in the named address-list headers.
+func headerAddresses(h mail.Header, names ...string) ([]string, error) {
+	var out []string
+	for _, name := range names {
+		if h.Get(name) == "" {
+			continue
+		}
+		list, err := h.AddressList(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		for _, addr := range list {
+			out = append(out, addr.Address)
+		}
+	}
+	return out, nil
+}
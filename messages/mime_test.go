@@ -0,0 +1,103 @@
+package messages
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+const testMIME = "From: Sender <sender@example.com>\r\n" +
+	"To: a@example.com, B <b@example.com>\r\n" +
+	"Cc: c@example.com\r\n" +
+	"Subject: Report\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=outer\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=inner\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"caf=C3=A9\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html\r\n" +
+	"\r\n" +
+	"<p>cafe</p>\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: application/pdf; name=\"ignored.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"=?UTF-8?Q?r=C3=A9sum=C3=A9.pdf?=\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"Content-ID: <doc1>\r\n" +
+	"\r\n" +
+	"JVBERi0x\r\n" +
+	"LjQ=\r\n" +
+	"--outer--\r\n"
+
+func TestParseMIME(t *testing.T) {
+	msg, err := ParseMIME([]byte(testMIME))
+	if err != nil {
+		t.Fatalf("ParseMIME() error = %v", err)
+	}
+	if got := msg.Header.Get("Subject"); got != "Report" {
+		t.Errorf("Subject = %q", got)
+	}
+	if msg.Root.MediaType != "multipart/mixed" || len(msg.Root.Parts) != 2 {
+		t.Fatalf("Root = %s with %d parts", msg.Root.MediaType, len(msg.Root.Parts))
+	}
+	if got := msg.TextBody(); got != "café" {
+		t.Errorf("TextBody() = %q, want café", got)
+	}
+	if got := msg.HTMLBody(); got != "<p>cafe</p>" {
+		t.Errorf("HTMLBody() = %q", got)
+	}
+
+	atts := msg.Attachments()
+	if len(atts) != 1 {
+		t.Fatalf("Attachments() len = %d, want 1", len(atts))
+	}
+	if atts[0].Filename != "résumé.pdf" || atts[0].ContentID != "doc1" || string(atts[0].Body) != "%PDF-1.4" {
+		t.Errorf("attachment = %q %q %q", atts[0].Filename, atts[0].ContentID, atts[0].Body)
+	}
+}
+
+func TestMessage_MIME(t *testing.T) {
+	m := &Message{RawMIME: base64.RawURLEncoding.EncodeToString([]byte(testMIME))}
+	msg, err := m.MIME()
+	if err != nil {
+		t.Fatalf("MIME() error = %v", err)
+	}
+	if string(msg.Raw) != testMIME {
+		t.Error("Raw does not match the original source")
+	}
+
+	if _, err := (&Message{}).MIME(); err == nil {
+		t.Error("MIME() without raw_mime error = nil")
+	}
+	if _, err := DecodeRawMIME("not base64!"); err == nil {
+		t.Error("DecodeRawMIME() error = nil")
+	}
+}
+
+func TestRawMIME(t *testing.T) {
+	recipients, err := RawMIME(testMIME).Recipients()
+	if err != nil {
+		t.Fatalf("Recipients() error = %v", err)
+	}
+	if len(recipients) != 3 || recipients[1] != "b@example.com" {
+		t.Errorf("Recipients() = %v", recipients)
+	}
+
+	if err := RawMIME(testMIME).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	for _, raw := range []string{"not a message", "Subject: x\r\n\r\nbody", "To: <<bad\r\n\r\nbody"} {
+		if err := RawMIME(raw).Validate(); !errors.Is(err, common.ErrValidation) {
+			t.Errorf("Validate(%q) error = %v, want ErrValidation", raw, err)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package messages
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mqasimca/nylas-go/bodytext"
+	"github.com/mqasimca/nylas-go/drafts"
+)
+
+// QuoteStyle selects how the original message is quoted in a reply or forward.
+type QuoteStyle int
+
+// Quote styles.
+const (
+	// QuoteHTML quotes the original in an HTML blockquote. It is the default.
+	QuoteHTML QuoteStyle = iota
+	// QuoteText quotes the original as plain text with "> " line prefixes.
+	QuoteText
+	// QuoteNone leaves the original out.
+	QuoteNone
+)
+
+// ReplyOptions configures NewReply, NewReplyAll and NewForward.
+// A nil *ReplyOptions uses the defaults.
+type ReplyOptions struct {
+	// Self lists the replying account's own addresses. They are never added as
+	// recipients, and replying to a message they sent goes to its original recipients.
+	Self []string
+	// Body is the new content placed above the quoted original. It is HTML unless
+	// QuoteStyle is QuoteText.
+	Body string
+	// QuoteStyle selects how the original is quoted.
+	QuoteStyle QuoteStyle
+	// To sets the recipients of a forward. It is ignored by replies.
+	To []Participant
+	// From overrides the sender address.
+	From []Participant
+	// Location is the time zone for the date in the attribution line. Defaults to UTC.
+	Location *time.Location
+	// IncludeAttachments carries the original attachments forward. NewForward cannot
+	// download them itself; use MessagesService.NewForward.
+	IncludeAttachments bool
+}
+
+// NewReply returns a request replying to the sender of msg, or to its Reply-To
+// addresses if it has any. The subject gets a single "Re:" prefix and the original
+// is quoted below opts.Body.
+//
+// Example:
+//
+//	req := messages.NewReply(msg, &messages.ReplyOptions{
+//	    Self: []string{"me@example.com"},
+//	    Body: "<p>Thanks, received.</p>",
+//	})
+//	sent, err := client.Messages.Send(ctx, grantID, req)
+func NewReply(msg *Message, opts *ReplyOptions) *SendRequest {
+	opts = replyDefaults(opts)
+	self := addressSet(opts.Self)
+
+	var to []Participant
+	if fromSelf(msg, self) {
+		to = msg.To
+	} else {
+		to = replyTargets(msg)
+	}
+
+	return &SendRequest{
+		To:               uniqueParticipants(self, to),
+		From:             opts.From,
+		Subject:          ReplySubject(msg.Subject),
+		Body:             quoteReply(msg, opts),
+		ReplyToMessageID: msg.ID,
+	}
+}
+
+// NewReplyAll returns a request replying to the sender and every other recipient
+// of msg, minus the replying account's own addresses and duplicates. The original
+// CC recipients stay on CC.
+func NewReplyAll(msg *Message, opts *ReplyOptions) *SendRequest {
+	opts = replyDefaults(opts)
+	self := addressSet(opts.Self)
+
+	var to []Participant
+	if !fromSelf(msg, self) {
+		to = append(to, replyTargets(msg)...)
+	}
+	to = uniqueParticipants(self, to, msg.To)
+
+	seen := addressSet(opts.Self)
+	for _, p := range to {
+		seen[strings.ToLower(p.Email)] = true
+	}
+
+	return &SendRequest{
+		To:               to,
+		CC:               uniqueParticipants(seen, msg.CC),
+		From:             opts.From,
+		Subject:          ReplySubject(msg.Subject),
+		Body:             quoteReply(msg, opts),
+		ReplyToMessageID: msg.ID,
+	}
+}
+
+// NewForward returns a request forwarding msg to opts.To. The subject gets a single
+// "Fwd:" prefix and the original headers and body are included below opts.Body.
+// Attachments are not included; use MessagesService.NewForward to download them.
+func NewForward(msg *Message, opts *ReplyOptions) *SendRequest {
+	opts = replyDefaults(opts)
+	return &SendRequest{
+		To:      opts.To,
+		From:    opts.From,
+		Subject: ForwardSubject(msg.Subject),
+		Body:    quoteForward(msg, opts),
+	}
+}
+
+// DraftRequest returns the request as a drafts.CreateRequest, for saving a reply or
+// forward as a draft instead of sending it. SendAt and UseReplyTo have no draft
+// equivalent and are dropped.
+//
+// Example:
+//
+//	draft, err := client.Drafts.Create(ctx, grantID, messages.NewReply(msg, opts).DraftRequest())
+func (r *SendRequest) DraftRequest() *drafts.CreateRequest {
+	return &drafts.CreateRequest{
+		Subject:          r.Subject,
+		Body:             r.Body,
+		From:             r.From,
+		To:               r.To,
+		CC:               r.CC,
+		BCC:              r.BCC,
+		ReplyTo:          r.ReplyTo,
+		ReplyToMessageID: r.ReplyToMessageID,
+		TrackingOptions:  r.TrackingOptions,
+		Attachments:      r.Attachments,
+		CustomHeaders:    r.CustomHeaders,
+		Metadata:         r.Metadata,
+	}
+}
+
+// subjectPrefix matches leading reply and forward prefixes in common languages,
+// including counted forms such as "Re[2]:".
+var subjectPrefix = regexp.MustCompile(`(?i)^\s*(re|fwd?|aw|wg|sv|vs|antw|tr)\s*(\[\d+\])?\s*:\s*`)
+
+// NormalizeSubject strips any number of leading reply and forward prefixes,
+// e.g. "RE: Fwd: Re[2]: Invoice" becomes "Invoice".
+func NormalizeSubject(subject string) string {
+	for {
+		loc := subjectPrefix.FindStringIndex(subject)
+		if loc == nil {
+			return strings.TrimSpace(subject)
+		}
+		subject = subject[loc[1]:]
+	}
+}
+
+// ReplySubject returns subject with exactly one "Re: " prefix.
+func ReplySubject(subject string) string {
+	return "Re: " + NormalizeSubject(subject)
+}
+
+// ForwardSubject returns subject with exactly one "Fwd: " prefix.
+func ForwardSubject(subject string) string {
+	return "Fwd: " + NormalizeSubject(subject)
+}
+
+func replyDefaults(opts *ReplyOptions) *ReplyOptions {
+	if opts == nil {
+		opts = &ReplyOptions{}
+	}
+	if opts.Location == nil {
+		o := *opts
+		o.Location = time.UTC
+		opts = &o
+	}
+	return opts
+}
+
+// replyTargets returns where replies to msg should go: Reply-To if set, otherwise From.
+func replyTargets(msg *Message) []Participant {
+	if len(msg.ReplyTo) > 0 {
+		return msg.ReplyTo
+	}
+	return msg.From
+}
+
+func fromSelf(msg *Message, self map[string]bool) bool {
+	for _, p := range msg.From {
+		if self[strings.ToLower(p.Email)] {
+			return true
+		}
+	}
+	return false
+}
+
+func addressSet(addrs []string) map[string]bool {
+	set := make(map[string]bool, len(addrs))
+	for _, a := range addrs {
+		set[strings.ToLower(strings.TrimSpace(a))] = true
+	}
+	return set
+}
+
+// uniqueParticipants concatenates lists, dropping addresses in exclude and repeats.
+func uniqueParticipants(exclude map[string]bool, lists ...[]Participant) []Participant {
+	seen := make(map[string]bool, len(exclude))
+	for k := range exclude {
+		seen[k] = true
+	}
+	var out []Participant
+	for _, list := range lists {
+		for _, p := range list {
+			key := strings.ToLower(strings.TrimSpace(p.Email))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func quoteReply(msg *Message, opts *ReplyOptions) string {
+	attribution := fmt.Sprintf("On %s, %s wrote:", formatQuoteDate(msg, opts.Location), formatParticipants(msg.From))
+	switch opts.QuoteStyle {
+	case QuoteText:
+		return joinNonEmpty("\n\n", opts.Body, attribution+"\n"+quoteLines(bodytext.ToText(msg.Body, nil)))
+	case QuoteNone:
+		return opts.Body
+	default:
+		quote := `<div class="gmail_quote"><div>` + html.EscapeString(attribution) + `</div>` +
+			`<blockquote style="margin:0 0 0 .8ex;border-left:1px solid #ccc;padding-left:1ex">` +
+			bodyHTML(msg.Body) + `</blockquote></div>`
+		return joinNonEmpty("<br><br>", opts.Body, quote)
+	}
+}
+
+func quoteForward(msg *Message, opts *ReplyOptions) string {
+	lines := []string{
+		"---------- Forwarded message ---------",
+		"From: " + formatParticipants(msg.From),
+		"Date: " + formatQuoteDate(msg, opts.Location),
+		"Subject: " + msg.Subject,
+		"To: " + formatParticipants(msg.To),
+	}
+	if len(msg.CC) > 0 {
+		lines = append(lines, "Cc: "+formatParticipants(msg.CC))
+	}
+
+	switch opts.QuoteStyle {
+	case QuoteText:
+		return joinNonEmpty("\n\n", opts.Body, strings.Join(lines, "\n")+"\n\n"+bodytext.ToText(msg.Body, nil))
+	case QuoteNone:
+		return opts.Body
+	default:
+		escaped := make([]string, len(lines))
+		for i, l := range lines {
+			escaped[i] = html.EscapeString(l)
+		}
+		quote := `<div class="gmail_quote"><div>` + strings.Join(escaped, "<br>") + `</div><br>` + bodyHTML(msg.Body) + `</div>`
+		return joinNonEmpty("<br><br>", opts.Body, quote)
+	}
+}
+
+func formatQuoteDate(msg *Message, loc *time.Location) string {
+	if msg.Date == 0 {
+		return "an unknown date"
+	}
+	return time.Unix(msg.Date, 0).In(loc).Format("Mon, Jan 2, 2006 at 3:04 PM MST")
+}
+
+func formatParticipants(list []Participant) string {
+	parts := make([]string, 0, len(list))
+	for _, p := range list {
+		if p.Name != "" {
+			parts = append(parts, fmt.Sprintf("%s <%s>", p.Name, p.Email))
+		} else {
+			parts = append(parts, p.Email)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, sep)
+}
+
+func quoteLines(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, l := range lines {
+		if l == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bodyHTML returns body as HTML, escaping plain text bodies.
+func bodyHTML(body string) string {
+	if bodytext.IsHTML(body) {
+		return body
+	}
+	return strings.ReplaceAll(html.EscapeString(body), "\n", "<br>")
+}
@@ -0,0 +1,136 @@
+package messages
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func replyFixture() *Message {
+	return &Message{
+		ID:      "msg-1",
+		Subject: "RE: Fwd: Re[2]: Quarterly numbers",
+		From:    []Participant{{Name: "Alice", Email: "alice@example.com"}},
+		ReplyTo: []Participant{{Email: "team@example.com"}},
+		To:      []Participant{{Email: "me@example.com"}, {Email: "bob@example.com"}},
+		CC:      []Participant{{Email: "Carol@example.com"}, {Email: "BOB@example.com"}},
+		Date:    1700000000,
+		Body:    "<p>Numbers &amp; charts</p>",
+	}
+}
+
+func emails(ps []Participant) []string {
+	var out []string
+	for _, p := range ps {
+		out = append(out, strings.ToLower(p.Email))
+	}
+	return out
+}
+
+func TestNormalizeSubject(t *testing.T) {
+	tests := map[string]string{
+		"Hello":                        "Hello",
+		"Re: Hello":                    "Hello",
+		"RE: Fwd: Re[2]: Hello":        "Hello",
+		"AW: WG:  Hello ":              "Hello",
+		"Regarding: the plan":          "Regarding: the plan",
+		"Fw : Re:Hello":                "Hello",
+		"Report: Re: not at the start": "Report: Re: not at the start",
+	}
+	for in, want := range tests {
+		if got := NormalizeSubject(in); got != want {
+			t.Errorf("NormalizeSubject(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := ReplySubject("Re: Re: Hi"); got != "Re: Hi" {
+		t.Errorf("ReplySubject() = %q", got)
+	}
+	if got := ForwardSubject("Fwd: Hi"); got != "Fwd: Hi" {
+		t.Errorf("ForwardSubject() = %q", got)
+	}
+}
+
+func TestNewReply(t *testing.T) {
+	req := NewReply(replyFixture(), &ReplyOptions{Self: []string{"ME@example.com"}, Body: "<p>Thanks</p>"})
+
+	if got := emails(req.To); !reflect.DeepEqual(got, []string{"team@example.com"}) {
+		t.Errorf("To = %v, want the Reply-To address", got)
+	}
+	if req.Subject != "Re: Quarterly numbers" || req.ReplyToMessageID != "msg-1" {
+		t.Errorf("Subject = %q, ReplyToMessageID = %q", req.Subject, req.ReplyToMessageID)
+	}
+	if !strings.HasPrefix(req.Body, "<p>Thanks</p><br><br>") ||
+		!strings.Contains(req.Body, "On Tue, Nov 14, 2023 at 10:13 PM UTC, Alice &lt;alice@example.com&gt; wrote:") ||
+		!strings.Contains(req.Body, "<blockquote") || !strings.Contains(req.Body, "<p>Numbers &amp; charts</p>") {
+		t.Errorf("Body = %q", req.Body)
+	}
+}
+
+func TestNewReply_ToOwnMessage(t *testing.T) {
+	msg := replyFixture()
+	msg.From = []Participant{{Email: "me@example.com"}}
+	msg.ReplyTo = nil
+	msg.To = []Participant{{Email: "bob@example.com"}}
+
+	req := NewReply(msg, &ReplyOptions{Self: []string{"me@example.com"}})
+	if got := emails(req.To); !reflect.DeepEqual(got, []string{"bob@example.com"}) {
+		t.Errorf("To = %v, want the original recipients", got)
+	}
+}
+
+func TestNewReplyAll(t *testing.T) {
+	req := NewReplyAll(replyFixture(), &ReplyOptions{Self: []string{"me@example.com"}})
+
+	if got := emails(req.To); !reflect.DeepEqual(got, []string{"team@example.com", "bob@example.com"}) {
+		t.Errorf("To = %v", got)
+	}
+	if got := emails(req.CC); !reflect.DeepEqual(got, []string{"carol@example.com"}) {
+		t.Errorf("CC = %v, want duplicates and self removed", got)
+	}
+}
+
+func TestNewReply_TextQuote(t *testing.T) {
+	msg := replyFixture()
+	msg.Body = "<div>Line one</div><div>Line two</div>"
+
+	req := NewReply(msg, &ReplyOptions{Body: "Got it", QuoteStyle: QuoteText})
+	want := "Got it\n\nOn Tue, Nov 14, 2023 at 10:13 PM UTC, Alice <alice@example.com> wrote:\n> Line one\n> Line two"
+	if req.Body != want {
+		t.Errorf("Body = %q, want %q", req.Body, want)
+	}
+}
+
+func TestNewForward(t *testing.T) {
+	to := []Participant{{Email: "dave@example.com"}}
+	msg := replyFixture()
+	msg.Body = "a < b & c\nsecond line"
+
+	req := NewForward(msg, &ReplyOptions{To: to, Body: "<p>FYI</p>"})
+	if !reflect.DeepEqual(req.To, to) || req.Subject != "Fwd: Quarterly numbers" || req.ReplyToMessageID != "" {
+		t.Errorf("req = %+v", req)
+	}
+	for _, want := range []string{
+		"---------- Forwarded message ---------<br>From: Alice &lt;alice@example.com&gt;",
+		"Subject: RE: Fwd: Re[2]: Quarterly numbers",
+		"Cc: Carol@example.com, BOB@example.com",
+		"a &lt; b &amp; c<br>second line",
+	} {
+		if !strings.Contains(req.Body, want) {
+			t.Errorf("Body = %q, missing %q", req.Body, want)
+		}
+	}
+	if len(req.Attachments) != 0 {
+		t.Errorf("Attachments = %v, want none", req.Attachments)
+	}
+}
+
+func TestSendRequest_DraftRequest(t *testing.T) {
+	req := NewReply(replyFixture(), nil)
+	req.SetHeader("X-Tag", "1")
+
+	draft := req.DraftRequest()
+	if draft.Subject != req.Subject || draft.Body != req.Body || draft.ReplyToMessageID != "msg-1" ||
+		!reflect.DeepEqual(draft.To, req.To) || !reflect.DeepEqual(draft.CustomHeaders, req.CustomHeaders) {
+		t.Errorf("DraftRequest() = %+v", draft)
+	}
+}
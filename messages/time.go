@@ -0,0 +1,39 @@
+package messages
+
+import (
+	"time"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// SetReceivedAfter filters messages received after t.
+func (o *ListOptions) SetReceivedAfter(t time.Time) *ListOptions {
+	o.ReceivedAfter = common.UnixPtr(t)
+	return o
+}
+
+// SetReceivedBefore filters messages received before t.
+func (o *ListOptions) SetReceivedBefore(t time.Time) *ListOptions {
+	o.ReceivedBefore = common.UnixPtr(t)
+	return o
+}
+
+// SetReceivedBetween filters messages received after start and before end.
+//
+// Example:
+//
+//	opts := (&messages.ListOptions{}).SetReceivedBetween(time.Now().Add(-24*time.Hour), time.Now())
+func (o *ListOptions) SetReceivedBetween(start, end time.Time) *ListOptions {
+	return o.SetReceivedAfter(start).SetReceivedBefore(end)
+}
+
+// SetSendAt schedules the message for delivery at t.
+func (r *SendRequest) SetSendAt(t time.Time) *SendRequest {
+	r.SendAt = common.UnixPtr(t)
+	return r
+}
+
+// SetSendAfter schedules the message for delivery d from now.
+func (r *SendRequest) SetSendAfter(d time.Duration) *SendRequest {
+	return r.SetSendAt(time.Now().Add(d))
+}
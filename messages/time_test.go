@@ -0,0 +1,48 @@
+package messages
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestListOptions_TimeSetters(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	opts := (&ListOptions{}).SetReceivedBetween(start, end)
+	if *opts.ReceivedAfter != 1704067200 || *opts.ReceivedBefore != 1704153600 {
+		t.Errorf("received = %d..%d", *opts.ReceivedAfter, *opts.ReceivedBefore)
+	}
+	if err := opts.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	if err := (&ListOptions{}).SetReceivedAfter(end).SetReceivedBefore(start).Validate(); !errors.Is(err, common.ErrValidation) {
+		t.Errorf("Validate() reversed range error = %v, want ErrValidation", err)
+	}
+
+	millis := start.UnixMilli()
+	if err := (&ListOptions{ReceivedAfter: &millis}).Validate(); !errors.Is(err, common.ErrValidation) {
+		t.Errorf("Validate() milliseconds error = %v, want ErrValidation", err)
+	}
+	if err := (&ListOptions{}).SetReceivedAfter(time.Time{}).Validate(); !errors.Is(err, common.ErrValidation) {
+		t.Errorf("Validate() zero time error = %v, want ErrValidation", err)
+	}
+}
+
+func TestSendRequest_TimeSetters(t *testing.T) {
+	at := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	req := (&SendRequest{}).SetSendAt(at)
+	if *req.SendAt != at.Unix() {
+		t.Errorf("SendAt = %d, want %d", *req.SendAt, at.Unix())
+	}
+
+	before := time.Now().Add(time.Hour).Unix()
+	req.SetSendAfter(time.Hour)
+	if *req.SendAt < before || *req.SendAt > before+5 {
+		t.Errorf("SendAt = %d, want about %d", *req.SendAt, before)
+	}
+}
@@ -1,6 +1,7 @@
 package messages
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/mqasimca/nylas-go/common"
@@ -15,6 +16,9 @@ type Attachment = common.Attachment
 // AttachmentRequest is an alias for common.AttachmentRequest.
 type AttachmentRequest = common.AttachmentRequest
 
+// Header is an alias for common.Header.
+type Header = common.Header
+
 // TrackingOptions is an alias for common.TrackingOptions.
 type TrackingOptions = common.TrackingOptions
 
@@ -58,6 +62,17 @@ type Message struct {
 	CreatedAt int64 `json:"created_at,omitempty"`
 	// Object is the object type, always "message".
 	Object string `json:"object,omitempty"`
+	// Metadata contains custom key-value pairs; see common.Metadata for typed access.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Headers contains the message headers. It is only returned when requested
+	// with FieldsIncludeHeaders; see Header and the typed accessors.
+	Headers []Header `json:"headers,omitempty"`
+	// RawMIME is the base64url-encoded RFC 5322 source of the message. It is only
+	// returned when requested with the raw_mime field; see MIME to parse it.
+	RawMIME string `json:"raw_mime,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ListOptions specifies options for listing messages.
@@ -93,10 +108,14 @@ type ListOptions struct {
 	ReceivedBefore *int64 `json:"received_before,omitempty"`
 	// HasAttachment filters messages with or without attachments.
 	HasAttachment *bool `json:"has_attachment,omitempty"`
-	// Fields specifies which fields to return (comma-separated).
+	// Fields selects the fields to return, e.g. FieldsIncludeHeaders.
 	Fields *string `json:"fields,omitempty"`
 	// SearchQueryNative is a provider-specific search query (Gmail, Microsoft, etc.).
+	// Package search builds one for any provider.
 	SearchQueryNative *string `json:"search_query_native,omitempty"`
+	// MetadataPair filters by metadata key-value pair ("key:value" format). Only the
+	// keys key1 to key5 can be filtered on; see common.MetadataPair.
+	MetadataPair *string `json:"metadata_pair,omitempty"`
 }
 
 // Values converts ListOptions to URL query parameters.
@@ -156,6 +175,9 @@ func (o *ListOptions) Values() map[string]any {
 	if o.SearchQueryNative != nil {
 		v["search_query_native"] = *o.SearchQueryNative
 	}
+	if o.MetadataPair != nil {
+		v["metadata_pair"] = *o.MetadataPair
+	}
 	return v
 }
 
@@ -185,6 +207,10 @@ type SendRequest struct {
 	UseReplyTo *bool `json:"use_draft,omitempty"`
 	// Attachments is the list of files to attach.
 	Attachments []AttachmentRequest `json:"attachments,omitempty"`
+	// CustomHeaders adds custom headers to the outgoing message.
+	CustomHeaders []Header `json:"custom_headers,omitempty"`
+	// Metadata attaches custom key-value pairs to the sent message.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // UpdateRequest represents a request to update a message's metadata.
@@ -195,6 +221,8 @@ type UpdateRequest struct {
 	Starred *bool `json:"starred,omitempty"`
 	// Folders moves the message to these folder IDs.
 	Folders []string `json:"folders,omitempty"`
+	// Metadata replaces the message's custom key-value pairs.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // ScheduledMessage represents a message scheduled for future delivery.
@@ -205,6 +233,9 @@ type ScheduledMessage struct {
 	Status string `json:"status"`
 	// CloseTime is the Unix timestamp when the message will be sent.
 	CloseTime int64 `json:"close_time,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ScheduledMessagesList represents a list of scheduled messages.
@@ -0,0 +1,81 @@
+package messages
+
+import (
+	"bytes"
+	"net/mail"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// Validate checks the request for problems the API would reject.
+func (r *SendRequest) Validate() error {
+	v := &common.ValidationError{}
+	if len(r.To) == 0 {
+		v.Add("to", "at least one recipient is required")
+	}
+	common.CheckParticipants(v, "to", r.To)
+	common.CheckParticipants(v, "from", r.From)
+	common.CheckParticipants(v, "cc", r.CC)
+	common.CheckParticipants(v, "bcc", r.BCC)
+	common.CheckParticipants(v, "reply_to", r.ReplyTo)
+	if r.SendAt != nil {
+		common.CheckUnixTime(v, "send_at", *r.SendAt)
+	}
+	common.CheckAttachments(v, "attachments", r.Attachments)
+	common.CheckHeaders(v, "custom_headers", r.CustomHeaders)
+	common.CheckMetadata(v, "metadata", r.Metadata)
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	for i, id := range r.Folders {
+		if id == "" {
+			v.Add(common.IndexPath("folders", i), "must not be empty")
+		}
+	}
+	common.CheckMetadata(v, "metadata", r.Metadata)
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *CleanRequest) Validate() error {
+	v := &common.ValidationError{}
+	if len(r.MessageID) == 0 {
+		v.Add("message_id", "at least one message ID is required")
+	}
+	for i, id := range r.MessageID {
+		if id == "" {
+			v.Add(common.IndexPath("message_id", i), "must not be empty")
+		}
+	}
+	return v.Err()
+}
+
+// Validate checks that the time filters are Unix timestamps in seconds and that
+// MetadataPair names a filterable key.
+func (o *ListOptions) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckOptionalTimeRange(v, "received_after", o.ReceivedAfter, "received_before", o.ReceivedBefore)
+	common.CheckMetadataPair(v, "metadata_pair", o.MetadataPair)
+	return v.Err()
+}
+
+// Validate checks that the message headers parse and name at least one recipient.
+func (r RawMIME) Validate() error {
+	v := &common.ValidationError{}
+	msg, err := mail.ReadMessage(bytes.NewReader(r))
+	if err != nil {
+		v.Add("mime", "is not a valid RFC 5322 message: %v", err)
+		return v.Err()
+	}
+	recipients, err := headerAddresses(msg.Header, "To", "Cc", "Bcc")
+	switch {
+	case err != nil:
+		v.Add("mime", "has invalid recipient headers: %v", err)
+	case len(recipients) == 0:
+		v.Add("mime", "must have a To, Cc or Bcc header")
+	}
+	return v.Err()
+}
@@ -0,0 +1,61 @@
+package messages
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestMessageRequests_Validate(t *testing.T) {
+	sendAt := int64(-1)
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"send valid", (&SendRequest{To: []Participant{{Email: "a@example.com"}}, Subject: "Hi"}).Validate(), nil},
+		{"send no recipients", (&SendRequest{Subject: "Hi"}).Validate(), []string{"to"}},
+		{"send bad fields", (&SendRequest{
+			To:            []Participant{{Email: "a@example.com"}},
+			CC:            []Participant{{Name: "No Email"}},
+			SendAt:        &sendAt,
+			Attachments:   []AttachmentRequest{{Filename: "a.pdf"}},
+			CustomHeaders: []Header{{Name: "X-Id", Value: "1\n2"}},
+		}).Validate(), []string{"cc[0].email", "send_at", "attachments[0].content", "custom_headers[0].value"}},
+		{"send metadata", (&SendRequest{
+			To:       []Participant{{Email: "a@example.com"}},
+			Metadata: map[string]string{"key1": "crm-42", strings.Repeat("k", 41): "x"},
+		}).Validate(), []string{"metadata." + strings.Repeat("k", 41)}},
+		{"update", (&UpdateRequest{Folders: []string{"inbox", ""}}).Validate(), []string{"folders[1]"}},
+		{"update metadata", (&UpdateRequest{Metadata: map[string]string{"key2": strings.Repeat("v", 501)}}).Validate(), []string{"metadata.key2"}},
+		{"list metadata_pair", (&ListOptions{MetadataPair: ptrStr("key1:crm-42")}).Validate(), nil},
+		{"list metadata_pair key", (&ListOptions{MetadataPair: ptrStr("crm_id:42")}).Validate(), []string{"metadata_pair"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldPaths(t, tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() paths = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func fieldPaths(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verr *common.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *common.ValidationError", err)
+	}
+	paths := make([]string, len(verr.Fields))
+	for i, f := range verr.Fields {
+		paths[i] = f.Path
+	}
+	return paths
+}
@@ -2,11 +2,15 @@ package nylas
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/mqasimca/nylas-go/common"
 	"github.com/mqasimca/nylas-go/messages"
 )
 
@@ -133,6 +137,128 @@ func TestMessagesService_Get(t *testing.T) {
 	}
 }
 
+func TestMessagesService_GetWithHeaders(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fields"); got != messages.FieldsIncludeHeaders {
+			t.Errorf("fields = %q, want %q", got, messages.FieldsIncludeHeaders)
+		}
+		_, _ = w.Write([]byte(`{"data": {"id": "msg-1", "headers": [{"name": "Message-ID", "value": "<t-42@tickets.example.com>"}]}, "request_id": "req-1"}`))
+	})
+
+	msg, err := client.Messages.GetWithHeaders(context.Background(), "grant-123", "msg-1")
+	if err != nil {
+		t.Fatalf("GetWithHeaders() error = %v", err)
+	}
+	if got := msg.MessageID(); got != "t-42@tickets.example.com" {
+		t.Errorf("MessageID() = %q", got)
+	}
+}
+
+func TestMessagesService_Metadata(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if md, _ := body["metadata"].(map[string]any); md["key1"] != "crm-42" {
+				t.Errorf("metadata = %v", body["metadata"])
+			}
+			_, _ = w.Write([]byte(`{"data": {"id": "msg-1", "metadata": {"key1": "crm-42"}}}`))
+		case http.MethodGet:
+			if got := r.URL.Query().Get("metadata_pair"); got != "key1:crm-42" {
+				t.Errorf("metadata_pair = %q", got)
+			}
+			_, _ = w.Write([]byte(`{"data": [{"id": "msg-1", "metadata": {"key1": "crm-42"}}]}`))
+		}
+	})
+
+	md := common.Metadata{}
+	md.SetString("key1", "crm-42")
+	sent, err := client.Messages.Send(context.Background(), "grant-123", &messages.SendRequest{
+		To:       []messages.Participant{{Email: "a@example.com"}},
+		Metadata: md,
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if sent.Metadata["key1"] != "crm-42" {
+		t.Errorf("Metadata = %v", sent.Metadata)
+	}
+
+	resp, err := client.Messages.List(context.Background(), "grant-123", &messages.ListOptions{
+		MetadataPair: Ptr(common.MetadataPair("key1", "crm-42")),
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].Metadata["key1"] != "crm-42" {
+		t.Errorf("List() = %+v", resp.Data)
+	}
+
+	_, err = client.Messages.List(context.Background(), "grant-123", &messages.ListOptions{MetadataPair: Ptr("crm_id:42")})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("List() error = %v, want ErrValidation for an unfilterable key", err)
+	}
+}
+
+func TestMessagesService_NewForward(t *testing.T) {
+	downloads := 0
+	var parts map[string]string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/grants/grant-123/attachments/att-1/download":
+			downloads++
+			_, _ = w.Write([]byte("hello"))
+		case "/v3/grants/grant-123/messages/send":
+			if parts != nil {
+				// The second send is aborted mid-body.
+				_, _ = io.Copy(io.Discard, r.Body)
+				return
+			}
+			parts = readMultipart(t, r)
+			_, _ = w.Write([]byte(`{"data": {"id": "msg-2"}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	})
+
+	msg := &messages.Message{
+		ID:          "msg-1",
+		Subject:     "Logo",
+		Attachments: []messages.Attachment{{ID: "att-1", Filename: "logo.png", ContentType: "image/png", ContentID: "logo", IsInline: true, Size: 5}},
+	}
+	ctx := context.Background()
+	req, err := client.Messages.NewForward(ctx, "grant-123", msg, &messages.ReplyOptions{
+		To:                 []messages.Participant{{Email: "dave@example.com"}},
+		IncludeAttachments: true,
+	})
+	if err != nil {
+		t.Fatalf("NewForward() error = %v", err)
+	}
+	if req.Subject != "Fwd: Logo" || len(req.Attachments) != 1 {
+		t.Fatalf("req = %+v", req)
+	}
+	a := req.Attachments[0]
+	if a.Filename != "logo.png" || a.ContentID != "logo" || !a.IsInline || a.Content != "" || a.Reader == nil {
+		t.Errorf("attachment = %+v", a)
+	}
+	if downloads != 0 {
+		t.Errorf("downloads before Send = %d, want 0", downloads)
+	}
+
+	if _, err := client.Messages.Send(ctx, "grant-123", req); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if downloads != 1 || parts["logo"] != "hello" {
+		t.Errorf("downloads = %d, inline part = %q, want the attachment streamed once", downloads, parts["logo"])
+	}
+	if _, err := client.Messages.Send(ctx, "grant-123", req); !errors.Is(err, ErrAttachmentConsumed) {
+		t.Errorf("second Send() error = %v, want ErrAttachmentConsumed", err)
+	}
+}
+
 func TestMessagesService_Send(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -182,6 +308,62 @@ func TestMessagesService_Send(t *testing.T) {
 	}
 }
 
+func TestMessagesService_SendRawMIME(t *testing.T) {
+	raw := []byte("From: me@example.com\r\nTo: you@example.com\r\nSubject: Signed\r\n\r\nHello")
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v3/grants/grant-123/messages/send" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.FormValue("mime"); got != string(raw) {
+			t.Errorf("mime = %q, want %q", got, raw)
+		}
+		_, _ = w.Write([]byte(`{"data": {"id": "msg-new", "subject": "Signed"}, "request_id": "req-1"}`))
+	})
+
+	msg, err := client.Messages.SendRawMIME(context.Background(), "grant-123", raw)
+	if err != nil {
+		t.Fatalf("SendRawMIME() error = %v", err)
+	}
+	if msg.ID != "msg-new" {
+		t.Errorf("ID = %q, want msg-new", msg.ID)
+	}
+
+	_, err = client.Messages.SendRawMIME(context.Background(), "grant-123", []byte("Subject: no recipients\r\n\r\nHi"))
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("SendRawMIME() without recipients error = %v, want ErrValidation", err)
+	}
+}
+
+func TestMessagesService_GetRawMIME(t *testing.T) {
+	raw := "From: me@example.com\r\nTo: you@example.com\r\nSubject: Original\r\n\r\nExact body"
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fields"); got != "raw_mime" {
+			t.Errorf("fields = %q, want raw_mime", got)
+		}
+		resp := map[string]any{"data": map[string]any{
+			"id":       "msg-1",
+			"raw_mime": base64.URLEncoding.EncodeToString([]byte(raw)),
+		}}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	msg, err := client.Messages.GetRawMIME(context.Background(), "grant-123", "msg-1")
+	if err != nil {
+		t.Fatalf("GetRawMIME() error = %v", err)
+	}
+	if string(msg.Raw) != raw {
+		t.Errorf("Raw = %q, want %q", msg.Raw, raw)
+	}
+	if got := msg.Header.Get("Subject"); got != "Original" {
+		t.Errorf("Subject = %q, want Original", got)
+	}
+	if got := msg.TextBody(); got != "Exact body" {
+		t.Errorf("TextBody() = %q, want Exact body", got)
+	}
+}
+
 func TestMessagesService_Update(t *testing.T) {
 	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPut {
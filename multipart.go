@@ -0,0 +1,206 @@
+package nylas
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// DefaultMultipartThreshold is the total attachment size above which messages and
+// drafts are sent as multipart/form-data instead of JSON with base64 content.
+// The Nylas API rejects JSON requests larger than 3 MB.
+const DefaultMultipartThreshold = 3 << 20
+
+// WithMultipartThreshold sets the total attachment size, in bytes, above which
+// Messages.Send, Drafts.Create and Drafts.Update switch from JSON to
+// multipart/form-data. Attachments with a Reader always use multipart.
+func WithMultipartThreshold(n int64) Option {
+	return func(c *Client) { c.multipartThreshold = n }
+}
+
+// newMultipartRequest creates a multipart/form-data request whose parts are written by build.
+// The body is buffered so the request can be retried, audited and recorded in dry-run mode.
+func (c *Client) newMultipartRequest(ctx context.Context, method, path string, build func(w *multipart.Writer) error) (*http.Request, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := build(w); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return c.newFormRequest(ctx, method, path, bytes.NewReader(buf.Bytes()), w.FormDataContentType())
+}
+
+// newStreamingMultipartRequest is newMultipartRequest for large bodies: parts are
+// written by build as the request is sent, so nothing is buffered. If replayable,
+// build is run again for each retry; otherwise the body is read once and the
+// request is not retried.
+func (c *Client) newStreamingMultipartRequest(ctx context.Context, method, path string, replayable bool, build func(w *multipart.Writer) error) (*http.Request, error) {
+	form := multipart.NewWriter(io.Discard)
+	boundary := form.Boundary()
+	stream := func() io.ReadCloser {
+		pr, pw := io.Pipe()
+		w := multipart.NewWriter(pw)
+		_ = w.SetBoundary(boundary)
+		return &multipartStream{pr: pr, start: func() {
+			err := build(w)
+			if err == nil {
+				err = w.Close()
+			}
+			_ = pw.CloseWithError(err)
+		}}
+	}
+	req, err := c.newFormRequest(ctx, method, path, stream(), form.FormDataContentType())
+	if err != nil {
+		return nil, err
+	}
+	if replayable {
+		req.GetBody = func() (io.ReadCloser, error) { return stream(), nil }
+	}
+	return req, nil
+}
+
+func (c *Client) newFormRequest(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Request, error) {
+	u, err := url.Parse(c.BaseURL + path)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	return req, nil
+}
+
+// multipartStream is a request body that starts writing parts on the first Read,
+// so no goroutine is left blocked if the request is never sent.
+type multipartStream struct {
+	once  sync.Once
+	pr    *io.PipeReader
+	start func()
+}
+
+func (s *multipartStream) Read(p []byte) (int, error) {
+	s.once.Do(func() { go s.start() })
+	return s.pr.Read(p)
+}
+
+func (s *multipartStream) Close() error {
+	return s.pr.Close()
+}
+
+// newAttachmentRequest creates a request for a message or draft body. It is sent as
+// JSON unless the attachments are too large or streamed from readers, in which case
+// it is sent as multipart/form-data with the body, minus attachments, in a "message"
+// part and one file part per attachment.
+func (c *Client) newAttachmentRequest(ctx context.Context, method, path string, body any, attachments []common.AttachmentRequest) (*http.Request, error) {
+	if !c.useMultipart(attachments) {
+		return c.NewRequest(ctx, method, path, body)
+	}
+
+	if err := c.validate(body); err != nil {
+		return nil, err
+	}
+	message, err := marshalWithoutAttachments(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attachments held in memory can be written again for a retry; a Reader can
+	// only be read once.
+	replayable := !slices.ContainsFunc(attachments, func(a common.AttachmentRequest) bool { return a.Reader != nil })
+	return c.newStreamingMultipartRequest(ctx, method, path, replayable, func(w *multipart.Writer) error {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", `form-data; name="message"`)
+		h.Set("Content-Type", "application/json")
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write(message); err != nil {
+			return err
+		}
+
+		for i, a := range attachments {
+			if err := writeAttachmentPart(w, i, a); err != nil {
+				return fmt.Errorf("nylas: attachment %q: %w", a.Filename, err)
+			}
+		}
+		return nil
+	})
+}
+
+// useMultipart reports whether attachments must be sent as multipart/form-data.
+func (c *Client) useMultipart(attachments []common.AttachmentRequest) bool {
+	var total int64
+	for _, a := range attachments {
+		if a.Reader != nil {
+			return true
+		}
+		total += int64(base64.StdEncoding.DecodedLen(len(a.Content)))
+	}
+	return len(attachments) > 0 && total > c.multipartThreshold
+}
+
+// marshalWithoutAttachments encodes body as a JSON object without its attachments field.
+func marshalWithoutAttachments(body any) ([]byte, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "attachments")
+	return json.Marshal(fields)
+}
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// writeAttachmentPart writes a as a file part. Inline attachments use their content ID
+// as the part name so HTML bodies can reference them with cid: URLs.
+func writeAttachmentPart(w *multipart.Writer, i int, a common.AttachmentRequest) error {
+	name := fmt.Sprintf("file%d", i)
+	if a.ContentID != "" {
+		name = a.ContentID
+	}
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(name), quoteEscaper.Replace(a.Filename)))
+	h.Set("Content-Type", contentType)
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return err
+	}
+
+	r := a.Reader
+	if r == nil {
+		r = base64.NewDecoder(base64.StdEncoding, strings.NewReader(a.Content))
+	}
+	_, err = io.Copy(part, r)
+	return err
+}
@@ -0,0 +1,182 @@
+package nylas
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/drafts"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// readMultipart returns the parts of a multipart/form-data request by form name.
+func readMultipart(t *testing.T, r *http.Request) map[string]string {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("Content-Type = %q, want multipart/form-data", r.Header.Get("Content-Type"))
+	}
+	parts := map[string]string{}
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("NextPart error: %v", err)
+		}
+		b, _ := io.ReadAll(p)
+		parts[p.FormName()] = string(b)
+		if p.FileName() != "" {
+			parts[p.FormName()+":filename"] = p.FileName()
+		}
+	}
+}
+
+func TestMessagesService_Send_MultipartReader(t *testing.T) {
+	var parts map[string]string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		parts = readMultipart(t, r)
+		_, _ = w.Write([]byte(`{"data": {"id": "msg-1"}, "request_id": "req-1"}`))
+	})
+
+	pdf := strings.Repeat("%PDF-1.4 contract ", 1000)
+	req := &messages.SendRequest{
+		To:      []messages.Participant{{Email: "legal@example.com"}},
+		Subject: "Contract",
+		Attachments: []messages.AttachmentRequest{
+			{Filename: "contract.pdf", ContentType: "application/pdf", Reader: strings.NewReader(pdf)},
+			{Filename: "logo.png", ContentType: "image/png", Content: base64.StdEncoding.EncodeToString([]byte("png")), ContentID: "logo", IsInline: true},
+		},
+	}
+	if _, err := client.Messages.Send(context.Background(), "grant-123", req); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	var message map[string]any
+	if err := json.Unmarshal([]byte(parts["message"]), &message); err != nil {
+		t.Fatalf("message part = %q: %v", parts["message"], err)
+	}
+	if message["subject"] != "Contract" || message["attachments"] != nil {
+		t.Errorf("message part = %v, want subject and no attachments", message)
+	}
+	if parts["file0"] != pdf || parts["file0:filename"] != "contract.pdf" {
+		t.Errorf("file0 = %d bytes named %q", len(parts["file0"]), parts["file0:filename"])
+	}
+	if parts["logo"] != "png" {
+		t.Errorf("inline part = %q, want decoded content under its content ID", parts["logo"])
+	}
+}
+
+func TestDraftsService_MultipartThreshold(t *testing.T) {
+	var contentType string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		_, _ = w.Write([]byte(`{"data": {"id": "draft-1"}, "request_id": "req-1"}`))
+	})
+	client.multipartThreshold = 10
+	ctx := context.Background()
+
+	small := &drafts.CreateRequest{Attachments: []drafts.AttachmentRequest{
+		{Filename: "a.txt", Content: base64.StdEncoding.EncodeToString([]byte("tiny"))},
+	}}
+	if _, err := client.Drafts.Create(ctx, "grant-123", small); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if contentType != "application/json" {
+		t.Errorf("small attachment Content-Type = %q, want application/json", contentType)
+	}
+
+	large := &drafts.UpdateRequest{Attachments: []drafts.AttachmentRequest{
+		{Filename: "b.txt", Content: base64.StdEncoding.EncodeToString([]byte("more than ten bytes"))},
+	}}
+	if _, err := client.Drafts.Update(ctx, "grant-123", "draft-1", large); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !strings.HasPrefix(contentType, "multipart/form-data") {
+		t.Errorf("large attachment Content-Type = %q, want multipart/form-data", contentType)
+	}
+}
+
+func TestStreamingRequest_NotRetried(t *testing.T) {
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	client.MaxRetries = 3
+	client.RetryWait = 0
+
+	_, err := client.Messages.Send(context.Background(), "grant-123", &messages.SendRequest{
+		To:          []messages.Participant{{Email: "a@example.com"}},
+		Attachments: []messages.AttachmentRequest{{Filename: "a.bin", Reader: strings.NewReader("data")}},
+	})
+	if err == nil {
+		t.Fatal("Send() error = nil, want error")
+	}
+	if calls != 1 {
+		t.Errorf("server calls = %d, want 1 for a streamed body", calls)
+	}
+}
+
+func TestBufferedRequest_RetriedWithBody(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"id": "msg-1"}, "request_id": "req-1"}`))
+	})
+	client.MaxRetries = 1
+	client.RetryWait = 0
+
+	_, err := client.Messages.Send(context.Background(), "grant-123", &messages.SendRequest{
+		To: []messages.Participant{{Email: "a@example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(bodies) != 2 || bodies[1] == "" || bodies[0] != bodies[1] {
+		t.Errorf("request bodies = %q, want the same body on retry", bodies)
+	}
+}
+
+func TestMultipartContent_RetriedWithBody(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"id": "msg-1"}, "request_id": "req-1"}`))
+	})
+	client.MaxRetries = 1
+	client.RetryWait = 0
+	client.multipartThreshold = 10
+
+	// Content over the threshold is sent as multipart but, unlike a Reader, can be
+	// written again for a retry.
+	_, err := client.Messages.Send(context.Background(), "grant-123", &messages.SendRequest{
+		To:          []messages.Participant{{Email: "a@example.com"}},
+		Attachments: []messages.AttachmentRequest{{Filename: "a.txt", Content: base64.StdEncoding.EncodeToString([]byte("more than ten bytes"))}},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(bodies) != 2 || !strings.Contains(bodies[1], "more than ten bytes") || bodies[0] != bodies[1] {
+		t.Errorf("request bodies = %q, want the same multipart body on retry", bodies)
+	}
+}
@@ -14,7 +14,7 @@ func (s *NotetakersService) List(ctx context.Context, grantID string, opts *note
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("notetakers.List: %w", err)
+		return nil, newOpError("notetakers.List", grantID, "", err)
 	}
 
 	if opts != nil {
@@ -26,7 +26,7 @@ func (s *NotetakersService) List(ctx context.Context, grantID string, opts *note
 	var data []notetakers.Notetaker
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("notetakers.List: %w", err)
+		return nil, newOpError("notetakers.List", grantID, "", err)
 	}
 
 	return &ListResponse[notetakers.Notetaker]{
@@ -42,13 +42,13 @@ func (s *NotetakersService) Get(ctx context.Context, grantID, notetakerID string
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("notetakers.Get(%s): %w", notetakerID, err)
+		return nil, newOpError("notetakers.Get", grantID, notetakerID, err)
 	}
 
 	var nt notetakers.Notetaker
 	_, err = s.client.Do(req, &nt)
 	if err != nil {
-		return nil, fmt.Errorf("notetakers.Get(%s): %w", notetakerID, err)
+		return nil, newOpError("notetakers.Get", grantID, notetakerID, err)
 	}
 
 	return &nt, nil
@@ -61,13 +61,13 @@ func (s *NotetakersService) Create(ctx context.Context, grantID string, createRe
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, createReq)
 	if err != nil {
-		return nil, fmt.Errorf("notetakers.Create: %w", err)
+		return nil, newOpError("notetakers.Create", grantID, "", err)
 	}
 
 	var nt notetakers.Notetaker
 	_, err = s.client.Do(req, &nt)
 	if err != nil {
-		return nil, fmt.Errorf("notetakers.Create: %w", err)
+		return nil, newOpError("notetakers.Create", grantID, "", err)
 	}
 
 	return &nt, nil
@@ -79,12 +79,12 @@ func (s *NotetakersService) Cancel(ctx context.Context, grantID, notetakerID str
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("notetakers.Cancel(%s): %w", notetakerID, err)
+		return newOpError("notetakers.Cancel", grantID, notetakerID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("notetakers.Cancel(%s): %w", notetakerID, err)
+		return newOpError("notetakers.Cancel", grantID, notetakerID, err)
 	}
 
 	return nil
@@ -96,12 +96,12 @@ func (s *NotetakersService) Leave(ctx context.Context, grantID, notetakerID stri
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
-		return fmt.Errorf("notetakers.Leave(%s): %w", notetakerID, err)
+		return newOpError("notetakers.Leave", grantID, notetakerID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("notetakers.Leave(%s): %w", notetakerID, err)
+		return newOpError("notetakers.Leave", grantID, notetakerID, err)
 	}
 
 	return nil
@@ -114,13 +114,13 @@ func (s *NotetakersService) GetHistory(ctx context.Context, grantID, notetakerID
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("notetakers.GetHistory(%s): %w", notetakerID, err)
+		return nil, newOpError("notetakers.GetHistory", grantID, notetakerID, err)
 	}
 
 	var history notetakers.History
 	_, err = s.client.Do(req, &history)
 	if err != nil {
-		return nil, fmt.Errorf("notetakers.GetHistory(%s): %w", notetakerID, err)
+		return nil, newOpError("notetakers.GetHistory", grantID, notetakerID, err)
 	}
 
 	return &history, nil
@@ -132,13 +132,13 @@ func (s *NotetakersService) GetMedia(ctx context.Context, grantID, notetakerID s
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("notetakers.GetMedia(%s): %w", notetakerID, err)
+		return nil, newOpError("notetakers.GetMedia", grantID, notetakerID, err)
 	}
 
 	var media []notetakers.Media
 	_, err = s.client.Do(req, &media)
 	if err != nil {
-		return nil, fmt.Errorf("notetakers.GetMedia(%s): %w", notetakerID, err)
+		return nil, newOpError("notetakers.GetMedia", grantID, notetakerID, err)
 	}
 
 	return media, nil
@@ -0,0 +1,17 @@
+package notetakers
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes n, keeping fields this package does not declare in Extra.
+func (n *Notetaker) UnmarshalJSON(data []byte) error {
+	type alias Notetaker
+	extra, err := common.UnmarshalExtra(data, (*alias)(n))
+	n.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes n together with the fields kept in Extra.
+func (n Notetaker) MarshalJSON() ([]byte, error) {
+	type alias Notetaker
+	return common.MarshalExtra(alias(n), n.Extra)
+}
@@ -0,0 +1,19 @@
+package notetakers
+
+import (
+	"time"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// SetJoinTime schedules the notetaker to join the meeting at t.
+func (r *CreateRequest) SetJoinTime(t time.Time) *CreateRequest {
+	r.JoinTime = common.UnixPtr(t)
+	return r
+}
+
+// SetLeaveAfterSilence makes the notetaker leave after d of silence, truncated to whole seconds.
+func (s *MeetingSettings) SetLeaveAfterSilence(d time.Duration) *MeetingSettings {
+	s.LeaveAfterSilence = common.Seconds(d)
+	return s
+}
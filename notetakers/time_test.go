@@ -0,0 +1,18 @@
+package notetakers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreateRequest_TimeSetters(t *testing.T) {
+	join := time.Unix(1700000000, 0)
+	req := (&CreateRequest{MeetingLink: "https://meet.google.com/abc-defg-hij"}).SetJoinTime(join)
+	if *req.JoinTime != 1700000000 || req.Validate() != nil {
+		t.Errorf("CreateRequest = %+v", req)
+	}
+
+	if s := (&MeetingSettings{}).SetLeaveAfterSilence(2 * time.Minute); s.LeaveAfterSilence != 120 {
+		t.Errorf("LeaveAfterSilence = %d, want 120", s.LeaveAfterSilence)
+	}
+}
@@ -1,6 +1,9 @@
 package notetakers
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Notetaker represents a Nylas Notetaker bot.
 type Notetaker struct {
@@ -13,6 +16,9 @@ type Notetaker struct {
 	MeetingSettings *MeetingSettings `json:"meeting_settings,omitempty"`
 	CreatedAt       int64            `json:"created_at,omitempty"`
 	UpdatedAt       int64            `json:"updated_at,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // MeetingSettings defines settings for a notetaker session.
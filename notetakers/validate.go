@@ -0,0 +1,20 @@
+package notetakers
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+func (r *CreateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.MeetingLink == "" {
+		v.Add("meeting_link", "is required")
+	} else {
+		common.CheckURL(v, "meeting_link", r.MeetingLink, "https", "http")
+	}
+	if r.JoinTime != nil {
+		common.CheckUnixTime(v, "join_time", *r.JoinTime)
+	}
+	if r.MeetingSettings != nil && r.MeetingSettings.LeaveAfterSilence < 0 {
+		v.Add("meeting_settings.leave_after_silence_seconds", "must not be negative")
+	}
+	return v.Err()
+}
@@ -0,0 +1,34 @@
+package notetakers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantPath string
+	}{
+		{"link scheme", (&CreateRequest{MeetingLink: "meet.google.com"}).Validate(), "meeting_link"},
+		{"negative silence", (&CreateRequest{MeetingLink: "https://meet.google.com/abc-defg-hij", MeetingSettings: &MeetingSettings{LeaveAfterSilence: -1}}).Validate(), "meeting_settings.leave_after_silence_seconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPath == "-" {
+				if tt.err != nil {
+					t.Errorf("Validate() error = %v, want nil", tt.err)
+				}
+				return
+			}
+			var verr *common.ValidationError
+			if !errors.As(tt.err, &verr) || verr.Fields[0].Path != tt.wantPath {
+				t.Errorf("Validate() error = %v, want problem at %q", tt.err, tt.wantPath)
+			}
+		})
+	}
+}
@@ -8,9 +8,12 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"sync"
 	"time"
+
+	"github.com/mqasimca/nylas-go/folders"
 )
 
 // Default configuration values for the Nylas API client.
@@ -52,6 +55,27 @@ type Client struct {
 	rateMu     sync.Mutex
 	rateLimits Rate
 
+	sendPolicy     *SendPolicy
+	skipValidation bool
+
+	onUnknownFields UnknownFieldsFunc
+
+	multipartThreshold int64
+
+	auditSink   AuditSink
+	auditBodies bool
+	auditRedact map[string]bool
+
+	dryRun    bool
+	dryRunMu  sync.Mutex
+	dryRunOps []DryRunOperation
+	dryRunSeq int
+
+	folderRolesMu sync.Mutex
+	folderRoles   map[string]map[folders.Role]folders.Folder
+
+	optErr error
+
 	common service
 }
 
@@ -73,12 +97,17 @@ func NewClient(opts ...Option) (*Client, error) {
 		HTTPClient: &http.Client{Timeout: defaultTimeout},
 		MaxRetries: defaultRetries,
 		RetryWait:  defaultRetryWait,
+
+		multipartThreshold: DefaultMultipartThreshold,
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if c.optErr != nil {
+		return nil, c.optErr
+	}
 	if c.APIKey == "" {
 		return nil, ErrMissingAPIKey
 	}
@@ -113,6 +142,10 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body any)
 		return nil, err
 	}
 
+	if err := c.validate(body); err != nil {
+		return nil, err
+	}
+
 	var buf io.Reader
 	if body != nil {
 		b, err := json.Marshal(body)
@@ -134,15 +167,49 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body any)
 	return req, nil
 }
 
+// validator is implemented by request types that can check themselves before sending.
+type validator interface {
+	Validate() error
+}
+
+// validate runs body's Validate method unless validation is disabled.
+func (c *Client) validate(body any) error {
+	if c.skipValidation || body == nil {
+		return nil
+	}
+	v, ok := body.(validator)
+	if !ok {
+		return nil
+	}
+	if rv := reflect.ValueOf(body); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+	return v.Validate()
+}
+
 // doWithRetry executes an HTTP request with exponential backoff retry on 5xx and 429 errors.
 func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
 	var resp *http.Response
 	var err error
 
-	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+	// Streamed bodies cannot be replayed, so they get a single attempt.
+	maxRetries := c.MaxRetries
+	if req.Body != nil && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+
 		resp, err = c.HTTPClient.Do(req)
 		if err != nil {
-			if attempt < c.MaxRetries {
+			if attempt < maxRetries {
 				time.Sleep(c.RetryWait * time.Duration(1<<attempt))
 				continue
 			}
@@ -154,9 +221,9 @@ func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
 			return resp, nil
 		}
 
-		_ = resp.Body.Close()
-
-		if attempt < c.MaxRetries {
+		// Keep the last response open so its error body can be parsed.
+		if attempt < maxRetries {
+			_ = resp.Body.Close()
 			wait := c.RetryWait * time.Duration(1<<attempt)
 
 			// Use Retry-After header if present (for 429)
@@ -175,10 +242,36 @@ func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+// roundTrip sends a request. Mutating requests are intercepted in dry-run mode
+// and reported to the audit sink, if one is configured.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	if !isMutating(req) {
+		return c.doWithRetry(req)
+	}
+
+	var rec *AuditRecord
+	if c.auditSink != nil {
+		rec = c.newAuditRecord(req)
+	}
+
+	var resp *http.Response
+	var err error
+	if c.isDryRun(req) {
+		resp, err = c.dryRunResponse(req)
+	} else {
+		resp, err = c.doWithRetry(req)
+	}
+
+	if rec != nil {
+		c.finishAuditRecord(req, rec, resp, err)
+	}
+	return resp, err
+}
+
 // Do executes an HTTP request and decodes the JSON response into v.
 // The response is expected to be wrapped in a standard Nylas response envelope with data and request_id fields.
 func (c *Client) Do(req *http.Request, v any) (*Response[any], error) {
-	resp, err := c.doWithRetry(req)
+	resp, err := c.roundTrip(req)
 	if err != nil {
 		return nil, err
 	}
@@ -206,6 +299,7 @@ func (c *Client) Do(req *http.Request, v any) (*Response[any], error) {
 	if err := json.Unmarshal(result.Data, v); err != nil {
 		return nil, err
 	}
+	c.reportUnknownFields(v)
 
 	return &Response[any]{
 		Data:      v,
@@ -216,7 +310,7 @@ func (c *Client) Do(req *http.Request, v any) (*Response[any], error) {
 // DoRaw executes a request and decodes the response directly (not wrapped in data/request_id).
 // Use this for endpoints that return arrays or objects directly without the standard wrapper.
 func (c *Client) DoRaw(req *http.Request, v any) error {
-	resp, err := c.doWithRetry(req)
+	resp, err := c.roundTrip(req)
 	if err != nil {
 		return err
 	}
@@ -232,12 +326,27 @@ func (c *Client) DoRaw(req *http.Request, v any) error {
 		return nil
 	}
 
-	return json.NewDecoder(resp.Body).Decode(v)
+	if resp.Header.Get(DryRunHeader) != "" {
+		var result struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(result.Data, v); err != nil {
+			return err
+		}
+	} else if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return err
+	}
+
+	c.reportUnknownFields(v)
+	return nil
 }
 
 // DoList executes a request and decodes a list response with pagination.
 func (c *Client) DoList(req *http.Request, v any) (string, string, error) {
-	resp, err := c.doWithRetry(req)
+	resp, err := c.roundTrip(req)
 	if err != nil {
 		return "", "", err
 	}
@@ -262,11 +371,15 @@ func (c *Client) DoList(req *http.Request, v any) (string, string, error) {
 	if err := json.Unmarshal(result.Data, v); err != nil {
 		return "", "", err
 	}
+	c.reportUnknownFields(v)
 
 	return result.NextCursor, result.RequestID, nil
 }
 
 func (c *Client) updateRateLimits(resp *http.Response) {
+	if resp.Header.Get(DryRunHeader) != "" {
+		return
+	}
 	c.rateMu.Lock()
 	defer c.rateMu.Unlock()
 	c.rateLimits = parseRateLimits(resp)
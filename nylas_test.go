@@ -3,9 +3,13 @@ package nylas
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/mqasimca/nylas-go/events"
+	"github.com/mqasimca/nylas-go/messages"
 )
 
 func TestNewClient(t *testing.T) {
@@ -99,6 +103,47 @@ func TestClient_NewRequest(t *testing.T) {
 	}
 }
 
+func TestClient_Validation(t *testing.T) {
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"data": {"id": "event-1"}, "request_id": "req-1"}`))
+	})
+	ctx := context.Background()
+
+	_, err := client.Events.Create(ctx, "grant-123", "primary", &events.CreateRequest{
+		When:         events.When{StartTime: Ptr(int64(1700000000)), Date: "2024-01-01"},
+		Participants: []events.Participant{{Email: "not-an-email"}},
+	})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Create() error = %v, want ErrValidation", err)
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Fields) != 2 || verr.Fields[0].Path != "when" || verr.Fields[1].Path != "participants[0].email" {
+		t.Errorf("ValidationError = %+v", verr)
+	}
+	var opErr *OpError
+	if !errors.As(err, &opErr) || opErr.Op != "events.Create" {
+		t.Errorf("error = %v, want *OpError for events.Create", err)
+	}
+	if calls != 0 {
+		t.Errorf("server calls = %d, want 0", calls)
+	}
+
+	// A nil body is left for the API to reject.
+	if _, err := client.Messages.Send(ctx, "grant-123", nil); err != nil {
+		t.Errorf("Send(nil) error = %v", err)
+	}
+
+	WithoutValidation()(client)
+	if _, err := client.Messages.Send(ctx, "grant-123", &messages.SendRequest{}); err != nil {
+		t.Errorf("Send() with validation disabled error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("server calls = %d, want 2", calls)
+	}
+}
+
 func TestClient_Do(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -252,8 +297,8 @@ func TestClient_Do_RateLimitExhausted(t *testing.T) {
 	_, err := client.Do(req, &result)
 
 	// Should return error after exhausting retries
-	if err == nil {
-		t.Error("Do() expected error after exhausting retries")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Do() error = %v, want ErrRateLimited after exhausting retries", err)
 	}
 	if attempts != 3 { // Initial + 2 retries
 		t.Errorf("Do() attempts = %d, want 3", attempts)
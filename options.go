@@ -1,7 +1,9 @@
 package nylas
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -51,11 +53,42 @@ func WithMaxRetries(n int) Option {
 	return func(c *Client) { c.MaxRetries = n }
 }
 
+// WithoutValidation disables local request validation. By default every request type
+// with a Validate method is checked before it is sent, and problems are returned as a
+// *ValidationError without calling the API.
+func WithoutValidation() Option {
+	return func(c *Client) { c.skipValidation = true }
+}
+
 // WithRetryWait sets the base wait time between retries (uses exponential backoff).
 func WithRetryWait(d time.Duration) Option {
 	return func(c *Client) { c.RetryWait = d }
 }
 
+// WithProxy routes requests through the HTTP proxy at proxyURL.
+// The current HTTP client is copied so a shared *http.Client is not modified.
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) {
+		u, err := url.Parse(proxyURL)
+		if err != nil || u.Host == "" {
+			c.optErr = fmt.Errorf("nylas: invalid proxy URL %q", proxyURL)
+			return
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		hc := &http.Client{}
+		if c.HTTPClient != nil {
+			*hc = *c.HTTPClient
+			if t, ok := c.HTTPClient.Transport.(*http.Transport); ok {
+				transport = t.Clone()
+			}
+		}
+		transport.Proxy = http.ProxyURL(u)
+		hc.Transport = transport
+		c.HTTPClient = hc
+	}
+}
+
 // Region represents a Nylas API region.
 type Region string
 
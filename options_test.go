@@ -81,3 +81,21 @@ func TestWithRetryWait(t *testing.T) {
 		t.Errorf("WithRetryWait() = %v, want 1s", client.RetryWait)
 	}
 }
+
+func TestWithProxy(t *testing.T) {
+	shared := &http.Client{Timeout: 5 * time.Second}
+	client, err := NewClient(WithAPIKey("key"), WithHTTPClient(shared), WithProxy("http://proxy:8080"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.HTTPClient == shared {
+		t.Error("WithProxy() modified the shared HTTP client")
+	}
+	if client.HTTPClient.Timeout != 5*time.Second {
+		t.Errorf("WithProxy() timeout = %v, want 5s", client.HTTPClient.Timeout)
+	}
+
+	if _, err := NewClient(WithAPIKey("key"), WithProxy("::bad")); err == nil {
+		t.Error("WithProxy() with invalid URL expected error")
+	}
+}
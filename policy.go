@@ -0,0 +1,324 @@
+package nylas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mqasimca/nylas-go/drafts"
+	"github.com/mqasimca/nylas-go/events"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// ErrPolicyViolation matches every *PolicyError. Use errors.Is to check for it.
+var ErrPolicyViolation = errors.New("nylas: send policy violation")
+
+// Default names used to preserve original recipients when redirecting to a sink.
+const (
+	DefaultOriginalRecipientsHeader = "X-Nylas-Original-Recipients"
+	DefaultOriginalRecipientsKey    = "original_recipients"
+)
+
+// SendPolicy enforces guardrails on outbound mail and event invitations.
+// It applies to Messages.Send, Messages.SendRawMIME, Drafts.Create, Drafts.Update,
+// Drafts.Send and to Events.Create when the event has participants (Nylas notifies
+// them by default). Raw MIME messages cannot be redirected, so with a sink configured
+// they may only be addressed to the sink.
+//
+// Checks run before any network call. MaxRecipients is checked against the original
+// recipients; domain rules are checked against the recipients that would actually
+// be delivered to, i.e. after sink redirection.
+//
+// Example - QA environment that never reaches real customers:
+//
+//	client, err := nylas.NewClient(
+//	    nylas.WithAPIKey(apiKey),
+//	    nylas.WithSendPolicy(&nylas.SendPolicy{
+//	        SinkAddress:    "qa-sink@example.com",
+//	        AllowedDomains: []string{"example.com"},
+//	        MaxRecipients:  50,
+//	    }),
+//	)
+type SendPolicy struct {
+	// AllowedDomains, if set, is the only set of recipient domains that may be delivered to.
+	// A domain also matches its subdomains.
+	AllowedDomains []string
+	// BlockedDomains lists recipient domains that may never be delivered to.
+	// A domain also matches its subdomains.
+	BlockedDomains []string
+	// MaxRecipients caps the total number of recipients (to, cc, bcc or participants).
+	// Zero means no cap.
+	MaxRecipients int
+	// SinkAddress, if set, replaces all recipients with this single address.
+	SinkAddress string
+	// OriginalRecipientsHeader is the header that records the original recipients of
+	// redirected messages and drafts. Defaults to DefaultOriginalRecipientsHeader.
+	OriginalRecipientsHeader string
+	// OriginalRecipientsKey is the event metadata key that records the original
+	// participants of redirected events. Defaults to DefaultOriginalRecipientsKey.
+	OriginalRecipientsKey string
+	// Check is an optional custom rule run after the built-in checks.
+	// Returning an error aborts the operation; the error is wrapped in a *PolicyError.
+	Check func(ctx context.Context, out *Outbound) error
+}
+
+// Outbound describes an operation subject to the send policy.
+type Outbound struct {
+	// Op is the operation name, e.g. "messages.Send".
+	Op string
+	// GrantID is the grant the operation runs as.
+	GrantID string
+	// Recipients are the addresses that would be delivered to, after any sink redirection.
+	Recipients []string
+	// OriginalRecipients are the addresses as supplied by the caller.
+	OriginalRecipients []string
+}
+
+// PolicyError is returned when an operation violates the client's SendPolicy.
+type PolicyError struct {
+	// Op is the operation that was blocked.
+	Op string
+	// Violations describes each rule that was broken.
+	Violations []string
+	// Err is the error returned by SendPolicy.Check, if any.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("nylas: %s blocked by send policy: %s", e.Op, strings.Join(e.Violations, "; "))
+}
+
+// Is implements errors.Is for matching against ErrPolicyViolation.
+func (e *PolicyError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// Unwrap returns the error from the custom Check hook, if any.
+func (e *PolicyError) Unwrap() error {
+	return e.Err
+}
+
+// WithSendPolicy installs guardrails on outbound mail and event invitations.
+func WithSendPolicy(p *SendPolicy) Option {
+	return func(c *Client) { c.sendPolicy = p }
+}
+
+// check validates an outbound operation, returning a *PolicyError on violation.
+func (p *SendPolicy) check(ctx context.Context, out *Outbound) error {
+	var violations []string
+
+	if p.MaxRecipients > 0 && len(out.OriginalRecipients) > p.MaxRecipients {
+		violations = append(violations, fmt.Sprintf("%d recipients exceeds limit of %d", len(out.OriginalRecipients), p.MaxRecipients))
+	}
+
+	for _, addr := range out.Recipients {
+		domain := emailDomain(addr)
+		switch {
+		case domain == "":
+			violations = append(violations, fmt.Sprintf("recipient %q is not a valid email address", addr))
+		case matchDomain(domain, p.BlockedDomains):
+			violations = append(violations, fmt.Sprintf("recipient %q is in a blocked domain", addr))
+		case len(p.AllowedDomains) > 0 && !matchDomain(domain, p.AllowedDomains):
+			violations = append(violations, fmt.Sprintf("recipient %q is not in an allowed domain", addr))
+		}
+	}
+
+	var hookErr error
+	if len(violations) == 0 && p.Check != nil {
+		if hookErr = p.Check(ctx, out); hookErr != nil {
+			violations = append(violations, hookErr.Error())
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Op: out.Op, Violations: violations, Err: hookErr}
+	}
+	return nil
+}
+
+func (p *SendPolicy) header() string {
+	if p.OriginalRecipientsHeader != "" {
+		return p.OriginalRecipientsHeader
+	}
+	return DefaultOriginalRecipientsHeader
+}
+
+func (p *SendPolicy) metadataKey() string {
+	if p.OriginalRecipientsKey != "" {
+		return p.OriginalRecipientsKey
+	}
+	return DefaultOriginalRecipientsKey
+}
+
+// applySendPolicy checks a message send and returns the request to send,
+// redirected to the sink if one is configured. The caller's request is not modified.
+func (c *Client) applySendPolicy(ctx context.Context, grantID string, send *messages.SendRequest) (*messages.SendRequest, error) {
+	p := c.sendPolicy
+	if p == nil || send == nil {
+		return send, nil
+	}
+
+	original := participantEmails(send.To, send.CC, send.BCC)
+	out := *send
+	if p.SinkAddress != "" && len(original) > 0 {
+		out.To = []messages.Participant{{Email: p.SinkAddress}}
+		out.CC, out.BCC = nil, nil
+		out.CustomHeaders = appendOriginalRecipients(send.CustomHeaders, p.header(), original)
+	}
+
+	if err := p.check(ctx, &Outbound{Op: "messages.Send", GrantID: grantID, Recipients: participantEmails(out.To, out.CC, out.BCC), OriginalRecipients: original}); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// applyDraftPolicy checks a draft create and returns the request to send,
+// redirected to the sink if one is configured.
+func (c *Client) applyDraftPolicy(ctx context.Context, grantID string, create *drafts.CreateRequest) (*drafts.CreateRequest, error) {
+	p := c.sendPolicy
+	if p == nil || create == nil {
+		return create, nil
+	}
+
+	original := participantEmails(create.To, create.CC, create.BCC)
+	out := *create
+	if p.SinkAddress != "" && len(original) > 0 {
+		out.To = []drafts.Participant{{Email: p.SinkAddress}}
+		out.CC, out.BCC = nil, nil
+		out.CustomHeaders = appendOriginalRecipients(create.CustomHeaders, p.header(), original)
+	}
+
+	if err := p.check(ctx, &Outbound{Op: "drafts.Create", GrantID: grantID, Recipients: participantEmails(out.To, out.CC, out.BCC), OriginalRecipients: original}); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// applyDraftUpdatePolicy checks a draft update and returns the request to send,
+// redirected to the sink if one is configured.
+func (c *Client) applyDraftUpdatePolicy(ctx context.Context, grantID string, update *drafts.UpdateRequest) (*drafts.UpdateRequest, error) {
+	p := c.sendPolicy
+	if p == nil || update == nil {
+		return update, nil
+	}
+
+	original := participantEmails(update.To, update.CC, update.BCC)
+	out := *update
+	if p.SinkAddress != "" && len(original) > 0 {
+		out.To = []drafts.Participant{{Email: p.SinkAddress}}
+		out.CC, out.BCC = nil, nil
+		out.CustomHeaders = appendOriginalRecipients(update.CustomHeaders, p.header(), original)
+	}
+
+	if err := p.check(ctx, &Outbound{Op: "drafts.Update", GrantID: grantID, Recipients: participantEmails(out.To, out.CC, out.BCC), OriginalRecipients: original}); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// checkDraftSend checks an existing draft before it is sent. Drafts cannot be
+// redirected at send time, so a draft with recipients other than the sink is rejected.
+func (c *Client) checkDraftSend(ctx context.Context, draft *drafts.Draft) error {
+	p := c.sendPolicy
+	if p == nil {
+		return nil
+	}
+	recipients := participantEmails(draft.To, draft.CC, draft.BCC)
+	if p.SinkAddress != "" {
+		for _, addr := range recipients {
+			if !strings.EqualFold(addr, p.SinkAddress) {
+				return &PolicyError{Op: "drafts.Send", Violations: []string{fmt.Sprintf("recipient %q was not redirected to sink %q", addr, p.SinkAddress)}}
+			}
+		}
+	}
+	return p.check(ctx, &Outbound{Op: "drafts.Send", GrantID: draft.GrantID, Recipients: recipients, OriginalRecipients: recipients})
+}
+
+// checkRawMIMESend checks a raw MIME message before it is sent. Its headers are
+// sent verbatim, so with a sink configured any recipient other than the sink is rejected.
+func (c *Client) checkRawMIMESend(ctx context.Context, grantID string, raw messages.RawMIME) error {
+	p := c.sendPolicy
+	if p == nil {
+		return nil
+	}
+	recipients, err := raw.Recipients()
+	if err != nil {
+		return &PolicyError{Op: "messages.SendRawMIME", Violations: []string{fmt.Sprintf("cannot read recipients: %v", err)}}
+	}
+	if p.SinkAddress != "" {
+		for _, addr := range recipients {
+			if !strings.EqualFold(addr, p.SinkAddress) {
+				return &PolicyError{Op: "messages.SendRawMIME", Violations: []string{fmt.Sprintf("recipient %q was not redirected to sink %q", addr, p.SinkAddress)}}
+			}
+		}
+	}
+	return p.check(ctx, &Outbound{Op: "messages.SendRawMIME", GrantID: grantID, Recipients: recipients, OriginalRecipients: recipients})
+}
+
+// applyEventPolicy checks an event create that notifies participants and returns
+// the request to send, redirected to the sink if one is configured.
+func (c *Client) applyEventPolicy(ctx context.Context, grantID string, create *events.CreateRequest) (*events.CreateRequest, error) {
+	p := c.sendPolicy
+	if p == nil || create == nil || len(create.Participants) == 0 {
+		return create, nil
+	}
+
+	original := make([]string, 0, len(create.Participants))
+	for _, participant := range create.Participants {
+		original = append(original, participant.Email)
+	}
+
+	out := *create
+	recipients := original
+	if p.SinkAddress != "" {
+		out.Participants = []events.Participant{{Email: p.SinkAddress}}
+		out.Metadata = make(map[string]string, len(create.Metadata)+1)
+		for k, v := range create.Metadata {
+			out.Metadata[k] = v
+		}
+		out.Metadata[p.metadataKey()] = strings.Join(original, ", ")
+		recipients = []string{p.SinkAddress}
+	}
+
+	if err := p.check(ctx, &Outbound{Op: "events.Create", GrantID: grantID, Recipients: recipients, OriginalRecipients: original}); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func participantEmails(lists ...[]messages.Participant) []string {
+	var emails []string
+	for _, list := range lists {
+		for _, p := range list {
+			emails = append(emails, p.Email)
+		}
+	}
+	return emails
+}
+
+func appendOriginalRecipients(headers []messages.Header, name string, original []string) []messages.Header {
+	out := make([]messages.Header, 0, len(headers)+1)
+	out = append(out, headers...)
+	return append(out, messages.Header{Name: name, Value: strings.Join(original, ", ")})
+}
+
+func emailDomain(addr string) string {
+	at := strings.LastIndex(addr, "@")
+	if at <= 0 || at == len(addr)-1 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(addr[at+1:]))
+}
+
+// matchDomain reports whether domain equals or is a subdomain of any entry in list.
+func matchDomain(domain string, list []string) bool {
+	for _, d := range list {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
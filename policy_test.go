@@ -0,0 +1,186 @@
+package nylas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/drafts"
+	"github.com/mqasimca/nylas-go/events"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+func TestSendPolicy_Violations(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *SendPolicy
+		to      []string
+		wantErr string
+	}{
+		{"blocked domain", &SendPolicy{BlockedDomains: []string{"customer.com"}}, []string{"a@customer.com"}, "blocked domain"},
+		{"blocked subdomain", &SendPolicy{BlockedDomains: []string{"customer.com"}}, []string{"a@eu.customer.com"}, "blocked domain"},
+		{"not allowed", &SendPolicy{AllowedDomains: []string{"example.com"}}, []string{"a@other.com"}, "not in an allowed domain"},
+		{"too many recipients", &SendPolicy{MaxRecipients: 1}, []string{"a@example.com", "b@example.com"}, "exceeds limit of 1"},
+		{"invalid address", &SendPolicy{AllowedDomains: []string{"example.com"}}, []string{"nobody"}, "not a valid email address"},
+		{"custom check", &SendPolicy{Check: func(ctx context.Context, out *Outbound) error {
+			return errors.New("weekend sends disabled")
+		}}, []string{"a@example.com"}, "weekend sends disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				t.Error("request should not reach the server")
+			})
+			client.sendPolicy = tt.policy
+
+			req := &messages.SendRequest{Subject: "Hi"}
+			for _, addr := range tt.to {
+				req.To = append(req.To, messages.Participant{Email: addr})
+			}
+			_, err := client.Messages.Send(context.Background(), "grant-123", req)
+			if !errors.Is(err, ErrPolicyViolation) {
+				t.Fatalf("Send() error = %v, want ErrPolicyViolation", err)
+			}
+			var policyErr *PolicyError
+			if !errors.As(err, &policyErr) || policyErr.Op != "messages.Send" {
+				t.Errorf("Send() error = %#v, want *PolicyError for messages.Send", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Send() error = %q, want substring %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSendPolicy_SinkRedirect(t *testing.T) {
+	var got messages.SendRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		_, _ = w.Write([]byte(`{"data": {"id": "msg-1"}, "request_id": "req-1"}`))
+	})
+	client.sendPolicy = &SendPolicy{SinkAddress: "sink@qa.example.com", AllowedDomains: []string{"qa.example.com"}}
+
+	req := &messages.SendRequest{
+		To:  []messages.Participant{{Email: "customer@real.com"}},
+		CC:  []messages.Participant{{Email: "boss@real.com"}},
+		BCC: []messages.Participant{{Email: "audit@real.com"}},
+	}
+	if _, err := client.Messages.Send(context.Background(), "grant-123", req); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(got.To) != 1 || got.To[0].Email != "sink@qa.example.com" || len(got.CC) != 0 || len(got.BCC) != 0 {
+		t.Errorf("sent recipients = to:%v cc:%v bcc:%v, want only sink", got.To, got.CC, got.BCC)
+	}
+	if len(got.CustomHeaders) != 1 || got.CustomHeaders[0].Name != DefaultOriginalRecipientsHeader ||
+		got.CustomHeaders[0].Value != "customer@real.com, boss@real.com, audit@real.com" {
+		t.Errorf("CustomHeaders = %+v", got.CustomHeaders)
+	}
+	if req.To[0].Email != "customer@real.com" || len(req.CustomHeaders) != 0 {
+		t.Error("Send() modified the caller's request")
+	}
+}
+
+func TestSendPolicy_Drafts(t *testing.T) {
+	var created drafts.CreateRequest
+	sent := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			if len(body) > 0 {
+				_ = json.Unmarshal(body, &created)
+			} else {
+				sent = true
+			}
+			_, _ = w.Write([]byte(`{"data": {"id": "draft-1"}, "request_id": "req-1"}`))
+		case http.MethodGet:
+			if strings.HasSuffix(r.URL.Path, "/missing") {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "draft not found", "type": "not_found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": {"id": "draft-2", "to": [{"email": "customer@real.com"}]}, "request_id": "req-2"}`))
+		}
+	})
+	client.sendPolicy = &SendPolicy{SinkAddress: "sink@qa.example.com"}
+	ctx := context.Background()
+
+	_, err := client.Drafts.Create(ctx, "grant-123", &drafts.CreateRequest{To: []drafts.Participant{{Email: "customer@real.com"}}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if len(created.To) != 1 || created.To[0].Email != "sink@qa.example.com" {
+		t.Errorf("created draft recipients = %v, want sink", created.To)
+	}
+
+	_, err = client.Drafts.Send(ctx, "grant-123", "draft-2")
+	if !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("Send() error = %v, want ErrPolicyViolation for unredirected draft", err)
+	}
+	if sent {
+		t.Error("draft was sent despite policy violation")
+	}
+	// A failed fetch is reported as the Get that failed, not wrapped again.
+	_, err = client.Drafts.Send(ctx, "grant-123", "missing")
+	var opErr *OpError
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &opErr) || opErr.Op != "drafts.Get" || strings.Contains(err.Error(), "drafts.Send") {
+		t.Errorf("Send() of a missing draft error = %v, want the drafts.Get error", err)
+	}
+	if err := (&Client{}).checkDraftSend(ctx, &drafts.Draft{To: []drafts.Participant{{Email: "a@real.com"}}}); err != nil {
+		t.Errorf("checkDraftSend() without a policy error = %v", err)
+	}
+}
+
+func TestSendPolicy_RawMIME(t *testing.T) {
+	sent := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		_, _ = w.Write([]byte(`{"data": {"id": "msg-1"}, "request_id": "req-1"}`))
+	})
+	client.sendPolicy = &SendPolicy{SinkAddress: "sink@qa.example.com"}
+	ctx := context.Background()
+
+	_, err := client.Messages.SendRawMIME(ctx, "grant-123", []byte("To: customer@real.com\r\n\r\nHi"))
+	if !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("SendRawMIME() error = %v, want ErrPolicyViolation", err)
+	}
+	if _, err := client.Messages.SendRawMIME(ctx, "grant-123", []byte("To: sink@qa.example.com\r\n\r\nHi")); err != nil {
+		t.Errorf("SendRawMIME() to sink error = %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("requests sent = %d, want 1", sent)
+	}
+}
+
+func TestSendPolicy_Events(t *testing.T) {
+	var got events.CreateRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		_, _ = w.Write([]byte(`{"data": {"id": "event-1"}, "request_id": "req-1"}`))
+	})
+	client.sendPolicy = &SendPolicy{SinkAddress: "sink@qa.example.com"}
+
+	_, err := client.Events.Create(context.Background(), "grant-123", "primary", &events.CreateRequest{
+		Title:        "Kickoff",
+		When:         events.When{StartTime: Ptr(int64(1700000000)), EndTime: Ptr(int64(1700003600))},
+		Participants: []events.Participant{{Email: "a@real.com"}, {Email: "b@real.com"}},
+		Metadata:     map[string]string{"crm_id": "42"},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if len(got.Participants) != 1 || got.Participants[0].Email != "sink@qa.example.com" {
+		t.Errorf("participants = %v, want sink", got.Participants)
+	}
+	if got.Metadata[DefaultOriginalRecipientsKey] != "a@real.com, b@real.com" || got.Metadata["crm_id"] != "42" {
+		t.Errorf("metadata = %v", got.Metadata)
+	}
+}
@@ -14,7 +14,7 @@ func (s *RedirectURIsService) List(ctx context.Context, opts *redirecturis.ListO
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("redirecturis.List: %w", err)
+		return nil, newOpError("redirecturis.List", "", "", err)
 	}
 
 	if opts != nil {
@@ -26,7 +26,7 @@ func (s *RedirectURIsService) List(ctx context.Context, opts *redirecturis.ListO
 	var data []redirecturis.RedirectURI
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("redirecturis.List: %w", err)
+		return nil, newOpError("redirecturis.List", "", "", err)
 	}
 
 	return &ListResponse[redirecturis.RedirectURI]{
@@ -42,13 +42,13 @@ func (s *RedirectURIsService) Get(ctx context.Context, redirectURIID string) (*r
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("redirecturis.Get(%s): %w", redirectURIID, err)
+		return nil, newOpError("redirecturis.Get", "", redirectURIID, err)
 	}
 
 	var uri redirecturis.RedirectURI
 	_, err = s.client.Do(req, &uri)
 	if err != nil {
-		return nil, fmt.Errorf("redirecturis.Get(%s): %w", redirectURIID, err)
+		return nil, newOpError("redirecturis.Get", "", redirectURIID, err)
 	}
 
 	return &uri, nil
@@ -60,13 +60,13 @@ func (s *RedirectURIsService) Create(ctx context.Context, create *redirecturis.C
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, create)
 	if err != nil {
-		return nil, fmt.Errorf("redirecturis.Create: %w", err)
+		return nil, newOpError("redirecturis.Create", "", "", err)
 	}
 
 	var uri redirecturis.RedirectURI
 	_, err = s.client.Do(req, &uri)
 	if err != nil {
-		return nil, fmt.Errorf("redirecturis.Create: %w", err)
+		return nil, newOpError("redirecturis.Create", "", "", err)
 	}
 
 	return &uri, nil
@@ -78,13 +78,13 @@ func (s *RedirectURIsService) Update(ctx context.Context, redirectURIID string,
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("redirecturis.Update(%s): %w", redirectURIID, err)
+		return nil, newOpError("redirecturis.Update", "", redirectURIID, err)
 	}
 
 	var uri redirecturis.RedirectURI
 	_, err = s.client.Do(req, &uri)
 	if err != nil {
-		return nil, fmt.Errorf("redirecturis.Update(%s): %w", redirectURIID, err)
+		return nil, newOpError("redirecturis.Update", "", redirectURIID, err)
 	}
 
 	return &uri, nil
@@ -96,12 +96,12 @@ func (s *RedirectURIsService) Delete(ctx context.Context, redirectURIID string)
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("redirecturis.Delete(%s): %w", redirectURIID, err)
+		return newOpError("redirecturis.Delete", "", redirectURIID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("redirecturis.Delete(%s): %w", redirectURIID, err)
+		return newOpError("redirecturis.Delete", "", redirectURIID, err)
 	}
 
 	return nil
@@ -0,0 +1,17 @@
+package redirecturis
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes r, keeping fields this package does not declare in Extra.
+func (r *RedirectURI) UnmarshalJSON(data []byte) error {
+	type alias RedirectURI
+	extra, err := common.UnmarshalExtra(data, (*alias)(r))
+	r.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes r together with the fields kept in Extra.
+func (r RedirectURI) MarshalJSON() ([]byte, error) {
+	type alias RedirectURI
+	return common.MarshalExtra(alias(r), r.Extra)
+}
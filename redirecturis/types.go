@@ -1,12 +1,17 @@
 // Package redirecturis provides types for the Nylas Redirect URIs API.
 package redirecturis
 
+import "encoding/json"
+
 // RedirectURI represents a redirect URI configuration.
 type RedirectURI struct {
 	ID       string            `json:"id"`
 	URL      string            `json:"url"`
 	Platform string            `json:"platform"` // "web", "ios", "android", "js"
 	Settings *RedirectSettings `json:"settings,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // RedirectSettings contains platform-specific redirect URI settings.
@@ -0,0 +1,32 @@
+package redirecturis
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Platforms accepted by the API.
+var platforms = []string{"web", "ios", "android", "js"}
+
+// Validate checks the request for problems the API would reject.
+func (r *CreateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.URL == "" {
+		v.Add("url", "is required")
+	}
+	if r.Platform == "" {
+		v.Add("platform", "is required")
+	} else {
+		common.CheckOneOf(v, "platform", r.Platform, platforms...)
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.URL != nil && *r.URL == "" {
+		v.Add("url", "must not be empty")
+	}
+	if r.Platform != nil {
+		common.CheckOneOf(v, "platform", *r.Platform, platforms...)
+	}
+	return v.Err()
+}
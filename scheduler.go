@@ -14,7 +14,7 @@ func (s *SchedulerService) ListConfigurations(ctx context.Context, grantID strin
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.ListConfigurations: %w", err)
+		return nil, newOpError("scheduler.ListConfigurations", grantID, "", err)
 	}
 
 	if opts != nil {
@@ -26,7 +26,7 @@ func (s *SchedulerService) ListConfigurations(ctx context.Context, grantID strin
 	var data []scheduler.Configuration
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.ListConfigurations: %w", err)
+		return nil, newOpError("scheduler.ListConfigurations", grantID, "", err)
 	}
 
 	return &ListResponse[scheduler.Configuration]{
@@ -42,13 +42,13 @@ func (s *SchedulerService) GetConfiguration(ctx context.Context, grantID, config
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.GetConfiguration(%s): %w", configID, err)
+		return nil, newOpError("scheduler.GetConfiguration", grantID, configID, err)
 	}
 
 	var config scheduler.Configuration
 	_, err = s.client.Do(req, &config)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.GetConfiguration(%s): %w", configID, err)
+		return nil, newOpError("scheduler.GetConfiguration", grantID, configID, err)
 	}
 
 	return &config, nil
@@ -60,13 +60,13 @@ func (s *SchedulerService) CreateConfiguration(ctx context.Context, grantID stri
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, configReq)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.CreateConfiguration: %w", err)
+		return nil, newOpError("scheduler.CreateConfiguration", grantID, "", err)
 	}
 
 	var config scheduler.Configuration
 	_, err = s.client.Do(req, &config)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.CreateConfiguration: %w", err)
+		return nil, newOpError("scheduler.CreateConfiguration", grantID, "", err)
 	}
 
 	return &config, nil
@@ -78,13 +78,13 @@ func (s *SchedulerService) UpdateConfiguration(ctx context.Context, grantID, con
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, configReq)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.UpdateConfiguration(%s): %w", configID, err)
+		return nil, newOpError("scheduler.UpdateConfiguration", grantID, configID, err)
 	}
 
 	var config scheduler.Configuration
 	_, err = s.client.Do(req, &config)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.UpdateConfiguration(%s): %w", configID, err)
+		return nil, newOpError("scheduler.UpdateConfiguration", grantID, configID, err)
 	}
 
 	return &config, nil
@@ -96,12 +96,12 @@ func (s *SchedulerService) DeleteConfiguration(ctx context.Context, grantID, con
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("scheduler.DeleteConfiguration(%s): %w", configID, err)
+		return newOpError("scheduler.DeleteConfiguration", grantID, configID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("scheduler.DeleteConfiguration(%s): %w", configID, err)
+		return newOpError("scheduler.DeleteConfiguration", grantID, configID, err)
 	}
 
 	return nil
@@ -113,13 +113,13 @@ func (s *SchedulerService) CreateSession(ctx context.Context, sessionReq *schedu
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, sessionReq)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.CreateSession: %w", err)
+		return nil, newOpError("scheduler.CreateSession", "", "", err)
 	}
 
 	var session scheduler.Session
 	_, err = s.client.Do(req, &session)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.CreateSession: %w", err)
+		return nil, newOpError("scheduler.CreateSession", "", "", err)
 	}
 
 	return &session, nil
@@ -131,7 +131,7 @@ func (s *SchedulerService) ListBookings(ctx context.Context, configID string, op
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.ListBookings: %w", err)
+		return nil, newOpError("scheduler.ListBookings", "", "", err)
 	}
 
 	if opts != nil {
@@ -143,7 +143,7 @@ func (s *SchedulerService) ListBookings(ctx context.Context, configID string, op
 	var data []scheduler.Booking
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.ListBookings: %w", err)
+		return nil, newOpError("scheduler.ListBookings", "", "", err)
 	}
 
 	return &ListResponse[scheduler.Booking]{
@@ -159,13 +159,13 @@ func (s *SchedulerService) GetBooking(ctx context.Context, configID, bookingID s
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.GetBooking(%s): %w", bookingID, err)
+		return nil, newOpError("scheduler.GetBooking", "", bookingID, err)
 	}
 
 	var booking scheduler.Booking
 	_, err = s.client.Do(req, &booking)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.GetBooking(%s): %w", bookingID, err)
+		return nil, newOpError("scheduler.GetBooking", "", bookingID, err)
 	}
 
 	return &booking, nil
@@ -177,13 +177,13 @@ func (s *SchedulerService) CreateBooking(ctx context.Context, configID string, b
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, bookingReq)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.CreateBooking: %w", err)
+		return nil, newOpError("scheduler.CreateBooking", "", "", err)
 	}
 
 	var booking scheduler.Booking
 	_, err = s.client.Do(req, &booking)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.CreateBooking: %w", err)
+		return nil, newOpError("scheduler.CreateBooking", "", "", err)
 	}
 
 	return &booking, nil
@@ -195,13 +195,13 @@ func (s *SchedulerService) ConfirmBooking(ctx context.Context, configID, booking
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, confirm)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.ConfirmBooking(%s): %w", bookingID, err)
+		return nil, newOpError("scheduler.ConfirmBooking", "", bookingID, err)
 	}
 
 	var booking scheduler.Booking
 	_, err = s.client.Do(req, &booking)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.ConfirmBooking(%s): %w", bookingID, err)
+		return nil, newOpError("scheduler.ConfirmBooking", "", bookingID, err)
 	}
 
 	return &booking, nil
@@ -213,13 +213,13 @@ func (s *SchedulerService) RescheduleBooking(ctx context.Context, configID, book
 
 	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, reschedule)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.RescheduleBooking(%s): %w", bookingID, err)
+		return nil, newOpError("scheduler.RescheduleBooking", "", bookingID, err)
 	}
 
 	var booking scheduler.Booking
 	_, err = s.client.Do(req, &booking)
 	if err != nil {
-		return nil, fmt.Errorf("scheduler.RescheduleBooking(%s): %w", bookingID, err)
+		return nil, newOpError("scheduler.RescheduleBooking", "", bookingID, err)
 	}
 
 	return &booking, nil
@@ -236,12 +236,12 @@ func (s *SchedulerService) CancelBooking(ctx context.Context, configID, bookingI
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, body)
 	if err != nil {
-		return fmt.Errorf("scheduler.CancelBooking(%s): %w", bookingID, err)
+		return newOpError("scheduler.CancelBooking", "", bookingID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("scheduler.CancelBooking(%s): %w", bookingID, err)
+		return newOpError("scheduler.CancelBooking", "", bookingID, err)
 	}
 
 	return nil
@@ -0,0 +1,31 @@
+package scheduler
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes c, keeping fields this package does not declare in Extra.
+func (c *Configuration) UnmarshalJSON(data []byte) error {
+	type alias Configuration
+	extra, err := common.UnmarshalExtra(data, (*alias)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes c together with the fields kept in Extra.
+func (c Configuration) MarshalJSON() ([]byte, error) {
+	type alias Configuration
+	return common.MarshalExtra(alias(c), c.Extra)
+}
+
+// UnmarshalJSON decodes b, keeping fields this package does not declare in Extra.
+func (b *Booking) UnmarshalJSON(data []byte) error {
+	type alias Booking
+	extra, err := common.UnmarshalExtra(data, (*alias)(b))
+	b.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes b together with the fields kept in Extra.
+func (b Booking) MarshalJSON() ([]byte, error) {
+	type alias Booking
+	return common.MarshalExtra(alias(b), b.Extra)
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// SetTimes sets the booking's start and end.
+func (r *BookingRequest) SetTimes(start, end time.Time) *BookingRequest {
+	r.StartTime, r.EndTime = start.Unix(), end.Unix()
+	return r
+}
+
+// SetTimes sets the booking's new start and end.
+func (r *RescheduleBookingRequest) SetTimes(start, end time.Time) *RescheduleBookingRequest {
+	r.StartTime, r.EndTime = start.Unix(), end.Unix()
+	return r
+}
+
+// SetTimeToLive sets how long the session is valid, truncated to whole minutes.
+func (r *SessionRequest) SetTimeToLive(d time.Duration) *SessionRequest {
+	r.TimeToLive = common.Minutes(d)
+	return r
+}
+
+// SetDuration sets the meeting duration, truncated to whole minutes.
+func (a *Availability) SetDuration(d time.Duration) *Availability {
+	a.DurationMinutes = common.Minutes(d)
+	return a
+}
+
+// SetInterval sets the interval between bookable slots, truncated to whole minutes.
+func (a *Availability) SetInterval(d time.Duration) *Availability {
+	a.IntervalMinutes = common.Minutes(d)
+	return a
+}
+
+// SetBuffer sets buffer times before and after meetings, truncated to whole minutes.
+func (r *AvailabilityRules) SetBuffer(before, after time.Duration) *AvailabilityRules {
+	r.BufferBefore, r.BufferAfter = common.Minutes(before), common.Minutes(after)
+	return r
+}
+
+// SetMinBookingNotice sets how far in advance bookings must be made, truncated to whole minutes.
+func (b *EventBooking) SetMinBookingNotice(d time.Duration) *EventBooking {
+	b.MinBookingNotice = common.Minutes(d)
+	return b
+}
+
+// SetMinCancellationNotice sets how far in advance bookings may be cancelled, truncated to whole minutes.
+func (b *EventBooking) SetMinCancellationNotice(d time.Duration) *EventBooking {
+	b.MinCancellationNotice = common.Minutes(d)
+	return b
+}
@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduler_TimeSetters(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	booking := (&BookingRequest{Guest: BookingParticipant{Email: "g@example.com"}}).SetTimes(start, start.Add(30*time.Minute))
+	if booking.StartTime != 1700000000 || booking.EndTime != 1700001800 || booking.Validate() != nil {
+		t.Errorf("BookingRequest = %+v", booking)
+	}
+	if err := (&BookingRequest{Guest: BookingParticipant{Email: "g@example.com"}}).SetTimes(start, start).Validate(); err == nil {
+		t.Error("Validate() with empty booking error = nil")
+	}
+
+	resched := (&RescheduleBookingRequest{}).SetTimes(start, start.Add(time.Hour))
+	if resched.EndTime-resched.StartTime != 3600 {
+		t.Errorf("RescheduleBookingRequest = %+v", resched)
+	}
+
+	if s := (&SessionRequest{}).SetTimeToLive(2 * time.Hour); s.TimeToLive != 120 {
+		t.Errorf("TimeToLive = %d, want 120", s.TimeToLive)
+	}
+	if a := (&Availability{}).SetDuration(time.Hour).SetInterval(15 * time.Minute); a.DurationMinutes != 60 || a.IntervalMinutes != 15 {
+		t.Errorf("Availability = %+v", a)
+	}
+	if r := (&AvailabilityRules{}).SetBuffer(5*time.Minute, 10*time.Minute); r.BufferBefore != 5 || r.BufferAfter != 10 {
+		t.Errorf("AvailabilityRules = %+v", r)
+	}
+	if b := (&EventBooking{}).SetMinBookingNotice(24 * time.Hour).SetMinCancellationNotice(time.Hour); b.MinBookingNotice != 1440 || b.MinCancellationNotice != 60 {
+		t.Errorf("EventBooking = %+v", b)
+	}
+}
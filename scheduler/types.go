@@ -1,6 +1,9 @@
 package scheduler
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Configuration represents a Scheduler configuration.
 type Configuration struct {
@@ -11,6 +14,9 @@ type Configuration struct {
 	Scheduler           *SchedulerSettings  `json:"scheduler,omitempty"`
 	AppearanceSettings  *AppearanceSettings `json:"appearance,omitempty"`
 	RequiresSessionAuth bool                `json:"requires_session_auth,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Participant represents a scheduling participant.
@@ -156,6 +162,9 @@ type Booking struct {
 	AdditionalGuests []string             `json:"additional_guests,omitempty"`
 	CreatedAt        int64                `json:"created_at,omitempty"`
 	UpdatedAt        int64                `json:"updated_at,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // BookingParticipant represents a booking participant.
@@ -0,0 +1,112 @@
+package scheduler
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks that Days are 0-6 and StartTime and EndTime are "HH:MM"
+// with EndTime after StartTime.
+func (h *OpenHours) Validate() error {
+	v := &common.ValidationError{}
+	for i, d := range h.Days {
+		if d < 0 || d > 6 {
+			v.Add(common.IndexPath("days", i), "%d is not a day of the week (0-6)", d)
+		}
+	}
+	common.CheckClockRange(v, "start", h.StartTime, "end", h.EndTime)
+	return v.Err()
+}
+
+// Validate checks that Start and End are "HH:MM" with End after Start.
+func (s *TimeSlot) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckClockRange(v, "start", s.Start, "end", s.End)
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *ConfigurationRequest) Validate() error {
+	v := &common.ValidationError{}
+	for i, p := range r.Participants {
+		path := common.IndexPath("participants", i)
+		common.CheckEmail(v, common.JoinPath(path, "email"), p.Email)
+		checkAvailabilityRules(v, common.JoinPath(path, "availability"), p.Availability)
+	}
+	if a := r.Availability; a != nil {
+		if a.DurationMinutes < 0 {
+			v.Add("availability.duration_minutes", "must not be negative")
+		}
+		if a.IntervalMinutes < 0 {
+			v.Add("availability.interval_minutes", "must not be negative")
+		}
+		checkAvailabilityRules(v, "availability.availability_rules", a.AvailabilityRules)
+	}
+	if b := r.EventBooking; b != nil {
+		if b.MinBookingNotice < 0 {
+			v.Add("event_booking.min_booking_notice", "must not be negative")
+		}
+		if b.MinCancellationNotice < 0 {
+			v.Add("event_booking.min_cancellation_notice", "must not be negative")
+		}
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *SessionRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.ConfigurationID == "" {
+		v.Add("configuration_id", "is required")
+	}
+	if r.TimeToLive < 0 {
+		v.Add("time_to_live", "must not be negative")
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *BookingRequest) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckTimeRange(v, "start_time", r.StartTime, "end_time", r.EndTime)
+	common.CheckEmail(v, "guest.email", r.Guest.Email)
+	for i, g := range r.AdditionalGuests {
+		common.CheckEmail(v, common.IndexPath("additional_guests", i), g)
+	}
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *ConfirmBookingRequest) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckOneOf(v, "status", r.Status, "confirmed", "cancelled")
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *RescheduleBookingRequest) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckTimeRange(v, "start_time", r.StartTime, "end_time", r.EndTime)
+	return v.Err()
+}
+
+func checkAvailabilityRules(v *common.ValidationError, path string, rules *AvailabilityRules) {
+	if rules == nil {
+		return
+	}
+	for i := range rules.OpenHours {
+		v.Merge(common.IndexPath(common.JoinPath(path, "open_hours"), i), rules.OpenHours[i].Validate())
+	}
+	for i, day := range rules.AvailableDays {
+		dayPath := common.IndexPath(common.JoinPath(path, "available_days"), i)
+		if day.Day < 0 || day.Day > 6 {
+			v.Add(common.JoinPath(dayPath, "day"), "%d is not a day of the week (0-6)", day.Day)
+		}
+		for j := range day.Hours {
+			v.Merge(common.IndexPath(common.JoinPath(dayPath, "hours"), j), day.Hours[j].Validate())
+		}
+	}
+	if rules.BufferBefore < 0 {
+		v.Add(common.JoinPath(path, "buffer_before"), "must not be negative")
+	}
+	if rules.BufferAfter < 0 {
+		v.Add(common.JoinPath(path, "buffer_after"), "must not be negative")
+	}
+}
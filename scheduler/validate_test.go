@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestSchedulerRequests_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"booking valid", (&BookingRequest{StartTime: 100, EndTime: 200, Guest: BookingParticipant{Email: "g@example.com"}}).Validate(), nil},
+		{"booking end before start", (&BookingRequest{StartTime: 200, EndTime: 100, Guest: BookingParticipant{Email: "g@example.com"}}).Validate(), []string{"end_time"}},
+		{"booking guests", (&BookingRequest{StartTime: 100, EndTime: 200, AdditionalGuests: []string{"x"}}).Validate(), []string{"guest.email", "additional_guests[0]"}},
+		{"reschedule", (&RescheduleBookingRequest{StartTime: 100}).Validate(), []string{"end_time"}},
+		{"confirm", (&ConfirmBookingRequest{Status: "ok"}).Validate(), []string{"status"}},
+		{"session", (&SessionRequest{TimeToLive: -1}).Validate(), []string{"configuration_id", "time_to_live"}},
+		{"configuration valid", (&ConfigurationRequest{Participants: []Participant{{Email: "o@example.com"}}}).Validate(), nil},
+		{"configuration", (&ConfigurationRequest{
+			Participants: []Participant{{
+				Email: "o@example.com",
+				Availability: &AvailabilityRules{
+					OpenHours:     []OpenHours{{Days: []int{1}, StartTime: "9:00", EndTime: "17:00"}},
+					AvailableDays: []AvailableDay{{Day: 8, Hours: []TimeSlot{{Start: "10:00", End: "09:00"}}}},
+				},
+			}},
+			Availability: &Availability{DurationMinutes: -30},
+		}).Validate(), []string{
+			"participants[0].availability.open_hours[0].start",
+			"participants[0].availability.available_days[0].day",
+			"participants[0].availability.available_days[0].hours[0].end",
+			"availability.duration_minutes",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldPaths(t, tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() paths = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func fieldPaths(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verr *common.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *common.ValidationError", err)
+	}
+	paths := make([]string, len(verr.Fields))
+	for i, f := range verr.Fields {
+		paths[i] = f.Path
+	}
+	return paths
+}
@@ -0,0 +1,88 @@
+package nylas
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// UnknownFieldsFunc receives the Go type of a decoded value, e.g. "messages.Message",
+// and the sorted names of response fields that type does not declare.
+type UnknownFieldsFunc func(typeName string, fields []string)
+
+// WithUnknownFields enables strict decoding. After each response is decoded, fn is
+// called once for every model type that received fields the SDK does not declare,
+// which means the API has moved ahead of this version of the SDK. Unknown fields
+// are kept in the model's Extra map whether or not this option is set.
+//
+// Example:
+//
+//	client, err := nylas.NewClient(
+//	    nylas.WithAPIKey(apiKey),
+//	    nylas.WithUnknownFields(func(typeName string, fields []string) {
+//	        log.Printf("schema drift: %s has unknown fields %v", typeName, fields)
+//	    }),
+//	)
+func WithUnknownFields(fn UnknownFieldsFunc) Option {
+	return func(c *Client) { c.onUnknownFields = fn }
+}
+
+// extraType is the type of the Extra field on model types.
+var extraType = reflect.TypeOf(map[string]json.RawMessage(nil))
+
+// reportUnknownFields calls the UnknownFieldsFunc, if any, for the values in v
+// whose Extra maps are not empty.
+func (c *Client) reportUnknownFields(v any) {
+	if c.onUnknownFields == nil || v == nil {
+		return
+	}
+	found := map[string]map[string]bool{}
+	collectUnknownFields(reflect.ValueOf(v), found)
+
+	types := make([]string, 0, len(found))
+	for typeName := range found {
+		types = append(types, typeName)
+	}
+	sort.Strings(types)
+	for _, typeName := range types {
+		fields := make([]string, 0, len(found[typeName]))
+		for name := range found[typeName] {
+			fields = append(fields, name)
+		}
+		sort.Strings(fields)
+		c.onUnknownFields(typeName, fields)
+	}
+}
+
+// collectUnknownFields walks rv and records the Extra keys of every struct it
+// reaches, keyed by the struct's type name.
+func collectUnknownFields(rv reflect.Value, found map[string]map[string]bool) {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !rv.IsNil() {
+			collectUnknownFields(rv.Elem(), found)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			collectUnknownFields(rv.Index(i), found)
+		}
+	case reflect.Struct:
+		t := rv.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if f.Name == "Extra" && f.Type == extraType {
+				for name := range rv.Field(i).Interface().(map[string]json.RawMessage) {
+					if found[t.String()] == nil {
+						found[t.String()] = map[string]bool{}
+					}
+					found[t.String()][name] = true
+				}
+				continue
+			}
+			collectUnknownFields(rv.Field(i), found)
+		}
+	}
+}
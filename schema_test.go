@@ -0,0 +1,80 @@
+package nylas
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/events"
+)
+
+func TestWithUnknownFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "e1", "title": "A", "color": "red"},
+			{"id": "e2", "title": "B", "color": "blue", "ai_summary": "..."},
+			{"id": "e3", "title": "C"}
+		], "request_id": "req-1"}`))
+	}))
+	defer srv.Close()
+
+	got := map[string][]string{}
+	client, err := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(srv.URL),
+		WithUnknownFields(func(typeName string, fields []string) {
+			got[typeName] = fields
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	resp, err := client.Events.List(context.Background(), "grant-123", &events.ListOptions{CalendarID: "primary"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := map[string][]string{"events.Event": {"ai_summary", "color"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unknown fields = %v, want %v", got, want)
+	}
+	if string(resp.Data[0].Extra["color"]) != `"red"` {
+		t.Errorf("Extra = %v", resp.Data[0].Extra)
+	}
+
+	b, err := json.Marshal(resp.Data[1])
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var round map[string]any
+	_ = json.Unmarshal(b, &round)
+	if round["ai_summary"] != "..." || round["color"] != "blue" {
+		t.Errorf("re-marshaled event = %s, want unknown fields kept", b)
+	}
+}
+
+func TestWithUnknownFields_NoneReported(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": {"id": "e1", "title": "A"}, "request_id": "req-1"}`))
+	}))
+	defer srv.Close()
+
+	calls := 0
+	client, err := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(srv.URL),
+		WithUnknownFields(func(string, []string) { calls++ }),
+	)
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	if _, err := client.Events.Get(context.Background(), "grant-123", "e1", "primary"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("callback calls = %d, want 0", calls)
+	}
+}
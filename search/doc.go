@@ -0,0 +1,21 @@
+// Package search builds message search queries once and runs them against any
+// provider.
+//
+// A Query is a small syntax tree of terms (text, from, to, subject), attachment and
+// date conditions, combined with And, Or and Not. Parse reads the same syntax from a
+// search box, Native renders a query in the grant's own search language (Gmail
+// search, Microsoft KQL or IMAP SEARCH), and Build turns it into
+// messages.ListOptions, falling back to the structured filters for clauses the
+// provider cannot express natively and reporting anything neither can express.
+//
+// Example:
+//
+//	q, err := search.Parse(`from:alice has:attachment (invoice OR receipt)`, nil)
+//	plan := search.Build(grant.Provider, q, &messages.ListOptions{Limit: nylas.Ptr(50)})
+//	resp, err := client.Messages.List(ctx, grant.ID, plan.Options)
+//	for _, msg := range resp.Data {
+//	    if plan.Exact() || search.Matches(q, &msg) {
+//	        // ...
+//	    }
+//	}
+package search
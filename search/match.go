@@ -0,0 +1,74 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// Matches reports whether msg matches q, for filtering the results of a Plan that
+// is not exact. Terms are matched as case-insensitive substrings, text terms against
+// the subject, snippet, body and participants, and dates against msg.Date. A nil
+// query matches every message.
+func Matches(q Query, msg *messages.Message) bool {
+	switch q := q.(type) {
+	case nil:
+		return true
+	case Term:
+		value := strings.ToLower(q.Value)
+		switch q.Field {
+		case FieldFrom:
+			return participantsContain(msg.From, value)
+		case FieldTo:
+			return participantsContain(msg.To, value)
+		case FieldSubject:
+			return containsFold(msg.Subject, value)
+		}
+		return containsFold(msg.Subject, value) || containsFold(msg.Snippet, value) ||
+			containsFold(msg.Body, value) || participantsContain(msg.From, value) ||
+			participantsContain(msg.To, value) || participantsContain(msg.CC, value) ||
+			participantsContain(msg.BCC, value)
+	case HasAttachment:
+		for _, att := range msg.Attachments {
+			if !att.IsInline {
+				return true
+			}
+		}
+		return false
+	case After:
+		return msg.Date > q.Time.Unix()
+	case Before:
+		return msg.Date < q.Time.Unix()
+	case And:
+		for _, sub := range q {
+			if !Matches(sub, msg) {
+				return false
+			}
+		}
+		return true
+	case Or:
+		for _, sub := range q {
+			if Matches(sub, msg) {
+				return true
+			}
+		}
+		return false
+	case Not:
+		return !Matches(q.Query, msg)
+	}
+	return false
+}
+
+// containsFold reports whether s contains the lower-case substr, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), substr)
+}
+
+func participantsContain(ps []messages.Participant, value string) bool {
+	for _, p := range ps {
+		if containsFold(p.Email, value) || containsFold(p.Name, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,299 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseOptions configures Parse. A nil *ParseOptions uses the defaults.
+type ParseOptions struct {
+	// Location is the time zone of dates without one, such as after:2024-03-01.
+	// The default is UTC.
+	Location *time.Location
+}
+
+// SyntaxError is returned by Parse for input it cannot read.
+type SyntaxError struct {
+	// Offset is the byte offset in the input where the problem was found.
+	Offset int
+	// Msg describes the problem.
+	Msg string
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("search: %s at offset %d", e.Msg, e.Offset)
+}
+
+// Parse reads a query in the syntax of a Gmail-style search box:
+//
+//	word "exact phrase"          text anywhere in the message
+//	from:alice to:bob@example.com sender and recipients
+//	subject:"quarterly report"   subject line
+//	has:attachment               at least one attachment
+//	after:2024-03-01 before:2024-04-01
+//	a OR b   -a   NOT a   (a b)  alternatives, negation and grouping
+//
+// Terms next to each other must all match, and OR binds tighter than that, so
+// "a b OR c" means a and (b or c). Dates are YYYY-MM-DD, YYYY/MM/DD, RFC 3339 or
+// Unix seconds. Unknown prefixes such as "label:x" are searched for as text.
+// An empty input returns an empty And, which matches everything.
+func Parse(s string, opts *ParseOptions) (Query, error) {
+	if opts == nil {
+		opts = &ParseOptions{}
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks, loc: loc, end: len(s)}
+	q, err := p.sequence()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, &SyntaxError{Offset: tok.pos, Msg: "unexpected )"}
+	}
+	return q, nil
+}
+
+type lexKind int
+
+const (
+	wordLex lexKind = iota
+	openLex
+	closeLex
+	negLex
+)
+
+type lexToken struct {
+	kind lexKind
+	// text is the word with quotes removed; prefix is the part before a colon that
+	// was followed by a quoted value, e.g. "subject" in subject:"a b".
+	text   string
+	prefix string
+	quoted bool
+	pos    int
+}
+
+func lex(s string) ([]lexToken, error) {
+	var toks []lexToken
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '(':
+			toks = append(toks, lexToken{kind: openLex, pos: i})
+			i++
+		case c == ')':
+			toks = append(toks, lexToken{kind: closeLex, pos: i})
+			i++
+		case c == '-' && i+1 < len(s) && !strings.ContainsRune(" \t\r\n)", rune(s[i+1])):
+			toks = append(toks, lexToken{kind: negLex, pos: i})
+			i++
+		case c == '"':
+			text, n, err := unquote(s[i:], i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, lexToken{kind: wordLex, text: text, quoted: true, pos: i})
+			i += n
+		default:
+			start := i
+			for i < len(s) && !strings.ContainsRune(" \t\r\n()\"", rune(s[i])) {
+				i++
+			}
+			tok := lexToken{kind: wordLex, text: s[start:i], pos: start}
+			if i < len(s) && s[i] == '"' && strings.HasSuffix(tok.text, ":") {
+				text, n, err := unquote(s[i:], i)
+				if err != nil {
+					return nil, err
+				}
+				tok.prefix = strings.TrimSuffix(tok.text, ":")
+				tok.text = text
+				tok.quoted = true
+				i += n
+			}
+			toks = append(toks, tok)
+		}
+	}
+	return toks, nil
+}
+
+// unquote reads the quoted string at the start of s, which is at offset pos of the
+// input, and returns its content and length.
+func unquote(s string, pos int) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", 0, &SyntaxError{Offset: pos, Msg: "unterminated quote"}
+}
+
+type parser struct {
+	toks []lexToken
+	i    int
+	loc  *time.Location
+	end  int
+}
+
+func (p *parser) peek() (lexToken, bool) {
+	if p.i >= len(p.toks) {
+		return lexToken{}, false
+	}
+	return p.toks[p.i], true
+}
+
+func isKeyword(tok lexToken, kw string) bool {
+	return tok.kind == wordLex && !tok.quoted && tok.text == kw
+}
+
+// sequence reads alternatives up to a closing parenthesis or the end of input.
+func (p *parser) sequence() (Query, error) {
+	var and And
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind == closeLex {
+			break
+		}
+		if isKeyword(tok, "AND") {
+			p.i++
+			continue
+		}
+		q, err := p.alternatives()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, q)
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	if and == nil {
+		and = And{}
+	}
+	return and, nil
+}
+
+func (p *parser) alternatives() (Query, error) {
+	q, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	or := Or{q}
+	for {
+		tok, ok := p.peek()
+		if !ok || !isKeyword(tok, "OR") {
+			break
+		}
+		p.i++
+		q, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, q)
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *parser) unary() (Query, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, &SyntaxError{Offset: p.end, Msg: "missing term"}
+	}
+	p.i++
+	switch {
+	case tok.kind == negLex || isKeyword(tok, "NOT"):
+		q, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Query: q}, nil
+	case tok.kind == openLex:
+		q, err := p.sequence()
+		if err != nil {
+			return nil, err
+		}
+		if next, ok := p.peek(); !ok || next.kind != closeLex {
+			return nil, &SyntaxError{Offset: tok.pos, Msg: "unclosed ("}
+		}
+		p.i++
+		return q, nil
+	case tok.kind == closeLex || isKeyword(tok, "OR") || isKeyword(tok, "AND"):
+		return nil, &SyntaxError{Offset: tok.pos, Msg: "missing term"}
+	}
+	return p.word(tok)
+}
+
+// word interprets a single word, which may have a field prefix.
+func (p *parser) word(tok lexToken) (Query, error) {
+	prefix, value := tok.prefix, tok.text
+	if prefix == "" && !tok.quoted {
+		var ok bool
+		if prefix, value, ok = strings.Cut(tok.text, ":"); !ok {
+			return Text(tok.text), nil
+		}
+	}
+	if prefix == "" {
+		return Text(value), nil
+	}
+
+	switch strings.ToLower(prefix) {
+	case "from", "to", "subject":
+		if value == "" {
+			return nil, &SyntaxError{Offset: tok.pos, Msg: prefix + ": needs a value"}
+		}
+		return Term{Field: Field(strings.ToLower(prefix)), Value: value}, nil
+	case "has":
+		if v := strings.ToLower(value); v == "attachment" || v == "attachments" {
+			return HasAttachment{}, nil
+		}
+	case "after", "before":
+		t, err := parseTime(value, p.loc)
+		if err != nil {
+			return nil, &SyntaxError{Offset: tok.pos, Msg: fmt.Sprintf("%s: %q is not a date", prefix, value)}
+		}
+		if strings.EqualFold(prefix, "after") {
+			return After{Time: t}, nil
+		}
+		return Before{Time: t}, nil
+	}
+	if tok.quoted {
+		return Text(prefix + ":" + value), nil
+	}
+	return Text(tok.text), nil
+}
+
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && len(s) > 8 {
+		return time.Unix(n, 0).UTC(), nil
+	}
+	for _, layout := range []string{time.DateOnly, "2006/01/02"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Parse(time.RFC3339, s)
+}
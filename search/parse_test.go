@@ -0,0 +1,110 @@
+package search
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		input string
+		want  Query
+	}{
+		{"", And{}},
+		{"invoice", Text("invoice")},
+		{`"quarterly report"`, Text("quarterly report")},
+		{"from:alice@example.com has:attachment", And{From("alice@example.com"), HasAttachment{}}},
+		{`subject:"hello world" to:bob`, And{Subject("hello world"), To("bob")}},
+		{"a b OR c", And{Text("a"), Or{Text("b"), Text("c")}}},
+		{"(a b) OR c", Or{And{Text("a"), Text("b")}, Text("c")}},
+		{"-spam NOT has:attachment", And{Not{Text("spam")}, Not{HasAttachment{}}}},
+		{"a AND b", And{Text("a"), Text("b")}},
+		{"after:2024-03-01 before:2024/04/01", And{After{day(2024, 3, 1)}, Before{day(2024, 4, 1)}}},
+		{"after:1709251200", After{day(2024, 3, 1)}},
+		{"label:work http://x.test", And{Text("label:work"), Text("http://x.test")}},
+		{`"OR"`, Text("OR")},
+		{`FROM:Alice`, From("Alice")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, nil)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Location(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*3600)
+	q, err := Parse("after:2024-03-01", &ParseOptions{Location: loc})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := q.(After).Time; !got.Equal(time.Date(2024, 2, 29, 22, 0, 0, 0, time.UTC)) {
+		t.Errorf("Time = %v, want midnight in UTC+2", got)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+	}{
+		{"(a b", 0},
+		{"a )", 2},
+		{`subject:"open`, 8},
+		{"a OR", 4},
+		{"after:yesterday", 0},
+		{"from:", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input, nil)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse() error = %v, want *SyntaxError", err)
+			}
+			if syntaxErr.Offset != tt.offset {
+				t.Errorf("Offset = %d, want %d", syntaxErr.Offset, tt.offset)
+			}
+		})
+	}
+}
+
+func TestQuery_String(t *testing.T) {
+	tests := []struct {
+		q    Query
+		want string
+	}{
+		{And{From("a@x.test"), Or{Text("b c"), Subject("d:e")}}, `from:a@x.test ("b c" OR subject:"d:e")`},
+		{Not{And{Text("a"), HasAttachment{}}}, "-(a has:attachment)"},
+		{After{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, "after:2024-03-01"},
+		{Before{time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}, "before:2024-03-01T12:00:00Z"},
+		{Text(`say "hi"`), `"say \"hi\""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := tt.q.String()
+			if got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+			back, err := Parse(got, nil)
+			if err != nil {
+				t.Fatalf("Parse(String()) error = %v", err)
+			}
+			if !reflect.DeepEqual(back, tt.q) {
+				t.Errorf("Parse(String()) = %#v, want %#v", back, tt.q)
+			}
+		})
+	}
+}
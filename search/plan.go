@@ -0,0 +1,147 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// Plan is a query translated into list options for one provider.
+type Plan struct {
+	// Options are the options to list messages with: a copy of the base options
+	// given to Build, with SearchQueryNative or structured filters added.
+	Options *messages.ListOptions
+	// Dialect is the native search language used in Options.SearchQueryNative, or
+	// DialectNone if only structured filters are used.
+	Dialect Dialect
+	// Unsupported lists the parts of the query that Options does not express at all.
+	Unsupported []Query
+	// Approximate lists the parts of the query that Options only expresses loosely,
+	// such as dates rounded to whole days.
+	Approximate []Query
+}
+
+// Exact reports whether Options returns exactly the messages matching the query.
+// Otherwise the results include every match plus messages that the Unsupported and
+// Approximate parts would have excluded; filter them with Matches.
+func (p *Plan) Exact() bool {
+	return len(p.Unsupported) == 0 && len(p.Approximate) == 0
+}
+
+// Build translates q into list options for a grant of the given provider, as in
+// grants.Grant.Provider. base holds options to keep, such as Limit or In; it is
+// not modified and may be nil.
+//
+// The query is split into the parts that must all match. If the provider's native
+// search can express all of them, they go into SearchQueryNative. Nylas does not
+// combine a native query with most structured filters, so otherwise Build uses
+// whichever of the two expresses more of the query: the native query, or the
+// From, To, Subject, HasAttachment, ReceivedAfter and ReceivedBefore filters. The
+// parts left out are listed in Unsupported. From and To filters need a full email
+// address, and each filter takes a single value.
+//
+// Example:
+//
+//	plan := search.Build("imap", search.And{search.From("bob@example.com"), search.HasAttachment{}}, nil)
+//	// plan.Options.From = "bob@example.com", plan.Options.HasAttachment = true
+func Build(provider string, q Query, base *messages.ListOptions) *Plan {
+	var opts messages.ListOptions
+	if base != nil {
+		opts = *base
+	}
+	parts := conjuncts(q)
+	if len(parts) == 0 {
+		return &Plan{Options: &opts}
+	}
+
+	d := DialectFor(provider)
+	var native, approx, nativeBad []Query
+	var rendered []string
+	if d != DialectNone {
+		for _, part := range parts {
+			r := renderer{d: d}
+			s := r.query(part, false)
+			if r.unsupported != nil {
+				nativeBad = append(nativeBad, part)
+				continue
+			}
+			native = append(native, part)
+			rendered = append(rendered, s)
+			if r.approximate {
+				approx = append(approx, part)
+			}
+		}
+	}
+
+	structured := opts
+	var structBad []Query
+	for _, part := range parts {
+		if !addFilter(&structured, part) {
+			structBad = append(structBad, part)
+		}
+	}
+
+	if len(native) == 0 || len(nativeBad) > len(structBad) {
+		return &Plan{Options: &structured, Unsupported: structBad}
+	}
+	sep := " "
+	if d == DialectKQL {
+		sep = " AND "
+	}
+	s := strings.Join(rendered, sep)
+	opts.SearchQueryNative = &s
+	return &Plan{Options: &opts, Dialect: d, Unsupported: nativeBad, Approximate: approx}
+}
+
+// addFilter sets the structured filter of opts that expresses q and reports whether
+// there was one. A filter that is already set is only narrowed, for dates, or left
+// alone.
+func addFilter(opts *messages.ListOptions, q Query) bool {
+	switch q := q.(type) {
+	case Term:
+		switch {
+		case q.Field == FieldSubject && opts.Subject == nil:
+			opts.Subject = &q.Value
+		case q.Field == FieldFrom && opts.From == nil && isAddress(q.Value):
+			opts.From = &q.Value
+		case q.Field == FieldTo && opts.To == nil && isAddress(q.Value):
+			opts.To = &q.Value
+		default:
+			return false
+		}
+		return true
+	case HasAttachment:
+		return setBool(&opts.HasAttachment, true)
+	case Not:
+		if _, ok := q.Query.(HasAttachment); ok {
+			return setBool(&opts.HasAttachment, false)
+		}
+	case After:
+		t := q.Time.Unix()
+		if opts.ReceivedAfter == nil || t > *opts.ReceivedAfter {
+			opts.ReceivedAfter = &t
+		}
+		return true
+	case Before:
+		t := q.Time.Unix()
+		if opts.ReceivedBefore == nil || t < *opts.ReceivedBefore {
+			opts.ReceivedBefore = &t
+		}
+		return true
+	}
+	return false
+}
+
+func setBool(field **bool, b bool) bool {
+	if *field != nil {
+		return **field == b
+	}
+	*field = &b
+	return true
+}
+
+// isAddress reports whether s looks like a complete email address.
+func isAddress(s string) bool {
+	local, domain, ok := strings.Cut(s, "@")
+	return ok && local != "" && domain != "" && !strings.ContainsAny(s, " \t<>")
+}
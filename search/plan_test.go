@@ -0,0 +1,124 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+func TestBuild(t *testing.T) {
+	limit := 50
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	base := &messages.ListOptions{Limit: &limit}
+
+	t.Run("native", func(t *testing.T) {
+		plan := Build("google", And{From("bob@example.com"), HasAttachment{}}, base)
+		if plan.Dialect != DialectGmail || !plan.Exact() {
+			t.Errorf("Dialect = %s, Exact = %v", plan.Dialect, plan.Exact())
+		}
+		if got := plan.Options.SearchQueryNative; got == nil || *got != "from:bob@example.com has:attachment" {
+			t.Errorf("SearchQueryNative = %v", got)
+		}
+		if plan.Options.Limit != &limit || base.SearchQueryNative != nil {
+			t.Error("base options not copied")
+		}
+	})
+
+	t.Run("structured fallback", func(t *testing.T) {
+		plan := Build("imap", And{From("bob@example.com"), HasAttachment{}, After{march}}, base)
+		want := messages.ListOptions{
+			Limit:         &limit,
+			From:          ptr("bob@example.com"),
+			HasAttachment: ptr(true),
+			ReceivedAfter: ptr(march.Unix()),
+		}
+		if !reflect.DeepEqual(*plan.Options, want) {
+			t.Errorf("Options = %+v, want %+v", *plan.Options, want)
+		}
+		if plan.Dialect != DialectNone || !plan.Exact() {
+			t.Errorf("Dialect = %s, Exact = %v", plan.Dialect, plan.Exact())
+		}
+	})
+
+	t.Run("native with unsupported", func(t *testing.T) {
+		plan := Build("imap", And{Text("invoice"), Or{From("a"), From("b")}, HasAttachment{}}, nil)
+		if got := plan.Options.SearchQueryNative; got == nil || *got != `TEXT "invoice" (OR FROM "a" FROM "b")` {
+			t.Errorf("SearchQueryNative = %v", got)
+		}
+		if !reflect.DeepEqual(plan.Unsupported, []Query{HasAttachment{}}) {
+			t.Errorf("Unsupported = %v", plan.Unsupported)
+		}
+		if plan.Options.HasAttachment != nil {
+			t.Error("structured filter mixed with native query")
+		}
+	})
+
+	t.Run("no native search", func(t *testing.T) {
+		plan := Build("ews", And{Subject("report"), To("team"), Text("q1")}, nil)
+		if plan.Options.SearchQueryNative != nil || plan.Options.Subject == nil || *plan.Options.Subject != "report" {
+			t.Errorf("Options = %+v", *plan.Options)
+		}
+		if !reflect.DeepEqual(plan.Unsupported, []Query{To("team"), Text("q1")}) {
+			t.Errorf("Unsupported = %v", plan.Unsupported)
+		}
+	})
+
+	t.Run("approximate dates", func(t *testing.T) {
+		plan := Build("microsoft", Before{march.Add(time.Hour)}, nil)
+		if len(plan.Approximate) != 1 || plan.Exact() {
+			t.Errorf("Approximate = %v", plan.Approximate)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		plan := Build("google", And{}, base)
+		if plan.Options.SearchQueryNative != nil || !plan.Exact() {
+			t.Errorf("Options = %+v", *plan.Options)
+		}
+	})
+}
+
+func TestMatches(t *testing.T) {
+	msg := &messages.Message{
+		Subject:     "Q1 Invoice",
+		From:        []messages.Participant{{Name: "Bob", Email: "bob@example.com"}},
+		To:          []messages.Participant{{Email: "team@example.com"}},
+		Body:        "<p>Please pay</p>",
+		Date:        time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC).Unix(),
+		Attachments: []messages.Attachment{{Filename: "logo.png", IsInline: true}},
+	}
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		q    Query
+		want bool
+	}{
+		{nil, true},
+		{Text("invoice"), true},
+		{Text("pay"), true},
+		{Text("BOB"), true},
+		{From("example.com"), true},
+		{From("team"), false},
+		{To("team"), true},
+		{Subject("please"), false},
+		{HasAttachment{}, false},
+		{And{After{march}, Before{march.AddDate(0, 0, 1)}}, true},
+		{Or{Text("x"), Not{Text("y")}}, true},
+		{Or{}, false},
+	}
+
+	for _, tt := range tests {
+		name := "nil"
+		if tt.q != nil {
+			name = tt.q.String()
+		}
+		t.Run(name, func(t *testing.T) {
+			if got := Matches(tt.q, msg); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
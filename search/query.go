@@ -0,0 +1,182 @@
+package search
+
+import (
+	"strings"
+	"time"
+)
+
+// Query is a node of a search query. The node types are Term, HasAttachment, After,
+// Before, And, Or and Not.
+type Query interface {
+	// String returns the query in the syntax read by Parse.
+	String() string
+	query()
+}
+
+// Field is the part of a message a Term searches.
+type Field string
+
+// Fields a Term can search.
+const (
+	// FieldText searches the subject, body and participants.
+	FieldText Field = "text"
+	// FieldFrom searches the sender's address and name.
+	FieldFrom Field = "from"
+	// FieldTo searches the To recipients' addresses and names.
+	FieldTo Field = "to"
+	// FieldSubject searches the subject line.
+	FieldSubject Field = "subject"
+)
+
+// Term matches messages whose Field contains Value, ignoring case.
+type Term struct {
+	Field Field
+	Value string
+}
+
+// HasAttachment matches messages with at least one attachment that is not an
+// inline image.
+type HasAttachment struct{}
+
+// After matches messages received after Time.
+type After struct {
+	Time time.Time
+}
+
+// Before matches messages received before Time.
+type Before struct {
+	Time time.Time
+}
+
+// And matches messages that match every query in it. An empty And matches everything.
+type And []Query
+
+// Or matches messages that match at least one query in it. An empty Or matches nothing.
+type Or []Query
+
+// Not matches messages that do not match Query.
+type Not struct {
+	Query Query
+}
+
+// Text returns a term searching the subject, body and participants for s.
+func Text(s string) Term { return Term{Field: FieldText, Value: s} }
+
+// From returns a term searching the sender for s.
+func From(s string) Term { return Term{Field: FieldFrom, Value: s} }
+
+// To returns a term searching the recipients for s.
+func To(s string) Term { return Term{Field: FieldTo, Value: s} }
+
+// Subject returns a term searching the subject line for s.
+func Subject(s string) Term { return Term{Field: FieldSubject, Value: s} }
+
+func (Term) query()          {}
+func (HasAttachment) query() {}
+func (After) query()         {}
+func (Before) query()        {}
+func (And) query()           {}
+func (Or) query()            {}
+func (Not) query()           {}
+
+func (t Term) String() string {
+	if t.Field == FieldText {
+		return quoteValue(t.Value)
+	}
+	return string(t.Field) + ":" + quoteValue(t.Value)
+}
+
+func (HasAttachment) String() string { return "has:attachment" }
+
+func (a After) String() string { return "after:" + formatTime(a.Time) }
+
+func (b Before) String() string { return "before:" + formatTime(b.Time) }
+
+func (a And) String() string {
+	parts := make([]string, len(a))
+	for i, q := range a {
+		parts[i] = group(q)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (o Or) String() string {
+	parts := make([]string, len(o))
+	for i, q := range o {
+		parts[i] = group(q)
+	}
+	return strings.Join(parts, " OR ")
+}
+
+func (n Not) String() string {
+	if n.Query == nil {
+		return "-()"
+	}
+	return "-" + group(n.Query)
+}
+
+// group returns q's string, in parentheses if it combines several queries.
+func group(q Query) string {
+	switch q := q.(type) {
+	case nil:
+		return "()"
+	case And:
+		if len(q) != 1 {
+			return "(" + q.String() + ")"
+		}
+		return group(q[0])
+	case Or:
+		if len(q) != 1 {
+			return "(" + q.String() + ")"
+		}
+		return group(q[0])
+	}
+	return q.String()
+}
+
+// quoteValue returns s as a single search word, quoted if it contains spaces, quotes,
+// parentheses or colons, starts with "-" or is a keyword.
+func quoteValue(s string) string {
+	if s == "" || s == "OR" || s == "AND" || s == "NOT" ||
+		strings.ContainsAny(s, " \t\r\n\"():") || strings.HasPrefix(s, "-") {
+		return quote(s)
+	}
+	return s
+}
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quote returns s in double quotes, with backslashes and double quotes escaped.
+func quote(s string) string {
+	return `"` + quoteEscaper.Replace(s) + `"`
+}
+
+// formatTime returns t as a date if it is at midnight, otherwise in RFC 3339 format.
+func formatTime(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		if t.Location() == time.UTC {
+			return t.Format(time.DateOnly)
+		}
+	}
+	return t.Format(time.RFC3339)
+}
+
+// conjuncts returns the queries that must all match for q to match, flattening
+// nested Ands.
+func conjuncts(q Query) []Query {
+	switch q := q.(type) {
+	case nil:
+		return nil
+	case And:
+		var out []Query
+		for _, sub := range q {
+			out = append(out, conjuncts(sub)...)
+		}
+		return out
+	case Or:
+		if len(q) == 1 {
+			return conjuncts(q[0])
+		}
+	}
+	return []Query{q}
+}
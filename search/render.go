@@ -0,0 +1,250 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported is returned by Native when a query cannot be expressed in the
+// provider's search language.
+var ErrUnsupported = errors.New("search: not supported by provider")
+
+// Dialect is a provider's native search language.
+type Dialect int
+
+// Dialects returned by DialectFor.
+const (
+	// DialectNone means the provider has no native search; only the structured
+	// list filters can be used.
+	DialectNone Dialect = iota
+	// DialectGmail is Gmail's search box syntax, used by Google grants.
+	DialectGmail
+	// DialectKQL is the Keyword Query Language of Microsoft Graph $search, used by
+	// Microsoft grants.
+	DialectKQL
+	// DialectIMAP is IMAP SEARCH (RFC 3501), used by IMAP, iCloud and Yahoo grants.
+	DialectIMAP
+)
+
+// String returns the name of the dialect.
+func (d Dialect) String() string {
+	switch d {
+	case DialectGmail:
+		return "gmail"
+	case DialectKQL:
+		return "kql"
+	case DialectIMAP:
+		return "imap"
+	}
+	return "none"
+}
+
+// DialectFor returns the search language of a grant's provider, as in
+// grants.Grant.Provider. Exchange (EWS) and unknown providers get DialectNone.
+func DialectFor(provider string) Dialect {
+	switch strings.ToLower(provider) {
+	case "google", "gmail":
+		return DialectGmail
+	case "microsoft":
+		return DialectKQL
+	case "imap", "icloud", "yahoo":
+		return DialectIMAP
+	}
+	return DialectNone
+}
+
+// Native renders q in the search language of provider, for use as
+// messages.ListOptions.SearchQueryNative or threads.ListOptions.SearchQueryNative.
+// It returns an error wrapping ErrUnsupported naming the first clause that cannot be
+// expressed; Build falls back to the structured filters instead.
+//
+// Microsoft and IMAP only search by day, so After and Before are rounded to whole
+// days in UTC, outwards or inwards under Not: the results include every match, and
+// possibly messages from the same day outside the range.
+func Native(provider string, q Query) (string, error) {
+	return render(DialectFor(provider), q)
+}
+
+func render(d Dialect, q Query) (string, error) {
+	if d == DialectNone {
+		return "", fmt.Errorf("%w: no native search", ErrUnsupported)
+	}
+	r := renderer{d: d}
+	s := r.query(q, false)
+	if r.unsupported != nil {
+		return "", fmt.Errorf("%w: %s cannot express %s", ErrUnsupported, d, r.unsupported)
+	}
+	return s, nil
+}
+
+type renderer struct {
+	d           Dialect
+	unsupported Query
+	// negated is true inside an odd number of Nots, where rounding dates
+	// outwards would lose matches.
+	negated bool
+	// approximate is set when a date was rounded to a whole day.
+	approximate bool
+}
+
+func (r *renderer) fail(q Query) string {
+	if r.unsupported == nil {
+		r.unsupported = q
+	}
+	return ""
+}
+
+// query renders q; nested is true inside Or and Not, where a sequence of several
+// queries needs parentheses.
+func (r *renderer) query(q Query, nested bool) string {
+	switch q := q.(type) {
+	case Term:
+		return r.term(q)
+	case HasAttachment:
+		switch r.d {
+		case DialectGmail:
+			return "has:attachment"
+		case DialectKQL:
+			return "hasattachments:true"
+		}
+		return r.fail(q)
+	case After:
+		switch r.d {
+		case DialectGmail:
+			return "after:" + strconv.FormatInt(q.Time.Unix(), 10)
+		}
+		day := r.day(q.Time, r.negated)
+		if r.d == DialectKQL {
+			return "received>=" + day.Format(time.DateOnly)
+		}
+		return "SINCE " + day.Format("2-Jan-2006")
+	case Before:
+		switch r.d {
+		case DialectGmail:
+			return "before:" + strconv.FormatInt(q.Time.Unix(), 10)
+		}
+		day := r.day(q.Time, !r.negated)
+		if r.d == DialectKQL {
+			return "received<" + day.Format(time.DateOnly)
+		}
+		return "BEFORE " + day.Format("2-Jan-2006")
+	case And:
+		return r.and(q, nested)
+	case Or:
+		return r.or(q)
+	case Not:
+		if q.Query == nil {
+			return r.fail(q)
+		}
+		r.negated = !r.negated
+		inner := r.query(q.Query, true)
+		r.negated = !r.negated
+		if r.d == DialectGmail {
+			return "-" + inner
+		}
+		return "NOT " + inner
+	}
+	return r.fail(q)
+}
+
+func (r *renderer) term(t Term) string {
+	switch r.d {
+	case DialectGmail:
+		if t.Field == FieldText {
+			return gmailValue(t.Value)
+		}
+		return string(t.Field) + ":" + gmailValue(t.Value)
+	case DialectKQL:
+		if t.Field == FieldText {
+			return phrase(t.Value)
+		}
+		return string(t.Field) + ":" + phrase(t.Value)
+	}
+	// IMAP quoted strings allow escapes but not line breaks.
+	value := quote(strings.Join(strings.Fields(t.Value), " "))
+	switch t.Field {
+	case FieldFrom:
+		return "FROM " + value
+	case FieldTo:
+		return "TO " + value
+	case FieldSubject:
+		return "SUBJECT " + value
+	}
+	return "TEXT " + value
+}
+
+func (r *renderer) and(q And, nested bool) string {
+	if len(q) == 0 {
+		return r.fail(q)
+	}
+	if len(q) == 1 {
+		return r.query(q[0], nested)
+	}
+	parts := make([]string, len(q))
+	for i, sub := range q {
+		parts[i] = r.query(sub, true)
+	}
+	sep := " "
+	if r.d == DialectKQL {
+		sep = " AND "
+	}
+	s := strings.Join(parts, sep)
+	if nested {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+func (r *renderer) or(q Or) string {
+	if len(q) == 0 {
+		return r.fail(q)
+	}
+	if len(q) == 1 {
+		return r.query(q[0], true)
+	}
+	parts := make([]string, len(q))
+	for i, sub := range q {
+		parts[i] = r.query(sub, true)
+	}
+	if r.d == DialectIMAP {
+		// IMAP OR takes exactly two keys: OR a OR b c.
+		s := parts[len(parts)-1]
+		for i := len(parts) - 2; i >= 0; i-- {
+			s = "OR " + parts[i] + " " + s
+		}
+		return "(" + s + ")"
+	}
+	return "(" + strings.Join(parts, " OR ") + ")"
+}
+
+// gmailValue returns s as a single Gmail search word, as a phrase unless it is a
+// plain word.
+func gmailValue(s string) string {
+	if s == "" || s == "OR" || s == "AND" || strings.ContainsAny(s, " \t\r\n\"(){}:") || strings.HasPrefix(s, "-") {
+		return phrase(s)
+	}
+	return s
+}
+
+// phrase returns s in double quotes for Gmail and KQL, which have no escape for a
+// quote inside a phrase, so quotes and line breaks become spaces.
+func phrase(s string) string {
+	return `"` + strings.Join(strings.Fields(strings.ReplaceAll(s, `"`, " ")), " ") + `"`
+}
+
+// day returns the start of t's day in UTC, or of the next day if up is true and t is
+// not at midnight UTC.
+func (r *renderer) day(t time.Time, up bool) time.Time {
+	day := t.UTC().Truncate(24 * time.Hour)
+	if day.Equal(t) {
+		return day
+	}
+	r.approximate = true
+	if up {
+		return day.AddDate(0, 0, 1)
+	}
+	return day
+}
@@ -0,0 +1,67 @@
+package search
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNative(t *testing.T) {
+	noon := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	q := And{
+		From("alice@example.com"),
+		Subject("q1 report"),
+		Or{Text("invoice"), Text("receipt")},
+		Not{Text("draft")},
+		After{noon},
+	}
+	tests := []struct {
+		provider string
+		q        Query
+		want     string
+		wantErr  bool
+	}{
+		{"google", q, `from:alice@example.com subject:"q1 report" (invoice OR receipt) -draft after:1709294400`, false},
+		{"microsoft", q, `from:"alice@example.com" AND subject:"q1 report" AND ("invoice" OR "receipt") AND NOT "draft" AND received>=2024-03-01`, false},
+		{"imap", q, `FROM "alice@example.com" SUBJECT "q1 report" (OR TEXT "invoice" TEXT "receipt") NOT TEXT "draft" SINCE 1-Mar-2024`, false},
+		{"icloud", Or{Text("a"), Text("b"), Text("c")}, `(OR TEXT "a" OR TEXT "b" TEXT "c")`, false},
+		{"google", HasAttachment{}, "has:attachment", false},
+		{"microsoft", HasAttachment{}, "hasattachments:true", false},
+		{"microsoft", Before{noon}, "received<2024-03-02", false},
+		{"microsoft", Not{Before{noon}}, "NOT received<2024-03-01", false},
+		{"imap", Not{Or{From("a"), And{To("b"), Text("c")}}}, `NOT (OR FROM "a" (TO "b" TEXT "c"))`, false},
+		{"google", Text(`say "hi" (now)`), `"say hi (now)"`, false},
+		{"imap", Text(`say "hi"`), `TEXT "say \"hi\""`, false},
+		{"imap", HasAttachment{}, "", true},
+		{"imap", Not{HasAttachment{}}, "", true},
+		{"ews", Text("a"), "", true},
+		{"google", And{}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider+" "+tt.q.String(), func(t *testing.T) {
+			got, err := Native(tt.provider, tt.q)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Native() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrUnsupported) {
+				t.Errorf("Native() error = %v, want ErrUnsupported", err)
+			}
+			if got != tt.want {
+				t.Errorf("Native() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialectFor(t *testing.T) {
+	tests := map[string]Dialect{
+		"google": DialectGmail, "Microsoft": DialectKQL, "imap": DialectIMAP,
+		"yahoo": DialectIMAP, "ews": DialectNone, "": DialectNone,
+	}
+	for provider, want := range tests {
+		if got := DialectFor(provider); got != want {
+			t.Errorf("DialectFor(%q) = %s, want %s", provider, got, want)
+		}
+	}
+}
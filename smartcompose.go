@@ -14,13 +14,13 @@ func (s *SmartComposeService) ComposeMessage(ctx context.Context, grantID string
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, compose)
 	if err != nil {
-		return nil, fmt.Errorf("smartcompose.ComposeMessage: %w", err)
+		return nil, newOpError("smartcompose.ComposeMessage", grantID, "", err)
 	}
 
 	var result smartcompose.ComposeResponse
 	_, err = s.client.Do(req, &result)
 	if err != nil {
-		return nil, fmt.Errorf("smartcompose.ComposeMessage: %w", err)
+		return nil, newOpError("smartcompose.ComposeMessage", grantID, "", err)
 	}
 
 	return &result, nil
@@ -32,13 +32,13 @@ func (s *SmartComposeService) ComposeReply(ctx context.Context, grantID, message
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, compose)
 	if err != nil {
-		return nil, fmt.Errorf("smartcompose.ComposeReply(%s): %w", messageID, err)
+		return nil, newOpError("smartcompose.ComposeReply", grantID, messageID, err)
 	}
 
 	var result smartcompose.ComposeResponse
 	_, err = s.client.Do(req, &result)
 	if err != nil {
-		return nil, fmt.Errorf("smartcompose.ComposeReply(%s): %w", messageID, err)
+		return nil, newOpError("smartcompose.ComposeReply", grantID, messageID, err)
 	}
 
 	return &result, nil
@@ -0,0 +1,12 @@
+package smartcompose
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+func (r *ComposeRequest) Validate() error {
+	v := &common.ValidationError{}
+	if r.Prompt == "" {
+		v.Add("prompt", "is required")
+	}
+	return v.Err()
+}
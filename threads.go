@@ -12,9 +12,13 @@ import (
 func (s *ThreadsService) List(ctx context.Context, grantID string, opts *threads.ListOptions) (*ListResponse[threads.Thread], error) {
 	path := fmt.Sprintf("/v3/grants/%s/threads", grantID)
 
+	if err := s.client.validate(opts); err != nil {
+		return nil, newOpError("threads.List", grantID, "", err)
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("threads.List: %w", err)
+		return nil, newOpError("threads.List", grantID, "", err)
 	}
 
 	if opts != nil {
@@ -26,7 +30,7 @@ func (s *ThreadsService) List(ctx context.Context, grantID string, opts *threads
 	var data []threads.Thread
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("threads.List: %w", err)
+		return nil, newOpError("threads.List", grantID, "", err)
 	}
 
 	return &ListResponse[threads.Thread]{
@@ -42,13 +46,13 @@ func (s *ThreadsService) Get(ctx context.Context, grantID, threadID string) (*th
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("threads.Get(%s): %w", threadID, err)
+		return nil, newOpError("threads.Get", grantID, threadID, err)
 	}
 
 	var thread threads.Thread
 	_, err = s.client.Do(req, &thread)
 	if err != nil {
-		return nil, fmt.Errorf("threads.Get(%s): %w", threadID, err)
+		return nil, newOpError("threads.Get", grantID, threadID, err)
 	}
 
 	return &thread, nil
@@ -60,13 +64,13 @@ func (s *ThreadsService) Update(ctx context.Context, grantID, threadID string, u
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("threads.Update(%s): %w", threadID, err)
+		return nil, newOpError("threads.Update", grantID, threadID, err)
 	}
 
 	var thread threads.Thread
 	_, err = s.client.Do(req, &thread)
 	if err != nil {
-		return nil, fmt.Errorf("threads.Update(%s): %w", threadID, err)
+		return nil, newOpError("threads.Update", grantID, threadID, err)
 	}
 
 	return &thread, nil
@@ -78,12 +82,12 @@ func (s *ThreadsService) Delete(ctx context.Context, grantID, threadID string) e
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("threads.Delete(%s): %w", threadID, err)
+		return newOpError("threads.Delete", grantID, threadID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("threads.Delete(%s): %w", threadID, err)
+		return newOpError("threads.Delete", grantID, threadID, err)
 	}
 
 	return nil
@@ -0,0 +1,188 @@
+package threads
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mqasimca/nylas-go/drafts"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+// Conversation is a thread with all of its messages and drafts, arranged as a reply
+// tree.
+type Conversation struct {
+	// Thread is the thread itself.
+	Thread *Thread
+	// Messages are the messages of the thread, oldest first.
+	Messages []messages.Message
+	// Drafts are the drafts of the thread, oldest first.
+	Drafts []drafts.Draft
+	// Roots are the messages that reply to nothing in the thread, oldest first.
+	// Usually there is one, the message that started the thread.
+	Roots []*Node
+}
+
+// Node is a message or draft in a conversation's reply tree.
+type Node struct {
+	// Message is the message, or nil for a draft.
+	Message *messages.Message
+	// Draft is the draft, or nil for a message.
+	Draft *drafts.Draft
+	// Parent is the message this one replies to, or nil for a root.
+	Parent *Node
+	// Replies are the replies to this message, oldest first.
+	Replies []*Node
+	// Depth is 0 for roots, 1 for their replies, and so on.
+	Depth int
+}
+
+// ID returns the ID of the message or draft.
+func (n *Node) ID() string {
+	if n.Draft != nil {
+		return n.Draft.ID
+	}
+	return n.Message.ID
+}
+
+// Date returns when the message was sent or the draft last saved.
+func (n *Node) Date() time.Time {
+	if n.Draft != nil {
+		return time.Unix(n.Draft.Date, 0)
+	}
+	return time.Unix(n.Message.Date, 0)
+}
+
+// NewConversation arranges the messages and drafts of thread into a reply tree.
+//
+// A message replies to the message named by its In-Reply-To header, or failing
+// that to the last message in its References header that is in the thread; both
+// need the messages to be fetched with messages.FieldsIncludeHeaders. Messages
+// whose parent is not in the thread become roots. Drafts carry no headers, so
+// each draft is placed as a reply to the latest message.
+func NewConversation(thread *Thread, msgs []messages.Message, ds []drafts.Draft) *Conversation {
+	c := &Conversation{
+		Thread:   thread,
+		Messages: append([]messages.Message(nil), msgs...),
+		Drafts:   append([]drafts.Draft(nil), ds...),
+	}
+	sort.SliceStable(c.Messages, func(i, j int) bool {
+		return before(c.Messages[i].Date, c.Messages[i].ID, c.Messages[j].Date, c.Messages[j].ID)
+	})
+	sort.SliceStable(c.Drafts, func(i, j int) bool {
+		return before(c.Drafts[i].Date, c.Drafts[i].ID, c.Drafts[j].Date, c.Drafts[j].ID)
+	})
+
+	nodes := make([]*Node, len(c.Messages))
+	byMessageID := make(map[string]*Node)
+	for i := range c.Messages {
+		nodes[i] = &Node{Message: &c.Messages[i]}
+		if id := c.Messages[i].MessageID(); id != "" {
+			if _, dup := byMessageID[id]; !dup {
+				byMessageID[id] = nodes[i]
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		if parent := findParent(n, byMessageID); parent != nil {
+			n.Parent = parent
+			parent.Replies = append(parent.Replies, n)
+		} else {
+			c.Roots = append(c.Roots, n)
+		}
+	}
+
+	var latest *Node
+	if len(nodes) > 0 {
+		latest = nodes[len(nodes)-1]
+	}
+	for i := range c.Drafts {
+		n := &Node{Draft: &c.Drafts[i], Parent: latest}
+		if latest != nil {
+			latest.Replies = append(latest.Replies, n)
+		} else {
+			c.Roots = append(c.Roots, n)
+		}
+	}
+
+	var setDepth func(ns []*Node, depth int)
+	setDepth = func(ns []*Node, depth int) {
+		sortNodes(ns)
+		for _, n := range ns {
+			n.Depth = depth
+			setDepth(n.Replies, depth+1)
+		}
+	}
+	setDepth(c.Roots, 0)
+	return c
+}
+
+// findParent returns the node n replies to, skipping any candidate that would make
+// n its own ancestor.
+func findParent(n *Node, byMessageID map[string]*Node) *Node {
+	candidates := n.Message.InReplyTo()
+	refs := n.Message.References()
+	for i := len(refs) - 1; i >= 0; i-- {
+		candidates = append(candidates, refs[i])
+	}
+	for _, id := range candidates {
+		p, ok := byMessageID[id]
+		if !ok || p == n || isAncestor(n, p) {
+			continue
+		}
+		return p
+	}
+	return nil
+}
+
+// isAncestor reports whether a is p or one of p's ancestors.
+func isAncestor(a, p *Node) bool {
+	for ; p != nil; p = p.Parent {
+		if p == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Walk calls fn for every message and draft in depth-first order, each before its
+// replies, which is the order a nested conversation view shows them in.
+func (c *Conversation) Walk(fn func(n *Node)) {
+	var walk func(ns []*Node)
+	walk = func(ns []*Node) {
+		for _, n := range ns {
+			fn(n)
+			walk(n.Replies)
+		}
+	}
+	walk(c.Roots)
+}
+
+// Find returns the node of the message or draft with the given ID.
+func (c *Conversation) Find(id string) (*Node, bool) {
+	var found *Node
+	c.Walk(func(n *Node) {
+		if found == nil && n.ID() == id {
+			found = n
+		}
+	})
+	return found, found != nil
+}
+
+func sortNodes(ns []*Node) {
+	sort.SliceStable(ns, func(i, j int) bool {
+		// Messages come before drafts written at the same time.
+		di, dj := ns[i].Date(), ns[j].Date()
+		if !di.Equal(dj) {
+			return di.Before(dj)
+		}
+		return ns[i].Draft == nil && ns[j].Draft != nil
+	})
+}
+
+func before(dateA int64, idA string, dateB int64, idB string) bool {
+	if dateA != dateB {
+		return dateA < dateB
+	}
+	return idA < idB
+}
@@ -0,0 +1,117 @@
+package threads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/drafts"
+	"github.com/mqasimca/nylas-go/messages"
+)
+
+func msg(id string, date int64, headers ...string) messages.Message {
+	m := messages.Message{ID: id, Date: date}
+	for i := 0; i+1 < len(headers); i += 2 {
+		m.Headers = append(m.Headers, messages.Header{Name: headers[i], Value: headers[i+1]})
+	}
+	return m
+}
+
+// shape renders a conversation as "id@depth" in walk order.
+func shape(c *Conversation) string {
+	var parts []string
+	c.Walk(func(n *Node) {
+		parts = append(parts, n.ID()+"@"+string(rune('0'+n.Depth)))
+	})
+	return strings.Join(parts, " ")
+}
+
+func TestNewConversation(t *testing.T) {
+	tests := []struct {
+		name   string
+		msgs   []messages.Message
+		drafts []drafts.Draft
+		want   string
+	}{
+		{
+			name: "in-reply-to",
+			msgs: []messages.Message{
+				msg("c", 300, "Message-ID", "<c@x>", "In-Reply-To", "<a@x>"),
+				msg("a", 100, "Message-ID", "<a@x>"),
+				msg("b", 200, "Message-ID", "<b@x>", "In-Reply-To", "<a@x>"),
+				msg("d", 400, "Message-ID", "<d@x>", "In-Reply-To", "<b@x>"),
+			},
+			want: "a@0 b@1 d@2 c@1",
+		},
+		{
+			name: "references fallback",
+			msgs: []messages.Message{
+				msg("a", 100, "Message-ID", "<a@x>"),
+				msg("b", 200, "Message-ID", "<b@x>", "References", "<a@x>"),
+				msg("c", 300, "Message-ID", "<c@x>", "In-Reply-To", "<gone@x>", "References", "<a@x> <b@x> <gone@x>"),
+			},
+			want: "a@0 b@1 c@2",
+		},
+		{
+			name: "orphans become roots",
+			msgs: []messages.Message{
+				msg("b", 200, "Message-ID", "<b@x>", "In-Reply-To", "<gone@x>"),
+				msg("a", 100),
+			},
+			want: "a@0 b@0",
+		},
+		{
+			name: "cycle",
+			msgs: []messages.Message{
+				msg("a", 100, "Message-ID", "<a@x>", "In-Reply-To", "<b@x>"),
+				msg("b", 200, "Message-ID", "<b@x>", "In-Reply-To", "<a@x>"),
+			},
+			want: "b@0 a@1",
+		},
+		{
+			name: "drafts reply to the latest message",
+			msgs: []messages.Message{
+				msg("a", 100, "Message-ID", "<a@x>"),
+				msg("b", 200, "Message-ID", "<b@x>", "In-Reply-To", "<a@x>"),
+			},
+			drafts: []drafts.Draft{{ID: "d2", Date: 500}, {ID: "d1", Date: 200}},
+			want:   "a@0 b@1 d1@2 d2@2",
+		},
+		{
+			name:   "drafts only",
+			drafts: []drafts.Draft{{ID: "d1", Date: 100}},
+			want:   "d1@0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConversation(&Thread{ID: "t1"}, tt.msgs, tt.drafts)
+			if got := shape(c); got != tt.want {
+				t.Errorf("tree = %q, want %q", got, tt.want)
+			}
+			for i := 1; i < len(c.Messages); i++ {
+				if c.Messages[i-1].Date > c.Messages[i].Date {
+					t.Errorf("Messages not ordered by date: %v", c.Messages)
+				}
+			}
+		})
+	}
+}
+
+func TestConversation_Find(t *testing.T) {
+	c := NewConversation(&Thread{ID: "t1"}, []messages.Message{
+		msg("a", 100, "Message-ID", "<a@x>"),
+		msg("b", 200, "Message-ID", "<b@x>", "In-Reply-To", "<a@x>"),
+	}, []drafts.Draft{{ID: "d1", Date: 300}})
+
+	n, ok := c.Find("d1")
+	if !ok || n.Draft == nil || n.Parent == nil || n.Parent.ID() != "b" {
+		t.Errorf("Find(d1) = %+v, %v", n, ok)
+	}
+	if n, ok := c.Find("b"); !ok || n.Message != &c.Messages[1] {
+		t.Errorf("Find(b) = %+v, %v", n, ok)
+	}
+	if _, ok := c.Find("missing"); ok {
+		t.Error("Find(missing) found a node")
+	}
+}
@@ -2,4 +2,7 @@
 //
 // A thread represents a conversation containing one or more messages.
 // Use the ThreadsService methods on the main nylas.Client to interact with threads.
+//
+// NewConversation arranges a thread's messages and drafts into a reply tree; the
+// client's ThreadsService.GetConversation fetches a thread and builds one.
 package threads
@@ -0,0 +1,17 @@
+package threads
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes t, keeping fields this package does not declare in Extra.
+func (t *Thread) UnmarshalJSON(data []byte) error {
+	type alias Thread
+	extra, err := common.UnmarshalExtra(data, (*alias)(t))
+	t.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes t together with the fields kept in Extra.
+func (t Thread) MarshalJSON() ([]byte, error) {
+	type alias Thread
+	return common.MarshalExtra(alias(t), t.Extra)
+}
@@ -0,0 +1,19 @@
+package threads
+
+import (
+	"time"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+// SetLatestMessageAfter filters threads whose latest message is after t.
+func (o *ListOptions) SetLatestMessageAfter(t time.Time) *ListOptions {
+	o.LatestMessageAfter = common.UnixPtr(t)
+	return o
+}
+
+// SetLatestMessageBefore filters threads whose latest message is before t.
+func (o *ListOptions) SetLatestMessageBefore(t time.Time) *ListOptions {
+	o.LatestMessageBefore = common.UnixPtr(t)
+	return o
+}
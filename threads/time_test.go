@@ -0,0 +1,17 @@
+package threads
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListOptions_TimeSetters(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	opts := (&ListOptions{}).SetLatestMessageAfter(start).SetLatestMessageBefore(start.Add(time.Hour))
+	if *opts.LatestMessageAfter != 1700000000 || *opts.LatestMessageBefore != 1700003600 || opts.Validate() != nil {
+		t.Errorf("ListOptions = %+v", opts)
+	}
+	if err := (&ListOptions{}).SetLatestMessageAfter(time.Time{}).Validate(); err == nil {
+		t.Error("Validate() with zero time error = nil")
+	}
+}
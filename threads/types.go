@@ -1,6 +1,7 @@
 package threads
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/mqasimca/nylas-go/common"
@@ -45,6 +46,9 @@ type Thread struct {
 	Folders []string `json:"folders,omitempty"`
 	// Labels contains Gmail label IDs (Gmail only).
 	Labels []string `json:"labels,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // MessageRef is a reference to the latest message or draft in a thread.
@@ -97,7 +101,11 @@ type ListOptions struct {
 	// HasAttachment filters threads with or without attachments.
 	HasAttachment *bool `json:"has_attachment,omitempty"`
 	// SearchQueryNative is a provider-specific search query.
+	// Package search builds one for any provider.
 	SearchQueryNative *string `json:"search_query_native,omitempty"`
+	// MetadataPair filters by metadata key-value pair ("key:value" format). Only the
+	// keys key1 to key5 can be filtered on; see common.MetadataPair.
+	MetadataPair *string `json:"metadata_pair,omitempty"`
 }
 
 // Values converts ListOptions to URL query parameters.
@@ -151,6 +159,9 @@ func (o *ListOptions) Values() map[string]any {
 	if o.SearchQueryNative != nil {
 		v["search_query_native"] = *o.SearchQueryNative
 	}
+	if o.MetadataPair != nil {
+		v["metadata_pair"] = *o.MetadataPair
+	}
 	return v
 }
 
@@ -99,6 +99,11 @@ func TestListOptions_Values(t *testing.T) {
 			opts: &ListOptions{SearchQueryNative: ptrStr("from:test@example.com")},
 			want: 1,
 		},
+		{
+			name: "with metadata_pair",
+			opts: &ListOptions{MetadataPair: ptrStr("key1:crm-42")},
+			want: 1,
+		},
 		{
 			name: "with all options",
 			opts: &ListOptions{
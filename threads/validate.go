@@ -0,0 +1,23 @@
+package threads
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	for i, id := range r.Folders {
+		if id == "" {
+			v.Add(common.IndexPath("folders", i), "must not be empty")
+		}
+	}
+	return v.Err()
+}
+
+// Validate checks that the time filters are Unix timestamps in seconds and that
+// MetadataPair names a filterable key.
+func (o *ListOptions) Validate() error {
+	v := &common.ValidationError{}
+	common.CheckOptionalTimeRange(v, "latest_message_after", o.LatestMessageAfter, "latest_message_before", o.LatestMessageBefore)
+	common.CheckMetadataPair(v, "metadata_pair", o.MetadataPair)
+	return v.Err()
+}
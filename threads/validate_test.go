@@ -0,0 +1,35 @@
+package threads
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantPath string
+	}{
+		{"empty folder", (&UpdateRequest{Folders: []string{"inbox", ""}}).Validate(), "folders[1]"},
+		{"metadata_pair", (&ListOptions{MetadataPair: ptrStr("key3:x")}).Validate(), "-"},
+		{"metadata_pair unfilterable key", (&ListOptions{MetadataPair: ptrStr("crm:x")}).Validate(), "metadata_pair"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPath == "-" {
+				if tt.err != nil {
+					t.Errorf("Validate() error = %v, want nil", tt.err)
+				}
+				return
+			}
+			var verr *common.ValidationError
+			if !errors.As(tt.err, &verr) || verr.Fields[0].Path != tt.wantPath {
+				t.Errorf("Validate() error = %v, want problem at %q", tt.err, tt.wantPath)
+			}
+		})
+	}
+}
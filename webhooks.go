@@ -15,7 +15,7 @@ func (s *WebhooksService) List(ctx context.Context, opts *webhooks.ListOptions)
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.List: %w", err)
+		return nil, newOpError("webhooks.List", "", "", err)
 	}
 
 	if opts != nil {
@@ -27,7 +27,7 @@ func (s *WebhooksService) List(ctx context.Context, opts *webhooks.ListOptions)
 	var data []webhooks.Webhook
 	nextCursor, requestID, err := s.client.DoList(req, &data)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.List: %w", err)
+		return nil, newOpError("webhooks.List", "", "", err)
 	}
 
 	return &ListResponse[webhooks.Webhook]{
@@ -43,13 +43,13 @@ func (s *WebhooksService) Get(ctx context.Context, webhookID string) (*webhooks.
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.Get(%s): %w", webhookID, err)
+		return nil, newOpError("webhooks.Get", "", webhookID, err)
 	}
 
 	var webhook webhooks.Webhook
 	_, err = s.client.Do(req, &webhook)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.Get(%s): %w", webhookID, err)
+		return nil, newOpError("webhooks.Get", "", webhookID, err)
 	}
 
 	return &webhook, nil
@@ -77,13 +77,13 @@ func (s *WebhooksService) Create(ctx context.Context, create *webhooks.CreateReq
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, create)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.Create: %w", err)
+		return nil, newOpError("webhooks.Create", "", "", err)
 	}
 
 	var webhook webhooks.Webhook
 	_, err = s.client.Do(req, &webhook)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.Create: %w", err)
+		return nil, newOpError("webhooks.Create", "", "", err)
 	}
 
 	return &webhook, nil
@@ -102,13 +102,13 @@ func (s *WebhooksService) Update(ctx context.Context, webhookID string, update *
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, update)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.Update(%s): %w", webhookID, err)
+		return nil, newOpError("webhooks.Update", "", webhookID, err)
 	}
 
 	var webhook webhooks.Webhook
 	_, err = s.client.Do(req, &webhook)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.Update(%s): %w", webhookID, err)
+		return nil, newOpError("webhooks.Update", "", webhookID, err)
 	}
 
 	return &webhook, nil
@@ -120,12 +120,12 @@ func (s *WebhooksService) Delete(ctx context.Context, webhookID string) error {
 
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
-		return fmt.Errorf("webhooks.Delete(%s): %w", webhookID, err)
+		return newOpError("webhooks.Delete", "", webhookID, err)
 	}
 
 	_, err = s.client.Do(req, nil)
 	if err != nil {
-		return fmt.Errorf("webhooks.Delete(%s): %w", webhookID, err)
+		return newOpError("webhooks.Delete", "", webhookID, err)
 	}
 
 	return nil
@@ -148,13 +148,13 @@ func (s *WebhooksService) RotateSecret(ctx context.Context, webhookID string) (*
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.RotateSecret(%s): %w", webhookID, err)
+		return nil, newOpError("webhooks.RotateSecret", "", webhookID, err)
 	}
 
 	var result webhooks.RotateSecretResponse
 	_, err = s.client.Do(req, &result)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.RotateSecret(%s): %w", webhookID, err)
+		return nil, newOpError("webhooks.RotateSecret", "", webhookID, err)
 	}
 
 	return &result, nil
@@ -195,13 +195,13 @@ func (s *WebhooksService) GetIPAddresses(ctx context.Context) (*webhooks.IPAddre
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.GetIPAddresses: %w", err)
+		return nil, newOpError("webhooks.GetIPAddresses", "", "", err)
 	}
 
 	var result webhooks.IPAddressesResponse
 	_, err = s.client.Do(req, &result)
 	if err != nil {
-		return nil, fmt.Errorf("webhooks.GetIPAddresses: %w", err)
+		return nil, newOpError("webhooks.GetIPAddresses", "", "", err)
 	}
 
 	return &result, nil
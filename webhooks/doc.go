@@ -2,4 +2,8 @@
 //
 // Webhooks allow you to receive notifications when events occur in connected accounts.
 // Use the WebhooksService methods on the main nylas.Client to manage webhook subscriptions.
+//
+// ParseNotification decodes the notifications Nylas delivers, with typed payloads for
+// the message.opened, message.link_clicked and thread.replied tracking triggers, and
+// TrackingReport aggregates those into engagement per message and per tracking label.
 package webhooks
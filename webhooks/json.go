@@ -0,0 +1,17 @@
+package webhooks
+
+import "github.com/mqasimca/nylas-go/common"
+
+// UnmarshalJSON decodes w, keeping fields this package does not declare in Extra.
+func (w *Webhook) UnmarshalJSON(data []byte) error {
+	type alias Webhook
+	extra, err := common.UnmarshalExtra(data, (*alias)(w))
+	w.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes w together with the fields kept in Extra.
+func (w Webhook) MarshalJSON() ([]byte, error) {
+	type alias Webhook
+	return common.MarshalExtra(alias(w), w.Extra)
+}
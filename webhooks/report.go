@@ -0,0 +1,338 @@
+package webhooks
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// TrackingReport aggregates tracking notifications into per-message and per-label
+// engagement. It is safe for concurrent use, so webhook handlers can share one.
+//
+// Each notification repeats the running totals for its message, so totals are taken
+// from the latest counts rather than added up, and notifications delivered more than
+// once are only counted once. Only the IDs of the last MaxSeenNotifications
+// notifications are remembered for that; an older notification delivered again is
+// recorded again, which leaves the totals unchanged. Register sent messages with
+// AddSent so that labels know how many messages could have been opened.
+//
+// A report keeps an entry for every message it has seen. For a long-running
+// process, start a new report for each reporting period.
+//
+// Example:
+//
+//	report := webhooks.NewTrackingReport()
+//	http.HandleFunc("/webhooks/nylas", func(w http.ResponseWriter, r *http.Request) {
+//	    body, _ := io.ReadAll(r.Body)
+//	    if n, err := webhooks.ParseNotification(body); err == nil {
+//	        _ = report.Add(n)
+//	    }
+//	})
+//	for _, l := range report.Labels() {
+//	    log.Printf("%s: %.0f%% opened, %.0f%% replied", l.Label, 100*l.OpenRate(), 100*l.ReplyRate())
+//	}
+type TrackingReport struct {
+	mu        sync.Mutex
+	seen      map[string]bool
+	seenOrder []string // ring of the IDs in seen, oldest at seenNext once full
+	seenNext  int
+	seenLimit int
+	messages  map[string]*MessageEngagement
+	openers   map[string]map[string]bool
+}
+
+// MaxSeenNotifications is the number of notification IDs a TrackingReport remembers
+// to skip redeliveries. Nylas redelivers within hours, long before that many other
+// notifications arrive for most applications.
+const MaxSeenNotifications = 100000
+
+// MessageEngagement is the engagement with one tracked message.
+type MessageEngagement struct {
+	// MessageID is the ID of the tracked message.
+	MessageID string
+	// Label is the label set in the message's TrackingOptions.
+	Label string
+	// Sent is true if the message was registered with AddSent.
+	Sent bool
+	// Opens is the total number of opens.
+	Opens int
+	// Openers is the number of distinct IP address and user agent pairs among the
+	// opens seen. Nylas does not identify recipients, so this only estimates how
+	// many people opened the message.
+	Openers int
+	// FirstOpened and LastOpened are the times of the earliest and latest open seen.
+	FirstOpened, LastOpened time.Time
+	// Clicks is the total number of link clicks.
+	Clicks int
+	// Links counts the clicks of each tracked link, in link index order.
+	Links []LinkEngagement
+	// LastClicked is the time of the latest click seen.
+	LastClicked time.Time
+	// Replies is the total number of replies.
+	Replies int
+	// ThreadID is the thread of the replies, if any.
+	ThreadID string
+	// LastReplied is the time of the latest reply notification.
+	LastReplied time.Time
+}
+
+// LinkEngagement counts the clicks of one tracked link.
+type LinkEngagement struct {
+	// Index is the link's index in the message.
+	Index int
+	// URL is the link's target.
+	URL string
+	// Clicks is the total number of clicks.
+	Clicks int
+}
+
+// LabelEngagement is the engagement with all tracked messages sharing a label.
+type LabelEngagement struct {
+	// Label is the tracking label; "" collects messages without one.
+	Label string
+	// Messages is the number of messages that were sent or had any engagement.
+	Messages int
+	// Opened, Clicked and Replied are the numbers of messages opened, clicked or
+	// replied to at least once.
+	Opened, Clicked, Replied int
+	// Opens, Clicks and Replies are the totals over all messages.
+	Opens, Clicks, Replies int
+}
+
+// OpenRate returns the share of Messages that were opened, from 0 to 1.
+func (l LabelEngagement) OpenRate() float64 { return rate(l.Opened, l.Messages) }
+
+// ClickRate returns the share of Messages with a link click, from 0 to 1.
+func (l LabelEngagement) ClickRate() float64 { return rate(l.Clicked, l.Messages) }
+
+// ReplyRate returns the share of Messages that were replied to, from 0 to 1.
+func (l LabelEngagement) ReplyRate() float64 { return rate(l.Replied, l.Messages) }
+
+func rate(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// NewTrackingReport returns an empty TrackingReport.
+func NewTrackingReport() *TrackingReport {
+	return &TrackingReport{
+		seen:      make(map[string]bool),
+		seenLimit: MaxSeenNotifications,
+		messages:  make(map[string]*MessageEngagement),
+		openers:   make(map[string]map[string]bool),
+	}
+}
+
+// AddSent registers a message sent with tracking enabled, so it counts towards its
+// label even if it is never opened.
+func (r *TrackingReport) AddSent(messageID, label string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.message(messageID, label)
+	m.Sent = true
+}
+
+// Add records a tracking notification. Notifications of other types are ignored,
+// as are notifications whose ID was already recorded.
+func (r *TrackingReport) Add(n *Notification) error {
+	var err error
+	switch n.Type {
+	case TriggerMessageOpened:
+		var e *MessageOpened
+		if e, err = n.MessageOpened(); err == nil {
+			r.record(n.ID, func() { r.addOpened(e) })
+		}
+	case TriggerMessageLinkClicked:
+		var e *LinkClicked
+		if e, err = n.LinkClicked(); err == nil {
+			r.record(n.ID, func() { r.addLinkClicked(e) })
+		}
+	case TriggerThreadReplied:
+		var e *ThreadReplied
+		if e, err = n.ThreadReplied(); err == nil {
+			r.record(n.ID, func() { r.addThreadReplied(e) })
+		}
+	}
+	return err
+}
+
+// AddOpened records a message.opened object.
+func (r *TrackingReport) AddOpened(e *MessageOpened) {
+	r.record("", func() { r.addOpened(e) })
+}
+
+// AddLinkClicked records a message.link_clicked object.
+func (r *TrackingReport) AddLinkClicked(e *LinkClicked) {
+	r.record("", func() { r.addLinkClicked(e) })
+}
+
+// AddThreadReplied records a thread.replied object.
+func (r *TrackingReport) AddThreadReplied(e *ThreadReplied) {
+	r.record("", func() { r.addThreadReplied(e) })
+}
+
+// record runs add under the lock unless the notification ID was seen recently.
+func (r *TrackingReport) record(id string, add func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id != "" {
+		if r.seen[id] {
+			return
+		}
+		r.remember(id)
+	}
+	add()
+}
+
+// remember adds id to the seen set, forgetting the oldest ID once seenLimit IDs
+// are remembered.
+func (r *TrackingReport) remember(id string) {
+	if len(r.seenOrder) < r.seenLimit {
+		r.seenOrder = append(r.seenOrder, id)
+	} else {
+		delete(r.seen, r.seenOrder[r.seenNext])
+		r.seenOrder[r.seenNext] = id
+		r.seenNext = (r.seenNext + 1) % r.seenLimit
+	}
+	r.seen[id] = true
+}
+
+// message returns the engagement of messageID, creating it if needed.
+func (r *TrackingReport) message(messageID, label string) *MessageEngagement {
+	m, ok := r.messages[messageID]
+	if !ok {
+		m = &MessageEngagement{MessageID: messageID}
+		r.messages[messageID] = m
+	}
+	if label != "" {
+		m.Label = label
+	}
+	return m
+}
+
+func (r *TrackingReport) addOpened(e *MessageOpened) {
+	m := r.message(e.MessageID, e.Label)
+	m.Opens = max(m.Opens, e.MessageData.Count, len(e.Recents))
+
+	openers := r.openers[e.MessageID]
+	if openers == nil {
+		openers = make(map[string]bool)
+		r.openers[e.MessageID] = openers
+	}
+	for _, o := range e.Recents {
+		openers[o.IP+"\x00"+o.UserAgent] = true
+		m.FirstOpened = earliest(m.FirstOpened, o.Time())
+		m.LastOpened = latest(m.LastOpened, o.Time())
+	}
+	if ts := e.MessageData.Timestamp; ts != 0 {
+		m.LastOpened = latest(m.LastOpened, time.Unix(ts, 0))
+	}
+	m.Openers = len(openers)
+}
+
+func (r *TrackingReport) addLinkClicked(e *LinkClicked) {
+	m := r.message(e.MessageID, e.Label)
+	for i, l := range e.LinkData {
+		for len(m.Links) <= i {
+			m.Links = append(m.Links, LinkEngagement{Index: len(m.Links)})
+		}
+		if l.URL != "" {
+			m.Links[i].URL = l.URL
+		}
+		m.Links[i].Clicks = max(m.Links[i].Clicks, l.Count)
+	}
+	for _, c := range e.Recents {
+		m.LastClicked = latest(m.LastClicked, c.Time())
+	}
+	m.Clicks = 0
+	for _, l := range m.Links {
+		m.Clicks += l.Clicks
+	}
+}
+
+func (r *TrackingReport) addThreadReplied(e *ThreadReplied) {
+	m := r.message(e.RootMessageID, e.Label)
+	m.Replies = max(m.Replies, e.ReplyData.Count, 1)
+	if e.ThreadID != "" {
+		m.ThreadID = e.ThreadID
+	}
+	m.LastReplied = latest(m.LastReplied, time.Unix(e.Timestamp, 0))
+}
+
+// Message returns the engagement with one message.
+func (r *TrackingReport) Message(messageID string) (MessageEngagement, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.messages[messageID]
+	if !ok {
+		return MessageEngagement{}, false
+	}
+	return m.clone(), true
+}
+
+// Messages returns the engagement with every message, sorted by message ID.
+func (r *TrackingReport) Messages() []MessageEngagement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]MessageEngagement, 0, len(r.messages))
+	for _, m := range r.messages {
+		out = append(out, m.clone())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].MessageID < out[j].MessageID })
+	return out
+}
+
+// Labels returns the engagement with every label, sorted by label.
+func (r *TrackingReport) Labels() []LabelEngagement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	byLabel := make(map[string]*LabelEngagement)
+	for _, m := range r.messages {
+		l, ok := byLabel[m.Label]
+		if !ok {
+			l = &LabelEngagement{Label: m.Label}
+			byLabel[m.Label] = l
+		}
+		l.Messages++
+		l.Opens += m.Opens
+		l.Clicks += m.Clicks
+		l.Replies += m.Replies
+		if m.Opens > 0 {
+			l.Opened++
+		}
+		if m.Clicks > 0 {
+			l.Clicked++
+		}
+		if m.Replies > 0 {
+			l.Replied++
+		}
+	}
+	out := make([]LabelEngagement, 0, len(byLabel))
+	for _, l := range byLabel {
+		out = append(out, *l)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Label < out[j].Label })
+	return out
+}
+
+func (m *MessageEngagement) clone() MessageEngagement {
+	c := *m
+	c.Links = append([]LinkEngagement(nil), m.Links...)
+	return c
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,143 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func notification(t *testing.T, id, typ string, object any) *Notification {
+	t.Helper()
+	data, err := json.Marshal(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Notification{ID: id, Type: typ, Data: NotificationData{Object: data}}
+}
+
+func TestTrackingReport(t *testing.T) {
+	r := NewTrackingReport()
+	r.AddSent("msg-1", "promo")
+	r.AddSent("msg-2", "promo")
+	r.AddSent("msg-3", "promo")
+	r.AddSent("msg-4", "followup")
+
+	first := notification(t, "n1", TriggerMessageOpened, MessageOpened{
+		MessageID: "msg-1", Label: "promo",
+		MessageData: OpenData{Count: 1, Timestamp: 100},
+		Recents:     []Open{{OpenedID: 0, IP: "1.1.1.1", UserAgent: "A", Timestamp: 100}},
+	})
+	events := []*Notification{
+		first,
+		first, // retried delivery
+		notification(t, "n2", TriggerMessageOpened, MessageOpened{
+			MessageID: "msg-1", Label: "promo",
+			MessageData: OpenData{Count: 3, Timestamp: 300},
+			Recents: []Open{
+				{OpenedID: 0, IP: "1.1.1.1", UserAgent: "A", Timestamp: 100},
+				{OpenedID: 1, IP: "2.2.2.2", UserAgent: "B", Timestamp: 200},
+				{OpenedID: 2, IP: "1.1.1.1", UserAgent: "A", Timestamp: 300},
+			},
+		}),
+		notification(t, "n3", TriggerMessageLinkClicked, LinkClicked{
+			MessageID: "msg-1", Label: "promo",
+			LinkData: []LinkData{{URL: "https://a.test", Count: 2}, {URL: "https://b.test", Count: 0}},
+			Recents:  []Click{{LinkIndex: 0, Timestamp: 250}},
+		}),
+		notification(t, "n4", TriggerMessageLinkClicked, LinkClicked{
+			MessageID: "msg-1",
+			LinkData:  []LinkData{{URL: "https://a.test", Count: 2}, {URL: "https://b.test", Count: 1}},
+		}),
+		notification(t, "n5", TriggerThreadReplied, ThreadReplied{
+			MessageID: "reply-1", RootMessageID: "msg-2", ThreadID: "thread-2", Label: "promo",
+			Timestamp: 400, ReplyData: ReplyData{Count: 1},
+		}),
+		notification(t, "n6", "message.created", map[string]string{"id": "x"}),
+	}
+	for _, n := range events {
+		if err := r.Add(n); err != nil {
+			t.Fatalf("Add(%s) error = %v", n.ID, err)
+		}
+	}
+
+	m, ok := r.Message("msg-1")
+	if !ok {
+		t.Fatal("Message(msg-1) not found")
+	}
+	if m.Opens != 3 || m.Openers != 2 || m.FirstOpened.Unix() != 100 || m.LastOpened.Unix() != 300 {
+		t.Errorf("opens = %d, openers = %d, first = %d, last = %d", m.Opens, m.Openers, m.FirstOpened.Unix(), m.LastOpened.Unix())
+	}
+	if m.Clicks != 3 || len(m.Links) != 2 || m.Links[1].Clicks != 1 || m.Links[0].URL != "https://a.test" {
+		t.Errorf("clicks = %d, links = %+v", m.Clicks, m.Links)
+	}
+	if m.LastClicked.Unix() != 250 {
+		t.Errorf("LastClicked = %d", m.LastClicked.Unix())
+	}
+
+	m2, _ := r.Message("msg-2")
+	if m2.Replies != 1 || m2.ThreadID != "thread-2" || m2.LastReplied.Unix() != 400 {
+		t.Errorf("msg-2 = %+v", m2)
+	}
+
+	labels := r.Labels()
+	if len(labels) != 2 || labels[0].Label != "followup" || labels[1].Label != "promo" {
+		t.Fatalf("Labels() = %+v", labels)
+	}
+	promo := labels[1]
+	if promo.Messages != 3 || promo.Opened != 1 || promo.Clicked != 1 || promo.Replied != 1 {
+		t.Errorf("promo = %+v", promo)
+	}
+	if promo.Opens != 3 || promo.Clicks != 3 || promo.Replies != 1 {
+		t.Errorf("promo totals = %+v", promo)
+	}
+	if got := promo.OpenRate(); got < 0.33 || got > 0.34 {
+		t.Errorf("OpenRate() = %v, want 1/3", got)
+	}
+	if got := labels[0].ReplyRate(); got != 0 {
+		t.Errorf("followup ReplyRate() = %v, want 0", got)
+	}
+	if got := len(r.Messages()); got != 4 {
+		t.Errorf("len(Messages()) = %d, want 4", got)
+	}
+}
+
+func TestTrackingReport_Concurrent(t *testing.T) {
+	r := NewTrackingReport()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r.AddOpened(&MessageOpened{MessageID: "msg-1", MessageData: OpenData{Count: i}})
+			_ = r.Labels()
+		}(i)
+	}
+	wg.Wait()
+	if m, _ := r.Message("msg-1"); m.Opens != 19 {
+		t.Errorf("Opens = %d, want 19", m.Opens)
+	}
+}
+
+func TestTrackingReport_SeenBounded(t *testing.T) {
+	r := NewTrackingReport()
+	r.seenLimit = 3
+	reply := func(id string) *Notification {
+		return notification(t, id, TriggerThreadReplied, ThreadReplied{RootMessageID: "msg-1", ReplyData: ReplyData{Count: 1}})
+	}
+	for _, id := range []string{"n1", "n2", "n3", "n4", "n5"} {
+		if err := r.Add(reply(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(r.seen) != 3 || r.seen["n1"] || r.seen["n2"] || !r.seen["n5"] {
+		t.Errorf("seen = %v, want the last 3 IDs", r.seen)
+	}
+	// A forgotten ID is recorded again, but the totals stay the latest counts.
+	_ = r.Add(reply("n1"))
+	if m, _ := r.Message("msg-1"); m.Replies != 1 {
+		t.Errorf("Replies = %d, want 1", m.Replies)
+	}
+	if len(r.seen) != 3 || !r.seen["n1"] || r.seen["n3"] {
+		t.Errorf("seen = %v after redelivery", r.seen)
+	}
+}
@@ -0,0 +1,244 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Trigger types of the notifications sent for messages sent with
+// common.TrackingOptions.
+const (
+	// TriggerMessageOpened is sent when a recipient opens a message tracked with Opens.
+	TriggerMessageOpened = "message.opened"
+	// TriggerMessageLinkClicked is sent when a recipient clicks a link in a message
+	// tracked with Links.
+	TriggerMessageLinkClicked = "message.link_clicked"
+	// TriggerThreadReplied is sent when a recipient replies to a message tracked with
+	// ThreadReplies.
+	TriggerThreadReplied = "thread.replied"
+)
+
+// Notification is the body of a webhook notification.
+type Notification struct {
+	// SpecVersion is the CloudEvents version of the envelope, "1.0".
+	SpecVersion string `json:"specversion,omitempty"`
+	// Type is the trigger type, e.g. TriggerMessageOpened.
+	Type string `json:"type"`
+	// Source identifies what produced the notification, e.g. "/com/nylas/tracking".
+	Source string `json:"source,omitempty"`
+	// ID is the unique ID of the notification. Retried deliveries keep the same ID.
+	ID string `json:"id"`
+	// Time is the Unix timestamp when the notification was created.
+	Time int64 `json:"time"`
+	// DeliveryAttempt is 1 for the first delivery and counts up on retries.
+	DeliveryAttempt int `json:"webhook_delivery_attempt,omitempty"`
+	// Data holds the object the notification is about.
+	Data NotificationData `json:"data"`
+}
+
+// NotificationData is the data of a Notification.
+type NotificationData struct {
+	// ApplicationID is the ID of the Nylas application.
+	ApplicationID string `json:"application_id,omitempty"`
+	// GrantID is the grant the notification is about, if any.
+	GrantID string `json:"grant_id,omitempty"`
+	// Object is the JSON object the notification is about. Decode it with the
+	// typed accessors, such as MessageOpened, or with json.Unmarshal.
+	Object json.RawMessage `json:"object"`
+}
+
+// ParseNotification decodes the body of a webhook notification. It does not verify
+// the signature.
+func ParseNotification(body []byte) (*Notification, error) {
+	var n Notification
+	if err := json.Unmarshal(body, &n); err != nil {
+		return nil, fmt.Errorf("webhooks: parse notification: %w", err)
+	}
+	return &n, nil
+}
+
+// MessageOpened decodes the object of a TriggerMessageOpened notification.
+func (n *Notification) MessageOpened() (*MessageOpened, error) {
+	var e MessageOpened
+	if err := n.decode(TriggerMessageOpened, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// LinkClicked decodes the object of a TriggerMessageLinkClicked notification.
+func (n *Notification) LinkClicked() (*LinkClicked, error) {
+	var e LinkClicked
+	if err := n.decode(TriggerMessageLinkClicked, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// ThreadReplied decodes the object of a TriggerThreadReplied notification.
+func (n *Notification) ThreadReplied() (*ThreadReplied, error) {
+	var e ThreadReplied
+	if err := n.decode(TriggerThreadReplied, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (n *Notification) decode(trigger string, v any) error {
+	if n.Type != trigger {
+		return fmt.Errorf("webhooks: notification %s is %q, not %q", n.ID, n.Type, trigger)
+	}
+	if err := json.Unmarshal(n.Data.Object, v); err != nil {
+		return fmt.Errorf("webhooks: decode %s notification %s: %w", trigger, n.ID, err)
+	}
+	return nil
+}
+
+// MessageOpened is the object of a message.opened notification. Nylas does not say
+// which recipient opened the message; Recents gives the IP address and user agent
+// of the latest opens.
+type MessageOpened struct {
+	// MessageID is the ID of the tracked message.
+	MessageID string `json:"message_id"`
+	// Label is the label set in the message's TrackingOptions.
+	Label string `json:"label,omitempty"`
+	// SenderAppID is the ID of the application that sent the message.
+	SenderAppID string `json:"sender_app_id,omitempty"`
+	// Timestamp is the Unix timestamp of the notification.
+	Timestamp int64 `json:"timestamp"`
+	// MessageData counts the opens of the message so far.
+	MessageData OpenData `json:"message_data"`
+	// Recents lists the latest opens, oldest first.
+	Recents []Open `json:"recents,omitempty"`
+}
+
+// OpenData counts the opens of a tracked message.
+type OpenData struct {
+	// Count is the total number of opens so far.
+	Count int `json:"count"`
+	// Timestamp is the Unix timestamp of the latest open.
+	Timestamp int64 `json:"timestamp,omitempty"`
+}
+
+// Open is one open of a tracked message.
+type Open struct {
+	// OpenedID numbers the opens of the message, starting at 0.
+	OpenedID int `json:"opened_id"`
+	// IP is the IP address that loaded the tracking pixel.
+	IP string `json:"ip,omitempty"`
+	// UserAgent is the user agent that loaded the tracking pixel.
+	UserAgent string `json:"user_agent,omitempty"`
+	// Timestamp is the Unix timestamp of the open.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Time returns Timestamp as a time.Time.
+func (o Open) Time() time.Time { return time.Unix(o.Timestamp, 0) }
+
+// UnmarshalJSON decodes o, accepting OpenedID as a number or a string.
+func (o *Open) UnmarshalJSON(data []byte) error {
+	type alias Open
+	aux := struct {
+		*alias
+		OpenedID json.Number `json:"opened_id"`
+	}{alias: (*alias)(o)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	o.OpenedID, err = numberToInt(aux.OpenedID)
+	return err
+}
+
+// LinkClicked is the object of a message.link_clicked notification.
+type LinkClicked struct {
+	// MessageID is the ID of the tracked message.
+	MessageID string `json:"message_id"`
+	// Label is the label set in the message's TrackingOptions.
+	Label string `json:"label,omitempty"`
+	// SenderAppID is the ID of the application that sent the message.
+	SenderAppID string `json:"sender_app_id,omitempty"`
+	// Timestamp is the Unix timestamp of the notification.
+	Timestamp int64 `json:"timestamp"`
+	// LinkData counts the clicks of each tracked link so far, indexed by link index.
+	LinkData []LinkData `json:"link_data,omitempty"`
+	// Recents lists the latest clicks, oldest first.
+	Recents []Click `json:"recents,omitempty"`
+}
+
+// LinkData counts the clicks of one tracked link.
+type LinkData struct {
+	// URL is the link's target.
+	URL string `json:"url"`
+	// Count is the total number of clicks so far.
+	Count int `json:"count"`
+}
+
+// Click is one click of a tracked link.
+type Click struct {
+	// ClickID numbers the clicks of the message, starting at 0.
+	ClickID int `json:"click_id"`
+	// LinkIndex is the index of the link in LinkData.
+	LinkIndex int `json:"link_index"`
+	// IP is the IP address of the click.
+	IP string `json:"ip,omitempty"`
+	// UserAgent is the user agent of the click.
+	UserAgent string `json:"user_agent,omitempty"`
+	// Timestamp is the Unix timestamp of the click.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Time returns Timestamp as a time.Time.
+func (c Click) Time() time.Time { return time.Unix(c.Timestamp, 0) }
+
+// UnmarshalJSON decodes c, accepting ClickID and LinkIndex as numbers or strings.
+func (c *Click) UnmarshalJSON(data []byte) error {
+	type alias Click
+	aux := struct {
+		*alias
+		ClickID   json.Number `json:"click_id"`
+		LinkIndex json.Number `json:"link_index"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if c.ClickID, err = numberToInt(aux.ClickID); err != nil {
+		return err
+	}
+	c.LinkIndex, err = numberToInt(aux.LinkIndex)
+	return err
+}
+
+// ThreadReplied is the object of a thread.replied notification.
+type ThreadReplied struct {
+	// MessageID is the ID of the reply.
+	MessageID string `json:"message_id"`
+	// RootMessageID is the ID of the tracked message that was replied to.
+	RootMessageID string `json:"root_message_id"`
+	// ThreadID is the ID of the thread.
+	ThreadID string `json:"thread_id,omitempty"`
+	// Label is the label set in the tracked message's TrackingOptions.
+	Label string `json:"label,omitempty"`
+	// SenderAppID is the ID of the application that sent the tracked message.
+	SenderAppID string `json:"sender_app_id,omitempty"`
+	// Timestamp is the Unix timestamp of the notification.
+	Timestamp int64 `json:"timestamp"`
+	// ReplyData counts the replies to the tracked message so far.
+	ReplyData ReplyData `json:"reply_data"`
+}
+
+// ReplyData counts the replies to a tracked message.
+type ReplyData struct {
+	// Count is the total number of replies so far.
+	Count int `json:"count"`
+}
+
+func numberToInt(n json.Number) (int, error) {
+	if n == "" {
+		return 0, nil
+	}
+	i, err := n.Int64()
+	return int(i), err
+}
@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"testing"
+)
+
+const openedJSON = `{
+  "specversion": "1.0",
+  "type": "message.opened",
+  "source": "/com/nylas/tracking",
+  "id": "notif-1",
+  "time": 1695480423,
+  "webhook_delivery_attempt": 1,
+  "data": {
+    "application_id": "app-1",
+    "grant_id": "grant-1",
+    "object": {
+      "message_id": "msg-1",
+      "label": "q3-outreach",
+      "sender_app_id": "app-1",
+      "timestamp": 1695480422,
+      "message_data": {"count": 2, "timestamp": 1695480410},
+      "recents": [
+        {"opened_id": 0, "ip": "1.2.3.4", "user_agent": "Mail", "timestamp": 1695480400},
+        {"opened_id": "1", "ip": "1.2.3.4", "user_agent": "Mail", "timestamp": 1695480410}
+      ]
+    }
+  }
+}`
+
+func TestParseNotification_MessageOpened(t *testing.T) {
+	n, err := ParseNotification([]byte(openedJSON))
+	if err != nil {
+		t.Fatalf("ParseNotification() error = %v", err)
+	}
+	if n.Type != TriggerMessageOpened || n.Data.GrantID != "grant-1" || n.DeliveryAttempt != 1 {
+		t.Errorf("Notification = %+v", n)
+	}
+
+	e, err := n.MessageOpened()
+	if err != nil {
+		t.Fatalf("MessageOpened() error = %v", err)
+	}
+	if e.MessageID != "msg-1" || e.Label != "q3-outreach" || e.MessageData.Count != 2 {
+		t.Errorf("MessageOpened = %+v", e)
+	}
+	if len(e.Recents) != 2 || e.Recents[1].OpenedID != 1 || e.Recents[1].UserAgent != "Mail" {
+		t.Errorf("Recents = %+v", e.Recents)
+	}
+
+	if _, err := n.LinkClicked(); err == nil {
+		t.Error("LinkClicked() on message.opened: expected error")
+	}
+}
+
+func TestNotification_LinkClicked(t *testing.T) {
+	n := &Notification{Type: TriggerMessageLinkClicked, Data: NotificationData{Object: []byte(`{
+		"message_id": "msg-1",
+		"link_data": [{"url": "https://example.com/a", "count": 1}, {"url": "https://example.com/b", "count": 3}],
+		"recents": [{"click_id": "3", "link_index": "1", "ip": "5.6.7.8", "timestamp": 1695480500}]
+	}`)}}
+
+	e, err := n.LinkClicked()
+	if err != nil {
+		t.Fatalf("LinkClicked() error = %v", err)
+	}
+	if len(e.LinkData) != 2 || e.LinkData[1].Count != 3 {
+		t.Errorf("LinkData = %+v", e.LinkData)
+	}
+	if c := e.Recents[0]; c.ClickID != 3 || c.LinkIndex != 1 || c.Time().Unix() != 1695480500 {
+		t.Errorf("Recents[0] = %+v", c)
+	}
+}
+
+func TestNotification_ThreadReplied(t *testing.T) {
+	n := &Notification{Type: TriggerThreadReplied, Data: NotificationData{Object: []byte(`{
+		"message_id": "reply-1", "root_message_id": "msg-1", "thread_id": "thread-1",
+		"label": "q3-outreach", "timestamp": 1695480600, "reply_data": {"count": 1}
+	}`)}}
+
+	e, err := n.ThreadReplied()
+	if err != nil {
+		t.Fatalf("ThreadReplied() error = %v", err)
+	}
+	if e.RootMessageID != "msg-1" || e.ThreadID != "thread-1" || e.ReplyData.Count != 1 {
+		t.Errorf("ThreadReplied = %+v", e)
+	}
+}
+
+func TestParseNotification_Invalid(t *testing.T) {
+	if _, err := ParseNotification([]byte(`{`)); err == nil {
+		t.Error("expected error")
+	}
+	n := &Notification{Type: TriggerThreadReplied, Data: NotificationData{Object: []byte(`[]`)}}
+	if _, err := n.ThreadReplied(); err == nil {
+		t.Error("expected error for bad object")
+	}
+}
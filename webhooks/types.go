@@ -1,5 +1,7 @@
 package webhooks
 
+import "encoding/json"
+
 // Webhook represents a webhook subscription in the Nylas API.
 // Webhooks deliver notifications when events occur in connected accounts.
 type Webhook struct {
@@ -24,6 +26,9 @@ type Webhook struct {
 	CreatedAt int64 `json:"created_at,omitempty"`
 	// UpdatedAt is the Unix timestamp when the webhook was last modified.
 	UpdatedAt int64 `json:"updated_at,omitempty"`
+	// Extra holds fields returned by the API that this type does not declare.
+	// They are written back out when the value is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ListOptions specifies options for listing webhooks.
@@ -0,0 +1,44 @@
+package webhooks
+
+import "github.com/mqasimca/nylas-go/common"
+
+// Validate checks the request for problems the API would reject.
+func (r *CreateRequest) Validate() error {
+	v := &common.ValidationError{}
+	if len(r.TriggerTypes) == 0 {
+		v.Add("trigger_types", "at least one trigger type is required")
+	}
+	checkTriggerTypes(v, r.TriggerTypes)
+	if r.WebhookURL == "" {
+		v.Add("webhook_url", "is required")
+	} else {
+		common.CheckURL(v, "webhook_url", r.WebhookURL, "https")
+	}
+	checkEmails(v, r.NotificationEmailAddresses)
+	return v.Err()
+}
+
+// Validate checks the request for problems the API would reject.
+func (r *UpdateRequest) Validate() error {
+	v := &common.ValidationError{}
+	checkTriggerTypes(v, r.TriggerTypes)
+	if r.WebhookURL != nil {
+		common.CheckURL(v, "webhook_url", *r.WebhookURL, "https")
+	}
+	checkEmails(v, r.NotificationEmailAddresses)
+	return v.Err()
+}
+
+func checkTriggerTypes(v *common.ValidationError, types []string) {
+	for i, t := range types {
+		if t == "" {
+			v.Add(common.IndexPath("trigger_types", i), "must not be empty")
+		}
+	}
+}
+
+func checkEmails(v *common.ValidationError, emails []string) {
+	for i, e := range emails {
+		common.CheckEmail(v, common.IndexPath("notification_email_addresses", i), e)
+	}
+}
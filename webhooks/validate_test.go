@@ -0,0 +1,35 @@
+package webhooks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mqasimca/nylas-go/common"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantPath string
+	}{
+		{"webhook url scheme", (&CreateRequest{TriggerTypes: []string{"message.created"}, WebhookURL: "http://example.com/hook"}).Validate(), "webhook_url"},
+		{"empty trigger type", (&UpdateRequest{TriggerTypes: []string{""}}).Validate(), "trigger_types[0]"},
+		{"notification email format", (&UpdateRequest{NotificationEmailAddresses: []string{"ops"}}).Validate(), "notification_email_addresses[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPath == "-" {
+				if tt.err != nil {
+					t.Errorf("Validate() error = %v, want nil", tt.err)
+				}
+				return
+			}
+			var verr *common.ValidationError
+			if !errors.As(tt.err, &verr) || verr.Fields[0].Path != tt.wantPath {
+				t.Errorf("Validate() error = %v, want problem at %q", tt.err, tt.wantPath)
+			}
+		})
+	}
+}